@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+
+	"github.com/salex06/pr-service/internal/openapi"
+)
+
+// contract выполняет запросы к тестовому серверу и проверяет,
+// что запросы и ответы соответствуют спецификации openapi.json
+type contract struct {
+	srv    *testServer
+	router routers.Router
+}
+
+func newContract(t *testing.T) *contract {
+	t.Helper()
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(openapi.Spec)
+	if err != nil {
+		t.Fatalf("load openapi spec: %v", err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		t.Fatalf("openapi spec is invalid: %v", err)
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		t.Fatalf("build openapi router: %v", err)
+	}
+
+	return &contract{srv: newTestServer(t), router: router}
+}
+
+// check выполняет запрос, проверяет код ответа и соответствие
+// запроса и ответа описанию операции в спецификации
+func (c *contract) check(t *testing.T, wantStatus int, method, path, token string, body any, headers ...string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := c.srv.mustDo(t, wantStatus, method, path, token, body, headers...)
+
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+
+	route, pathParams, err := c.router.FindRoute(req)
+	if err != nil {
+		t.Fatalf("%s %s is not described in openapi.json: %v", method, path, err)
+	}
+
+	requestInput := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+	}
+	// Запросы с заведомо неверным телом проверяют ответ 400, а не сам запрос
+	if wantStatus != http.StatusBadRequest {
+		if err := openapi3filter.ValidateRequest(context.Background(), requestInput); err != nil {
+			t.Errorf("%s %s: request does not match openapi.json: %v", method, path, err)
+		}
+	}
+
+	responseInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: requestInput,
+		Status:                 rec.Code,
+		Header:                 rec.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(rec.Body.Bytes())),
+		Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+	}
+	if err := openapi3filter.ValidateResponse(context.Background(), responseInput); err != nil {
+		t.Errorf("%s %s: %d response does not match openapi.json: %v", method, path, rec.Code, err)
+	}
+
+	return rec
+}
+
+func TestResponsesMatchOpenAPISpec(t *testing.T) {
+	c := newContract(t)
+
+	team := map[string]any{
+		"team_name": "backend",
+		"members": []map[string]any{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Carol", "is_active": true},
+			{"user_id": "u5", "username": "Erin", "is_active": true},
+		},
+	}
+
+	// Команды и сотрудники
+	c.check(t, http.StatusCreated, http.MethodPost, "/api/v1/teams", "admin-token", team)
+	c.check(t, http.StatusCreated, http.MethodPost, "/team/add", "admin-token", map[string]any{
+		"team_name": "frontend",
+		"members":   []map[string]any{{"user_id": "u4", "username": "Dave", "is_active": true}},
+	})
+	c.check(t, http.StatusBadRequest, http.MethodPost, "/api/v1/teams", "admin-token", map[string]any{"team_name": ""})
+	c.check(t, http.StatusOK, http.MethodGet, "/api/v1/teams/backend", "u1-token", nil)
+	c.check(t, http.StatusOK, http.MethodGet, "/team/get?team_name=backend", "u1-token", nil)
+	c.check(t, http.StatusNotFound, http.MethodGet, "/api/v1/teams/missing", "u1-token", nil)
+	c.check(t, http.StatusUnauthorized, http.MethodGet, "/api/v1/teams/backend", "", nil)
+	c.check(t, http.StatusOK, http.MethodPut, "/api/v1/teams/backend", "admin-token", team)
+	c.check(t, http.StatusOK, http.MethodGet, "/api/v1/users/u2", "u1-token", nil)
+	c.check(t, http.StatusOK, http.MethodGet, "/api/v1/users/u2/reviews", "u1-token", nil)
+
+	// PR's
+	created := c.check(t, http.StatusCreated, http.MethodPost, "/api/v1/pull-requests", "u1-token", map[string]any{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add search",
+		"author_id":         "u1",
+		"repository":        "salex06/pr-service",
+		"labels":            []string{"backend"},
+		"lines_changed":     120,
+	})
+	c.check(t, http.StatusCreated, http.MethodPost, "/pullRequest/create", "admin-token", map[string]any{
+		"pull_request_id": "pr-2", "pull_request_name": "Fix typo", "author_id": "u2",
+	})
+	c.check(t, http.StatusConflict, http.MethodPost, "/api/v1/pull-requests", "u1-token", map[string]any{
+		"pull_request_id": "pr-1", "pull_request_name": "Add search", "author_id": "u1",
+	})
+	c.check(t, http.StatusOK, http.MethodPost, "/api/v1/pull-requests/batch", "service-token", map[string]any{
+		"pull_requests": []map[string]any{
+			{"pull_request_id": "pr-3", "pull_request_name": "Bump deps", "author_id": "u3"},
+		},
+	})
+	c.check(t, http.StatusForbidden, http.MethodPost, "/api/v1/pull-requests/batch", "u1-token", map[string]any{
+		"pull_requests": []map[string]any{
+			{"pull_request_id": "pr-4", "pull_request_name": "Bump deps", "author_id": "u1"},
+		},
+	})
+	c.check(t, http.StatusOK, http.MethodGet, "/api/v1/pull-requests/pr-1", "u2-token", nil)
+	c.check(t, http.StatusOK, http.MethodGet, "/api/v1/pull-requests?label=backend&status=OPEN", "u2-token", nil)
+	c.check(t, http.StatusOK, http.MethodGet, "/pullRequest/list?author_id=u1", "u2-token", nil)
+
+	var pr struct {
+		PR struct {
+			AssignedReviewers []string `json:"assigned_reviewers"`
+		} `json:"pr"`
+	}
+	if err := json.Unmarshal(created.Body.Bytes(), &pr); err != nil || len(pr.PR.AssignedReviewers) == 0 {
+		t.Fatalf("created PR has no reviewers: %s", created.Body.String())
+	}
+	reviewer := pr.PR.AssignedReviewers[0]
+
+	c.check(t, http.StatusCreated, http.MethodPost, "/api/v1/pull-requests/pr-1/verdicts", "admin-token",
+		map[string]any{"user_id": reviewer, "verdict": "APPROVED"})
+	c.check(t, http.StatusOK, http.MethodPost, "/api/v1/pull-requests/pr-1/reassign", "u1-token",
+		map[string]any{"old_reviewer_id": reviewer})
+	c.check(t, http.StatusOK, http.MethodGet, "/api/v1/pull-requests/pr-1/reviewer-history", "u2-token", nil)
+	c.check(t, http.StatusPreconditionFailed, http.MethodPost, "/api/v1/pull-requests/pr-1/merge", "u1-token", nil,
+		"If-Match", `"0"`)
+	c.check(t, http.StatusOK, http.MethodPost, "/api/v1/pull-requests/pr-1/merge", "u1-token", nil)
+	c.check(t, http.StatusOK, http.MethodPost, "/pullRequest/merge", "u1-token", map[string]any{"pull_request_id": "pr-1"})
+	c.check(t, http.StatusConflict, http.MethodPost, "/api/v1/pull-requests/pr-1/reassign", "u1-token",
+		map[string]any{"old_reviewer_id": reviewer})
+	c.check(t, http.StatusNotFound, http.MethodPost, "/api/v1/pull-requests/missing/merge", "admin-token", nil)
+
+	// Статистика, деактивация и аудит
+	c.check(t, http.StatusOK, http.MethodGet, "/api/v1/stats?team=backend&granularity=week", "u1-token", nil)
+	c.check(t, http.StatusOK, http.MethodGet, "/stats", "u1-token", nil)
+	c.check(t, http.StatusOK, http.MethodGet, "/api/v1/stats/fairness", "u1-token", nil)
+	c.check(t, http.StatusOK, http.MethodPatch, "/api/v1/users/u3", "backend-lead-token", map[string]any{"is_active": false})
+	c.check(t, http.StatusOK, http.MethodPost, "/api/v1/teams/frontend/deactivate", "frontend-lead-token", nil)
+	c.check(t, http.StatusForbidden, http.MethodGet, "/api/v1/audit/records", "u1-token", nil)
+	c.check(t, http.StatusOK, http.MethodGet, "/api/v1/audit/records", "admin-token", nil)
+	c.check(t, http.StatusOK, http.MethodGet, "/api/v1/audit/verify", "admin-token", nil)
+}
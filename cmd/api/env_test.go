@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/auth"
+	"github.com/salex06/pr-service/internal/middleware"
+	auditRepository "github.com/salex06/pr-service/internal/repos/audit"
+	batchRepository "github.com/salex06/pr-service/internal/repos/batch"
+	idempotencyRepository "github.com/salex06/pr-service/internal/repos/idempotency"
+	prRepository "github.com/salex06/pr-service/internal/repos/pr"
+	revsRepository "github.com/salex06/pr-service/internal/repos/reviewers"
+	teamRepository "github.com/salex06/pr-service/internal/repos/team"
+	userRepository "github.com/salex06/pr-service/internal/repos/user"
+	"github.com/salex06/pr-service/internal/rest"
+	"github.com/salex06/pr-service/internal/service"
+)
+
+// testTokens - статические токены клиентов тестового сервера
+const testTokens = "admin-token=admin:admin;" +
+	"backend-lead-token=lead-backend:team-lead:backend;" +
+	"frontend-lead-token=lead-frontend:team-lead:frontend;" +
+	"u1-token=u1:member:backend;" +
+	"u2-token=u2:member:backend;" +
+	"service-token=ci:service"
+
+var registerValidators sync.Once
+
+// testServer связывает роутер REST API с in-memory репозиториями
+type testServer struct {
+	router *gin.Engine
+
+	teamRepo        teamRepository.TeamRepository
+	userRepo        userRepository.UserRepository
+	prRepo          prRepository.PullRequestRepository
+	revsRepo        revsRepository.AssignedRevsRepository
+	auditRepo       auditRepository.AuditRepository
+	idempotencyRepo idempotencyRepository.IdempotencyRepository
+}
+
+// newTestServer собирает роутер так же, как main, но поверх in-memory
+// репозиториев: middlewares выполняются перед аутентификацией (как otelgin и RequestID)
+func newTestServer(t *testing.T, middlewares ...gin.HandlerFunc) *testServer {
+	t.Helper()
+
+	registerValidators.Do(func() {
+		if err := rest.RegisterValidators(); err != nil {
+			t.Fatalf("RegisterValidators: %v", err)
+		}
+	})
+
+	prRepo := prRepository.NewInMemoryPullRequestRepository()
+	srv := &testServer{
+		teamRepo:        teamRepository.NewInMemoryTeamRepository(),
+		userRepo:        userRepository.NewInMemoryUserRepository(),
+		prRepo:          prRepo,
+		revsRepo:        revsRepository.NewInMemoryAssignedRevsRepository(prRepo),
+		auditRepo:       auditRepository.NewInMemoryAuditRepository(),
+		idempotencyRepo: idempotencyRepository.NewInMemoryIdempotencyRepository(),
+	}
+	return srv.build(t, middlewares...)
+}
+
+// build собирает сервисы и роутер поверх репозиториев сервера
+// (репозитории можно подменить до вызова)
+func (srv *testServer) build(t *testing.T, middlewares ...gin.HandlerFunc) *testServer {
+	t.Helper()
+
+	authenticator, err := auth.NewStaticTokenAuthenticator(testTokens)
+	if err != nil {
+		t.Fatalf("NewStaticTokenAuthenticator: %v", err)
+	}
+
+	auditService := service.NewAuditService(&srv.auditRepo)
+	statService := service.NewStatsService(&srv.prRepo, &srv.revsRepo, &srv.userRepo, &srv.teamRepo)
+	teamService := service.NewTeamService(&srv.teamRepo, &srv.userRepo, &srv.revsRepo, &srv.prRepo, auditService, nil)
+	userService := service.NewUserService(&srv.userRepo, &srv.revsRepo, &srv.prRepo, auditService, nil)
+	var batchRepo batchRepository.PullRequestBatchRepository = batchRepository.NewInMemoryPullRequestBatchRepository(srv.prRepo, srv.revsRepo)
+	pullRequestService := service.NewPullRequestService(&srv.prRepo, &srv.revsRepo, &srv.userRepo, &srv.teamRepo, &batchRepo,
+		auditService, service.NewAssignmentEventBroker(), nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middlewares...)
+	r.Use(middleware.RequestID(), middleware.Errors())
+
+	idempotency := middleware.Idempotency(&srv.idempotencyRepo, time.Hour, time.Minute)
+	authMiddleware := middleware.Authenticate(authenticator)
+	legacy := r.Group("/", authMiddleware, middleware.AuditMeta(), idempotency)
+	v1 := r.Group("/api/v1", authMiddleware, middleware.AuditMeta(), idempotency)
+
+	setupRoutes(&handlers{
+		team:        rest.NewTeamHandler(teamService),
+		user:        rest.NewUserHandler(userService),
+		pullRequest: rest.NewPullRequestHandler(pullRequestService),
+		stats:       rest.NewStatHandler(statService),
+		audit:       rest.NewAuditHandler(auditService),
+	}, legacy, v1, auth.NewPolicy(&srv.userRepo, &srv.prRepo))
+
+	srv.router = r
+	return srv
+}
+
+// do выполняет запрос от имени владельца token (пустая строка - без токена)
+// с телом body, сериализованным в JSON, и заголовками headers (пары ключ-значение)
+func (srv *testServer) do(t *testing.T, method, path, token string, body any, headers ...string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			t.Fatalf("marshal request body: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// mustDo выполняет запрос и проверяет код ответа
+func (srv *testServer) mustDo(t *testing.T, wantStatus int, method, path, token string, body any, headers ...string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := srv.do(t, method, path, token, body, headers...)
+	if rec.Code != wantStatus {
+		t.Fatalf("%s %s: status = %d, want %d, body: %s", method, path, rec.Code, wantStatus, rec.Body.String())
+	}
+	return rec
+}
+
+// addTeam создает команду из активных сотрудников с заданными идентификаторами
+func (srv *testServer) addTeam(t *testing.T, teamName string, userIDs ...string) {
+	t.Helper()
+
+	members := make([]map[string]any, 0, len(userIDs))
+	for _, userID := range userIDs {
+		members = append(members, map[string]any{"user_id": userID, "username": userID, "is_active": true})
+	}
+	srv.mustDo(t, http.StatusCreated, http.MethodPost, "/api/v1/teams", "admin-token",
+		map[string]any{"team_name": teamName, "members": members})
+}
+
+// errorCode возвращает код ошибки из тела ответа
+func errorCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var resp struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal error response %q: %v", rec.Body.String(), err)
+	}
+	return resp.Error.Code
+}
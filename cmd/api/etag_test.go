@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// etag возвращает текущее значение заголовка ETag ресурса path
+func (srv *testServer) etag(t *testing.T, path string) string {
+	t.Helper()
+
+	etag := srv.mustDo(t, http.StatusOK, http.MethodGet, path, "admin-token", nil).Header().Get("ETag")
+	if etag == "" {
+		t.Fatalf("GET %s: no ETag header", path)
+	}
+	return etag
+}
+
+// assertPreconditionFailed проверяет, что изменение с устаревшим If-Match
+// отклонено с кодом 412 и кодом ошибки PRECONDITION_FAILED
+func assertPreconditionFailed(t *testing.T, srv *testServer, method, path string, body any, staleETag string) {
+	t.Helper()
+
+	rec := srv.mustDo(t, http.StatusPreconditionFailed, method, path, "admin-token", body, "If-Match", staleETag)
+	if code := errorCode(t, rec); code != "PRECONDITION_FAILED" {
+		t.Fatalf("%s %s: error code = %s, want PRECONDITION_FAILED", method, path, code)
+	}
+}
+
+// touchUser изменяет сотрудника (деактивирует и снова активирует),
+// чтобы полученные ранее значения ETag устарели
+func (srv *testServer) touchUser(t *testing.T, userID string) {
+	t.Helper()
+
+	for _, isActive := range []bool{false, true} {
+		srv.mustDo(t, http.StatusOK, http.MethodPatch, "/api/v1/users/"+userID, "admin-token",
+			map[string]any{"is_active": isActive})
+	}
+}
+
+func TestIfMatchRejectsLostUpdates(t *testing.T) {
+	t.Run("user", func(t *testing.T) {
+		srv := newTestServer(t)
+		srv.addTeam(t, "backend", "u1", "u2")
+
+		stale := srv.etag(t, "/api/v1/users/u2")
+		srv.touchUser(t, "u2")
+
+		assertPreconditionFailed(t, srv, http.MethodPatch, "/api/v1/users/u2", map[string]any{"is_active": false}, stale)
+		user, err := srv.userRepo.GetUser(context.Background(), "u2")
+		if err != nil {
+			t.Fatalf("GetUser: %v", err)
+		}
+		if !user.IsActive {
+			t.Fatal("u2 deactivated by request with stale If-Match")
+		}
+
+		current := srv.etag(t, "/api/v1/users/u2")
+		rec := srv.mustDo(t, http.StatusOK, http.MethodPatch, "/api/v1/users/u2", "admin-token",
+			map[string]any{"is_active": false}, "If-Match", current)
+		if rec.Header().Get("ETag") == current {
+			t.Errorf("ETag %s did not change after update", current)
+		}
+	})
+
+	t.Run("pull request", func(t *testing.T) {
+		srv := newTestServer(t)
+		srv.addTeam(t, "backend", "u1", "u2", "u3", "u4")
+		created := srv.mustDo(t, http.StatusCreated, http.MethodPost, "/api/v1/pull-requests", "admin-token",
+			map[string]any{"pull_request_id": "pr-1", "pull_request_name": "Add search", "author_id": "u1"})
+
+		stale := created.Header().Get("ETag")
+		var resp struct {
+			PR struct {
+				AssignedReviewers []string `json:"assigned_reviewers"`
+			} `json:"pr"`
+		}
+		if err := json.Unmarshal(created.Body.Bytes(), &resp); err != nil || len(resp.PR.AssignedReviewers) == 0 {
+			t.Fatalf("created PR has no reviewers: %s", created.Body.String())
+		}
+		srv.mustDo(t, http.StatusOK, http.MethodPost, "/api/v1/pull-requests/pr-1/reassign", "admin-token",
+			map[string]any{"old_reviewer_id": resp.PR.AssignedReviewers[0]})
+
+		assertPreconditionFailed(t, srv, http.MethodPost, "/api/v1/pull-requests/pr-1/merge", nil, stale)
+		if pr, _ := srv.prRepo.GetPullRequest(context.Background(), "pr-1"); pr.Status != entity.OPEN {
+			t.Fatalf("PR status = %s after request with stale If-Match, want OPEN", pr.Status)
+		}
+
+		srv.mustDo(t, http.StatusOK, http.MethodPost, "/api/v1/pull-requests/pr-1/merge", "admin-token", nil,
+			"If-Match", srv.etag(t, "/api/v1/pull-requests/pr-1"))
+	})
+
+	t.Run("team", func(t *testing.T) {
+		srv := newTestServer(t)
+		srv.addTeam(t, "backend", "u1", "u2")
+
+		// ETag команды меняется и при изменении отдельного участника
+		stale := srv.etag(t, "/api/v1/teams/backend")
+		srv.touchUser(t, "u2")
+
+		assertPreconditionFailed(t, srv, http.MethodPost, "/api/v1/teams/backend/deactivate", nil, stale)
+		assertPreconditionFailed(t, srv, http.MethodPut, "/api/v1/teams/backend", map[string]any{
+			"members": []map[string]any{{"user_id": "u1", "username": "u1", "is_active": false}},
+		}, stale)
+		members, err := srv.userRepo.GetTeamMembers(context.Background(), "backend")
+		if err != nil {
+			t.Fatalf("GetTeamMembers: %v", err)
+		}
+		for _, member := range members {
+			if !member.IsActive {
+				t.Fatalf("%s deactivated by request with stale If-Match", member.UserID)
+			}
+		}
+
+		// Команды еще нет - условие If-Match не может выполниться
+		assertPreconditionFailed(t, srv, http.MethodPut, "/api/v1/teams/frontend", map[string]any{
+			"members": []map[string]any{{"user_id": "u4", "username": "u4", "is_active": true}},
+		}, stale)
+
+		srv.mustDo(t, http.StatusOK, http.MethodPost, "/api/v1/teams/backend/deactivate", "admin-token", nil,
+			"If-Match", srv.etag(t, "/api/v1/teams/backend"))
+	})
+}
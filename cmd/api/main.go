@@ -1,87 +1,372 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"google.golang.org/grpc"
 
+	"github.com/salex06/pr-service/internal/auth"
 	"github.com/salex06/pr-service/internal/config"
 	"github.com/salex06/pr-service/internal/database"
+	"github.com/salex06/pr-service/internal/digest"
+	"github.com/salex06/pr-service/internal/entity"
+	"github.com/salex06/pr-service/internal/grpcapi"
+	"github.com/salex06/pr-service/internal/jobs"
+	"github.com/salex06/pr-service/internal/logging"
+	"github.com/salex06/pr-service/internal/mail"
+	"github.com/salex06/pr-service/internal/metrics"
+	"github.com/salex06/pr-service/internal/middleware"
+	"github.com/salex06/pr-service/internal/migrator"
+	apiKeyRepository "github.com/salex06/pr-service/internal/repos/apikey"
+	auditRepository "github.com/salex06/pr-service/internal/repos/audit"
+	batchRepository "github.com/salex06/pr-service/internal/repos/batch"
+	digestRepository "github.com/salex06/pr-service/internal/repos/digest"
+	idempotencyRepository "github.com/salex06/pr-service/internal/repos/idempotency"
 	prRepository "github.com/salex06/pr-service/internal/repos/pr"
+	rateLimitRepository "github.com/salex06/pr-service/internal/repos/ratelimit"
 	revsRepository "github.com/salex06/pr-service/internal/repos/reviewers"
 	teamRepository "github.com/salex06/pr-service/internal/repos/team"
 	userRepository "github.com/salex06/pr-service/internal/repos/user"
 	"github.com/salex06/pr-service/internal/rest"
 	"github.com/salex06/pr-service/internal/service"
+	"github.com/salex06/pr-service/internal/tracing"
+	"github.com/salex06/pr-service/migrations"
 )
 
+// idempotencyCleanupPeriod - период удаления ключей идемпотентности с истекшим сроком хранения
+const idempotencyCleanupPeriod = time.Hour
+
 func init() {
 	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using system environment variables")
+		slog.Info("no .env file found, using system environment variables")
 	}
 }
 
 func main() {
+	slog.SetDefault(logging.New(config.LoadLogConfig(), os.Stdout))
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
+
+	tracingConfig := config.LoadTracingConfig()
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingConfig)
+	if err != nil {
+		slog.Error("configuring tracing failed", "error", err)
+		return
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("unable to flush traces", "error", err)
+		}
+	}()
+
 	dbConfig := config.LoadDBConfig()
 	appConfig := config.LoadAppConfig()
+	digestConfig := config.LoadDigestConfig()
+	authConfig := config.LoadAuthConfig()
+	rateLimitConfig := config.LoadRateLimitConfig()
+	cacheConfig := config.LoadCacheConfig()
 
 	// Подключение к БД
 	db, err := database.NewDB(dbConfig)
 	if err != nil {
-		log.Println(fmt.Errorf("connecting to database failed: %w", err))
+		slog.Error("connecting to database failed", "error", err)
 		return
 	}
 
+	// Применение миграций схемы БД
+	schemaMigrator, err := migrator.NewMigrator(db, migrations.FS)
+	if err != nil {
+		slog.Error("loading migrations failed", "error", err)
+		return
+	}
+	if appConfig.MigrateOnStartup {
+		if _, err := schemaMigrator.Up(context.Background()); err != nil {
+			slog.Error("applying migrations failed", "error", err)
+			return
+		}
+	}
+
 	// Инициализация и внедрение компонентов приложения
 	teamRepo := teamRepository.NewPostgresTeamRepository(db)
 	userRepo := userRepository.NewPostgresUserRepository(db)
 	revsRepo := revsRepository.NewPostgresAssignedRevsRepository(db)
 	pullRequestRepo := prRepository.NewPostgresPullRequestRepository(db)
+	batchRepo := batchRepository.NewPostgresPullRequestBatchRepository(db)
+	apiKeyRepo := apiKeyRepository.NewPostgresAPIKeyRepository(db)
+	auditRepo := auditRepository.NewPostgresAuditRepository(db)
+	idempotencyRepo := idempotencyRepository.NewPostgresIdempotencyRepository(db)
+	digestRunRepo := digestRepository.NewPostgresDigestRunRepository(db)
 
-	teamService := service.NewTeamService(&teamRepo, &userRepo)
-	userService := service.NewUserService(&userRepo, &revsRepo, &pullRequestRepo)
-	pullRequestService := service.NewPullRequestService(&pullRequestRepo, &revsRepo, &userRepo, &teamRepo)
-	statService := service.NewStatsService(&pullRequestRepo, &revsRepo, &userRepo, &teamRepo)
+	// Кеширование чтения команд, сотрудников и статистики
+	var statsCache service.CacheInvalidator
+	if cacheConfig.Enabled {
+		teamStore, userStore := teamRepo, userRepo
+		teamRepo = teamRepository.NewCachedTeamRepository(&teamStore, cacheConfig.TeamTTL, metrics.ObserveCacheRequest)
+		userRepo = userRepository.NewCachedUserRepository(&userStore, cacheConfig.UserTTL, metrics.ObserveCacheRequest)
+	}
+	var statService service.StatsProvider = service.NewStatsService(&pullRequestRepo, &revsRepo, &userRepo, &teamRepo)
+	if cacheConfig.Enabled {
+		cachedStatService := service.NewCachedStatsService(statService, cacheConfig.StatsTTL, metrics.ObserveCacheRequest)
+		statService, statsCache = cachedStatService, cachedStatService
+	}
+
+	auditService := service.NewAuditService(&auditRepo)
+	teamService := service.NewTeamService(&teamRepo, &userRepo, &revsRepo, &pullRequestRepo, auditService, statsCache)
+	userService := service.NewUserService(&userRepo, &revsRepo, &pullRequestRepo, auditService, statsCache)
+	assignmentEvents := service.NewAssignmentEventBroker()
+	pullRequestService := service.NewPullRequestService(&pullRequestRepo, &revsRepo, &userRepo, &teamRepo, &batchRepo, auditService, assignmentEvents, statsCache)
+	apiKeyService := service.NewAPIKeyService(&apiKeyRepo, authConfig.APIKeyRotationOverlap)
+
+	digestRenderer, err := digest.NewRenderer()
+	if err != nil {
+		slog.Error("loading digest templates failed", "error", err)
+		return
+	}
+	digestService := service.NewDigestService(userService, &userRepo, digestRenderer, newMailSender(digestConfig.SMTP))
 
 	teamHandler := rest.NewTeamHandler(teamService)
 	userHandler := rest.NewUserHandler(userService)
 	pullRequestHandler := rest.NewPullRequestHandler(pullRequestService)
 	statsHandler := rest.NewStatHandler(statService)
+	digestHandler := rest.NewDigestHandler(digestService)
+	apiKeyHandler := rest.NewAPIKeyHandler(apiKeyService)
+	auditHandler := rest.NewAuditHandler(auditService)
+	healthHandler := rest.NewHealthHandler(db, schemaMigrator)
+	openAPIHandler := rest.NewOpenAPIHandler()
+
+	// Остановка сервиса по SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Запуск фоновой рассылки дайджеста
+	var workers sync.WaitGroup
+	if digestConfig.Enabled {
+		jobCtx := logging.With(ctx, "job", "digest")
+		workers.Go(func() {
+			jobs.NewDigestJob(digestService, &digestRunRepo, digestConfig.Period).Run(jobCtx)
+		})
+	}
+
+	// Запуск фоновой очистки ключей идемпотентности
+	workers.Go(func() {
+		jobCtx := logging.With(ctx, "job", "idempotency_cleanup")
+		jobs.NewIdempotencyCleanupJob(&idempotencyRepo, idempotencyCleanupPeriod).Run(jobCtx)
+	})
+
+	authenticator, err := newAuthenticator(authConfig, auth.NewAPIKeyAuthenticator(&apiKeyRepo))
+	if err != nil {
+		slog.Error("configuring authentication failed", "error", err)
+		return
+	}
+	authMiddleware := middleware.Anonymous()
+	if authenticator != nil {
+		authMiddleware = middleware.Authenticate(authenticator)
+	}
+	policy := auth.NewPolicy(&userRepo, &pullRequestRepo)
 
-	r := gin.Default()
+	metrics.Registry.MustRegister(
+		metrics.NewPoolCollector(db),
+		metrics.NewDomainCollector(&pullRequestRepo, &userRepo),
+	)
+
+	if err := rest.RegisterValidators(); err != nil {
+		slog.Error("configuring request validation failed", "error", err)
+		return
+	}
+
+	r := gin.New()
+	if err := r.SetTrustedProxies(appConfig.TrustedProxies); err != nil {
+		slog.Error("configuring trusted proxies failed", "error", err)
+		return
+	}
+	r.Use(
+		gin.Recovery(),
+		otelgin.Middleware(tracingConfig.ServiceName),
+		middleware.RequestID(),
+		middleware.AccessLog(),
+		middleware.Metrics(),
+		middleware.Timeout(appConfig.RequestTimeout),
+		middleware.Errors(),
+	)
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+	r.GET("/healthz", healthHandler.HandleLivenessRequest)
+	r.GET("/readyz", healthHandler.HandleReadinessRequest)
+	r.GET("/openapi.json", openAPIHandler.HandleSpecRequest)
+	r.GET("/docs", openAPIHandler.HandleSwaggerUIRequest)
+	ipRateLimit, rateLimit := newRateLimitMiddlewares(rateLimitConfig)
+	idempotency := middleware.Idempotency(&idempotencyRepo, appConfig.IdempotencyTTL, appConfig.IdempotencyLease)
+	legacy := r.Group("/", ipRateLimit, authMiddleware, rateLimit, middleware.AuditMeta(), idempotency)
+	v1 := r.Group("/api/v1", ipRateLimit, authMiddleware, rateLimit, middleware.AuditMeta(), idempotency)
 
 	// Настройка эндпоинтов
-	setupTeamHandlers(teamHandler, r)
-	setupUserHandlers(userHandler, r)
-	setupPullRequestHandlers(pullRequestHandler, r)
-	setupStatRequestHandlers(statsHandler, r)
+	setupRoutes(&handlers{
+		team:        teamHandler,
+		user:        userHandler,
+		digest:      digestHandler,
+		pullRequest: pullRequestHandler,
+		stats:       statsHandler,
+		apiKey:      apiKeyHandler,
+		audit:       auditHandler,
+	}, legacy, v1, policy)
 
 	// Запуск сервера
-	err = r.Run(fmt.Sprintf(":%s", appConfig.ServerPort))
-	if err != nil {
-		log.Printf("unable to start server: %s\n", err)
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%s", appConfig.ServerPort),
+		Handler:           r,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	serverErr := make(chan error, 2)
+	go func() {
+		slog.Info("server started", "addr", server.Addr)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	// Запуск gRPC-сервера (GRPC_PORT пуст - gRPC API отключен)
+	var grpcServer *grpc.Server
+	if appConfig.GRPCPort != "" {
+		grpcServer = grpcapi.NewServer(&grpcapi.Services{
+			Team:        teamService,
+			User:        userService,
+			PullRequest: pullRequestService,
+			Stats:       statService,
+			Events:      assignmentEvents,
+		}, authenticator, policy, appConfig.RequestTimeout)
+
+		go func() {
+			listener, err := net.Listen("tcp", fmt.Sprintf(":%s", appConfig.GRPCPort))
+			if err != nil {
+				serverErr <- err
+				return
+			}
+			slog.Info("grpc server started", "addr", listener.Addr().String())
+			serverErr <- grpcServer.Serve(listener)
+		}()
+	}
+
+	select {
+	case err := <-serverErr:
+		slog.Error("unable to start server", "error", err)
+		stop()
+	case <-ctx.Done():
+		slog.Info("shutdown signal received, draining in-flight requests and workers")
+	}
+
+	assignmentEvents.Close()
+	if grpcServer != nil {
+		stopGRPCServer(grpcServer, appConfig.ShutdownTimeout)
+	}
+	shutdown(server, healthHandler, &workers, appConfig.ShutdownTimeout)
+	db.Close()
+	slog.Info("server stopped")
+}
+
+// stopGRPCServer прекращает прием новых вызовов и дожидается завершения
+// обрабатываемых (не дольше timeout, после чего вызовы прерываются)
+func stopGRPCServer(server *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		slog.Warn("grpc calls did not finish in time, closing connections")
+		server.Stop()
 	}
 }
 
-func setupTeamHandlers(handler *rest.TeamHandler, r *gin.Engine) {
-	r.POST("/team/add", handler.HandleAddTeamRequest)
-	r.GET("/team/get", handler.HandleGetTeamRequest)
-	r.POST("/team/deactivateAll", handler.HandleDeactivateAllRequest)
+// shutdown прекращает прием новых соединений и дожидается завершения
+// обрабатываемых запросов и фоновых задач (не дольше timeout)
+func shutdown(server *http.Server, healthHandler *rest.HealthHandler, workers *sync.WaitGroup, timeout time.Duration) {
+	healthHandler.SetShuttingDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("unable to drain in-flight requests", "error", err)
+	}
+
+	workersDone := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(workersDone)
+	}()
+
+	select {
+	case <-workersDone:
+	case <-ctx.Done():
+		slog.Warn("background workers did not stop in time")
+	}
 }
 
-func setupUserHandlers(handler *rest.UserHandler, r *gin.Engine) {
-	r.POST("/users/setIsActive", handler.HandleSetIsActiveRequest)
-	r.GET("/users/getReview", handler.HandleGetReviewRequest)
+// newAuthenticator конструирует цепочку аутентификаторов клиентов
+// (nil - аутентификация отключена)
+func newAuthenticator(cfg *config.AuthConfig, apiKeys auth.Authenticator) (auth.Authenticator, error) {
+	if !cfg.Enabled {
+		slog.Warn("authentication is disabled, all requests are treated as admin requests")
+		return nil, nil
+	}
+
+	staticTokens, err := auth.NewStaticTokenAuthenticator(cfg.StaticTokens)
+	if err != nil {
+		return nil, err
+	}
+	authenticators := []auth.Authenticator{apiKeys, staticTokens}
+	if cfg.StaticTokens == "" && cfg.JWTSecret == "" && cfg.JWTPublicKeyFile == "" {
+		slog.Warn("no static tokens or JWT keys configured, only API keys are accepted")
+	}
+
+	if cfg.JWTSecret != "" || cfg.JWTPublicKeyFile != "" {
+		jwtAuthenticator, err := auth.NewJWTAuthenticator(cfg)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, jwtAuthenticator)
+	}
+
+	return auth.NewChainAuthenticator(authenticators...), nil
 }
 
-func setupPullRequestHandlers(handler *rest.PullRequestHandler, r *gin.Engine) {
-	r.POST("/pullRequest/create", handler.HandleCreateRequest)
-	r.POST("/pullRequest/merge", handler.HandleMergeRequest)
-	r.POST("/pullRequest/reassign", handler.HandleReassignRequest)
+// newRateLimitMiddlewares конструирует обработчики, ограничивающие частоту
+// запросов с IP-адреса (до аутентификации) и запросов клиентов
+// (при RATE_LIMIT_ENABLED=false запросы не ограничиваются)
+func newRateLimitMiddlewares(cfg *config.RateLimitConfig) (ipRateLimit, rateLimit gin.HandlerFunc) {
+	if !cfg.Enabled {
+		slog.Warn("rate limiting is disabled")
+		next := func(c *gin.Context) { c.Next() }
+		return next, next
+	}
+
+	var store rateLimitRepository.RateLimitRepository = rateLimitRepository.NewInMemoryRateLimitRepository()
+	ipRateLimit = middleware.IPRateLimit(&store, entity.RateLimit{Rate: cfg.IPRate, Burst: cfg.IPBurst})
+	rateLimit = middleware.RateLimit(&store,
+		entity.RateLimit{Rate: cfg.ReadRate, Burst: cfg.ReadBurst},
+		entity.RateLimit{Rate: cfg.WriteRate, Burst: cfg.WriteBurst})
+	return ipRateLimit, rateLimit
 }
 
-func setupStatRequestHandlers(handler *rest.StatsHandler, r *gin.Engine) {
-	r.GET("/stats", handler.HandleGetStatsRequest)
+func newMailSender(cfg *config.SMTPConfig) mail.Sender {
+	if cfg.Host == "" {
+		return mail.NewLogSender()
+	}
+
+	return mail.NewSMTPSender(cfg)
 }
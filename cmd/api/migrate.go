@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/salex06/pr-service/internal/config"
+	"github.com/salex06/pr-service/internal/database"
+	"github.com/salex06/pr-service/internal/migrator"
+	"github.com/salex06/pr-service/migrations"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status"
+
+// runMigrate выполняет подкоманду migrate (up/down/status)
+// и возвращает код завершения процесса
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	db, err := database.NewDB(config.LoadDBConfig())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "connecting to database failed: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	m, err := migrator.NewMigrator(db, migrations.FS)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "loading migrations failed: %v\n", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx)
+		printMigrations("applied", applied)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate up failed: %v\n", err)
+			return 1
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				fmt.Fprintln(os.Stderr, migrateUsage)
+				return 2
+			}
+		}
+
+		reverted, err := m.Down(ctx, steps)
+		printMigrations("reverted", reverted)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate down failed: %v\n", err)
+			return 1
+		}
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate status failed: %v\n", err)
+			return 1
+		}
+		printStatus(statuses)
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	return 0
+}
+
+func printMigrations(action string, migrations []*migrator.Migration) {
+	if len(migrations) == 0 {
+		fmt.Printf("no migrations %s\n", action)
+		return
+	}
+
+	for _, migration := range migrations {
+		fmt.Printf("%s %03d-%s\n", action, migration.Version, migration.Name)
+	}
+}
+
+func printStatus(statuses []*migrator.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%03d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	w.Flush()
+}
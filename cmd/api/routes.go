@@ -0,0 +1,322 @@
+package main
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/auth"
+	"github.com/salex06/pr-service/internal/middleware"
+	"github.com/salex06/pr-service/internal/rest"
+)
+
+// legacyRoutesDeprecatedAt - дата, с которой маршруты без префикса /api/v1
+// считаются устаревшими (передается в заголовке Deprecation)
+var legacyRoutesDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// deprecated помечает устаревший маршрут и указывает его преемника в /api/v1
+func deprecated(successor string) gin.HandlerFunc {
+	return middleware.Deprecated(legacyRoutesDeprecatedAt, successor)
+}
+
+// handlers - набор контроллеров REST API
+type handlers struct {
+	team        *rest.TeamHandler
+	user        *rest.UserHandler
+	digest      *rest.DigestHandler
+	pullRequest *rest.PullRequestHandler
+	stats       *rest.StatsHandler
+	apiKey      *rest.APIKeyHandler
+	audit       *rest.AuditHandler
+}
+
+// setupRoutes настраивает эндпоинты API: ресурсные маршруты /api/v1
+// и прежние маршруты, оставленные как устаревшие псевдонимы
+func setupRoutes(h *handlers, legacy, v1 gin.IRoutes, policy *auth.Policy) {
+	setupTeamHandlers(h.team, legacy, policy)
+	setupUserHandlers(h.user, legacy, policy)
+	setupDigestHandlers(h.digest, legacy)
+	setupPullRequestHandlers(h.pullRequest, legacy, policy)
+	setupStatRequestHandlers(h.stats, legacy)
+	setupAPIKeyHandlers(h.apiKey, legacy)
+	setupAuditHandlers(h.audit, legacy)
+
+	setupV1TeamHandlers(h.team, v1, policy)
+	setupV1UserHandlers(h.user, h.digest, v1, policy)
+	setupV1PullRequestHandlers(h.pullRequest, v1, policy)
+	setupV1StatRequestHandlers(h.stats, v1)
+	setupV1APIKeyHandlers(h.apiKey, v1)
+	setupV1AuditHandlers(h.audit, v1)
+}
+
+func setupV1TeamHandlers(handler *rest.TeamHandler, r gin.IRoutes, policy *auth.Policy) {
+	r.POST("/teams",
+		middleware.RequireScope(auth.ScopeTeamWrite),
+		middleware.Authorize(auth.LeadOf(auth.FromJSONBody("team_name"))),
+		handler.HandleAddTeamRequest)
+	r.GET("/teams/:team_name",
+		middleware.RequireScope(auth.ScopeTeamRead),
+		middleware.Authorize(auth.Authenticated()),
+		handler.HandleGetTeamRequest)
+	r.PUT("/teams/:team_name",
+		middleware.RequireScope(auth.ScopeTeamWrite),
+		middleware.Authorize(auth.LeadOf(auth.FromPath("team_name"))),
+		handler.HandlePutTeamRequest)
+	r.POST("/teams/:team_name/deactivate",
+		middleware.RequireScope(auth.ScopeTeamWrite),
+		middleware.Authorize(auth.LeadOf(auth.FromPath("team_name"))),
+		handler.HandleDeactivateAllRequest)
+	r.PUT("/teams/:team_name/members/:user_id",
+		middleware.RequireScope(auth.ScopeTeamWrite),
+		middleware.Authorize(auth.AllOf(
+			policy.LeadOfUser(auth.FromPath("user_id")),
+			auth.LeadOf(auth.FromPath("team_name")),
+		)),
+		handler.HandleMoveMemberRequest)
+}
+
+func setupV1UserHandlers(handler *rest.UserHandler, digestHandler *rest.DigestHandler, r gin.IRoutes, policy *auth.Policy) {
+	r.GET("/users/:user_id",
+		middleware.RequireScope(auth.ScopeUserRead),
+		middleware.Authorize(auth.Authenticated()),
+		handler.HandleGetUserRequest)
+	r.PATCH("/users/:user_id",
+		middleware.RequireScope(auth.ScopeUserWrite),
+		middleware.Authorize(policy.LeadOfUser(auth.FromPath("user_id"))),
+		handler.HandleSetIsActiveRequest)
+	r.GET("/users/:user_id/reviews",
+		middleware.RequireScope(auth.ScopeUserRead),
+		middleware.Authorize(auth.Authenticated()),
+		handler.HandleGetReviewRequest)
+	r.PUT("/users/:user_id/digest-subscription",
+		middleware.RequireScope(auth.ScopeUserWrite),
+		middleware.Authorize(auth.Self(auth.FromPath("user_id"))),
+		handler.HandleSetDigestOptOutRequest)
+	r.GET("/users/:user_id/digest",
+		middleware.RequireScope(auth.ScopeUserRead),
+		middleware.Authorize(auth.Self(auth.FromPath("user_id"))),
+		digestHandler.HandlePreviewRequest)
+}
+
+func setupV1PullRequestHandlers(handler *rest.PullRequestHandler, r gin.IRoutes, policy *auth.Policy) {
+	r.POST("/pull-requests",
+		middleware.RequireScope(auth.ScopePRWrite),
+		middleware.Authorize(auth.AnyOf(
+			auth.Roles(auth.RoleService),
+			auth.Self(auth.FromJSONBody("author_id")),
+		)),
+		handler.HandleCreateRequest)
+	r.POST("/pull-requests/batch",
+		middleware.RequireScope(auth.ScopePRWrite),
+		middleware.Authorize(auth.Roles(auth.RoleService)),
+		handler.HandleBatchCreateRequest)
+	r.GET("/pull-requests",
+		middleware.RequireScope(auth.ScopePRRead),
+		middleware.Authorize(auth.Authenticated()),
+		handler.HandleListRequest)
+	r.GET("/pull-requests/:pull_request_id",
+		middleware.RequireScope(auth.ScopePRRead),
+		middleware.Authorize(auth.Authenticated()),
+		handler.HandleGetRequest)
+	r.POST("/pull-requests/:pull_request_id/merge",
+		middleware.RequireScope(auth.ScopePRWrite),
+		middleware.Authorize(policy.PullRequestAuthor(auth.FromPath("pull_request_id"))),
+		handler.HandleMergeRequest)
+	r.POST("/pull-requests/:pull_request_id/reassign",
+		middleware.RequireScope(auth.ScopePRWrite),
+		middleware.Authorize(auth.AnyOf(
+			auth.Roles(auth.RoleService),
+			policy.PullRequestAuthor(auth.FromPath("pull_request_id")),
+			policy.LeadOfPullRequestAuthor(auth.FromPath("pull_request_id")),
+		)),
+		handler.HandleReassignRequest)
+	r.PUT("/pull-requests/:pull_request_id/reviewers/:user_id",
+		middleware.RequireScope(auth.ScopePRWrite),
+		middleware.Authorize(auth.AnyOf(
+			auth.Roles(auth.RoleService),
+			policy.PullRequestAuthor(auth.FromPath("pull_request_id")),
+			policy.LeadOfPullRequestAuthor(auth.FromPath("pull_request_id")),
+		)),
+		handler.HandleAssignReviewerRequest)
+	r.DELETE("/pull-requests/:pull_request_id/reviewers/:user_id",
+		middleware.RequireScope(auth.ScopePRWrite),
+		middleware.Authorize(auth.AnyOf(
+			auth.Roles(auth.RoleService),
+			policy.PullRequestAuthor(auth.FromPath("pull_request_id")),
+			policy.LeadOfPullRequestAuthor(auth.FromPath("pull_request_id")),
+		)),
+		handler.HandleUnassignReviewerRequest)
+	r.POST("/pull-requests/:pull_request_id/verdicts",
+		middleware.RequireScope(auth.ScopePRWrite),
+		middleware.Authorize(auth.Self(auth.FromJSONBody("user_id"))),
+		handler.HandleSubmitVerdictRequest)
+	r.GET("/pull-requests/:pull_request_id/reviewer-history",
+		middleware.RequireScope(auth.ScopePRRead),
+		middleware.Authorize(auth.Authenticated()),
+		handler.HandleReviewerHistoryRequest)
+}
+
+func setupV1StatRequestHandlers(handler *rest.StatsHandler, r gin.IRoutes) {
+	r.GET("/stats",
+		middleware.RequireScope(auth.ScopeStatsRead),
+		middleware.Authorize(auth.Authenticated()),
+		handler.HandleGetStatsRequest)
+	r.GET("/stats/fairness",
+		middleware.RequireScope(auth.ScopeStatsRead),
+		middleware.Authorize(auth.Authenticated()),
+		handler.HandleGetFairnessRequest)
+}
+
+// Управление ключами доступа доступно только администраторам
+// (ключи доступа не могут управлять другими ключами)
+func setupV1APIKeyHandlers(handler *rest.APIKeyHandler, r gin.IRoutes) {
+	adminOnly := middleware.Authorize(auth.Roles(auth.RoleAdmin))
+
+	r.POST("/api-keys", adminOnly, handler.HandleCreateRequest)
+	r.GET("/api-keys", adminOnly, handler.HandleListRequest)
+	r.POST("/api-keys/:key_id/revoke", adminOnly, handler.HandleRevokeRequest)
+	r.POST("/api-keys/:key_id/rotate", adminOnly, handler.HandleRotateRequest)
+}
+
+func setupV1AuditHandlers(handler *rest.AuditHandler, r gin.IRoutes) {
+	r.GET("/audit/records",
+		middleware.RequireScope(auth.ScopeAuditRead),
+		middleware.Authorize(auth.Roles(auth.RoleAdmin)),
+		handler.HandleGetRecordsRequest)
+	r.GET("/audit/verify",
+		middleware.RequireScope(auth.ScopeAuditRead),
+		middleware.Authorize(auth.Roles(auth.RoleAdmin)),
+		handler.HandleVerifyRequest)
+}
+
+func setupTeamHandlers(handler *rest.TeamHandler, r gin.IRoutes, policy *auth.Policy) {
+	r.POST("/team/add",
+		deprecated("/api/v1/teams"),
+		middleware.RequireScope(auth.ScopeTeamWrite),
+		middleware.Authorize(auth.LeadOf(auth.FromJSONBody("team_name"))),
+		handler.HandleAddTeamRequest)
+	r.GET("/team/get",
+		deprecated("/api/v1/teams/{team_name}"),
+		middleware.RequireScope(auth.ScopeTeamRead),
+		middleware.Authorize(auth.Authenticated()),
+		handler.HandleGetTeamRequest)
+	r.POST("/team/deactivateAll",
+		deprecated("/api/v1/teams/{team_name}/deactivate"),
+		middleware.RequireScope(auth.ScopeTeamWrite),
+		middleware.Authorize(auth.LeadOf(auth.FromQuery("team_name"))),
+		handler.HandleDeactivateAllRequest)
+	r.POST("/team/moveMember",
+		deprecated("/api/v1/teams/{team_name}/members/{user_id}"),
+		middleware.RequireScope(auth.ScopeTeamWrite),
+		middleware.Authorize(auth.AllOf(
+			policy.LeadOfUser(auth.FromJSONBody("user_id")),
+			auth.LeadOf(auth.FromJSONBody("team_name")),
+		)),
+		handler.HandleMoveMemberRequest)
+}
+
+func setupUserHandlers(handler *rest.UserHandler, r gin.IRoutes, policy *auth.Policy) {
+	r.POST("/users/setIsActive",
+		deprecated("/api/v1/users/{user_id}"),
+		middleware.RequireScope(auth.ScopeUserWrite),
+		middleware.Authorize(policy.LeadOfUser(auth.FromJSONBody("user_id"))),
+		handler.HandleSetIsActiveRequest)
+	r.GET("/users/getReview",
+		deprecated("/api/v1/users/{user_id}/reviews"),
+		middleware.RequireScope(auth.ScopeUserRead),
+		middleware.Authorize(auth.Authenticated()),
+		handler.HandleGetReviewRequest)
+	r.POST("/users/setDigestOptOut",
+		deprecated("/api/v1/users/{user_id}/digest-subscription"),
+		middleware.RequireScope(auth.ScopeUserWrite),
+		middleware.Authorize(auth.Self(auth.FromJSONBody("user_id"))),
+		handler.HandleSetDigestOptOutRequest)
+}
+
+func setupDigestHandlers(handler *rest.DigestHandler, r gin.IRoutes) {
+	r.GET("/users/digest/preview",
+		deprecated("/api/v1/users/{user_id}/digest"),
+		middleware.RequireScope(auth.ScopeUserRead),
+		middleware.Authorize(auth.Self(auth.FromQuery("user_id"))),
+		handler.HandlePreviewRequest)
+}
+
+func setupPullRequestHandlers(handler *rest.PullRequestHandler, r gin.IRoutes, policy *auth.Policy) {
+	r.POST("/pullRequest/create",
+		deprecated("/api/v1/pull-requests"),
+		middleware.RequireScope(auth.ScopePRWrite),
+		middleware.Authorize(auth.AnyOf(
+			auth.Roles(auth.RoleService),
+			auth.Self(auth.FromJSONBody("author_id")),
+		)),
+		handler.HandleCreateRequest)
+	r.POST("/pullRequest/batchCreate",
+		deprecated("/api/v1/pull-requests/batch"),
+		middleware.RequireScope(auth.ScopePRWrite),
+		middleware.Authorize(auth.Roles(auth.RoleService)),
+		handler.HandleBatchCreateRequest)
+	r.POST("/pullRequest/merge",
+		deprecated("/api/v1/pull-requests/{pull_request_id}/merge"),
+		middleware.RequireScope(auth.ScopePRWrite),
+		middleware.Authorize(policy.PullRequestAuthor(auth.FromJSONBody("pull_request_id"))),
+		handler.HandleMergeRequest)
+	r.POST("/pullRequest/reassign",
+		deprecated("/api/v1/pull-requests/{pull_request_id}/reassign"),
+		middleware.RequireScope(auth.ScopePRWrite),
+		middleware.Authorize(auth.AnyOf(
+			auth.Roles(auth.RoleService),
+			policy.PullRequestAuthor(auth.FromJSONBody("pull_request_id")),
+			policy.LeadOfPullRequestAuthor(auth.FromJSONBody("pull_request_id")),
+		)),
+		handler.HandleReassignRequest)
+	r.POST("/pullRequest/submitVerdict",
+		deprecated("/api/v1/pull-requests/{pull_request_id}/verdicts"),
+		middleware.RequireScope(auth.ScopePRWrite),
+		middleware.Authorize(auth.Self(auth.FromJSONBody("user_id"))),
+		handler.HandleSubmitVerdictRequest)
+	r.GET("/pullRequest/reviewerHistory",
+		deprecated("/api/v1/pull-requests/{pull_request_id}/reviewer-history"),
+		middleware.RequireScope(auth.ScopePRRead),
+		middleware.Authorize(auth.Authenticated()),
+		handler.HandleReviewerHistoryRequest)
+	r.GET("/pullRequest/list",
+		deprecated("/api/v1/pull-requests"),
+		middleware.RequireScope(auth.ScopePRRead),
+		middleware.Authorize(auth.Authenticated()),
+		handler.HandleListRequest)
+}
+
+func setupStatRequestHandlers(handler *rest.StatsHandler, r gin.IRoutes) {
+	r.GET("/stats",
+		deprecated("/api/v1/stats"),
+		middleware.RequireScope(auth.ScopeStatsRead),
+		middleware.Authorize(auth.Authenticated()),
+		handler.HandleGetStatsRequest)
+	r.GET("/stats/fairness",
+		deprecated("/api/v1/stats/fairness"),
+		middleware.RequireScope(auth.ScopeStatsRead),
+		middleware.Authorize(auth.Authenticated()),
+		handler.HandleGetFairnessRequest)
+}
+
+func setupAPIKeyHandlers(handler *rest.APIKeyHandler, r gin.IRoutes) {
+	adminOnly := middleware.Authorize(auth.Roles(auth.RoleAdmin))
+
+	r.POST("/apiKeys/create", deprecated("/api/v1/api-keys"), adminOnly, handler.HandleCreateRequest)
+	r.GET("/apiKeys/list", deprecated("/api/v1/api-keys"), adminOnly, handler.HandleListRequest)
+	r.POST("/apiKeys/revoke", deprecated("/api/v1/api-keys/{key_id}/revoke"), adminOnly, handler.HandleRevokeRequest)
+	r.POST("/apiKeys/rotate", deprecated("/api/v1/api-keys/{key_id}/rotate"), adminOnly, handler.HandleRotateRequest)
+}
+
+func setupAuditHandlers(handler *rest.AuditHandler, r gin.IRoutes) {
+	r.GET("/audit",
+		deprecated("/api/v1/audit/records"),
+		middleware.RequireScope(auth.ScopeAuditRead),
+		middleware.Authorize(auth.Roles(auth.RoleAdmin)),
+		handler.HandleGetRecordsRequest)
+	r.GET("/audit/verify",
+		deprecated("/api/v1/audit/verify"),
+		middleware.RequireScope(auth.ScopeAuditRead),
+		middleware.Authorize(auth.Roles(auth.RoleAdmin)),
+		handler.HandleVerifyRequest)
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMoveMemberAuthorization(t *testing.T) {
+	moves := []struct {
+		name string
+		do   func(t *testing.T, srv *testServer, token string) *httptest.ResponseRecorder
+	}{
+		{name: "legacy", do: func(t *testing.T, srv *testServer, token string) *httptest.ResponseRecorder {
+			return srv.do(t, http.MethodPost, "/team/moveMember", token,
+				map[string]any{"user_id": "u3", "team_name": "backend"})
+		}},
+		{name: "v1", do: func(t *testing.T, srv *testServer, token string) *httptest.ResponseRecorder {
+			return srv.do(t, http.MethodPut, "/api/v1/teams/backend/members/u3", token, nil)
+		}},
+	}
+	callers := []struct {
+		token      string
+		wantStatus int
+	}{
+		{token: "backend-lead-token", wantStatus: http.StatusForbidden},
+		{token: "frontend-lead-token", wantStatus: http.StatusForbidden},
+		{token: "u1-token", wantStatus: http.StatusForbidden},
+		{token: "admin-token", wantStatus: http.StatusOK},
+	}
+
+	for _, move := range moves {
+		for _, caller := range callers {
+			t.Run(move.name+"/"+caller.token, func(t *testing.T) {
+				srv := newTestServer(t)
+				srv.addTeam(t, "backend", "u1", "u2")
+				srv.addTeam(t, "frontend", "u3")
+
+				rec := move.do(t, srv, caller.token)
+				if rec.Code != caller.wantStatus {
+					t.Fatalf("status = %d, want %d, body: %s", rec.Code, caller.wantStatus, rec.Body.String())
+				}
+				if rec.Code == http.StatusForbidden && errorCode(t, rec) != "FORBIDDEN" {
+					t.Errorf("error code = %s, want FORBIDDEN", errorCode(t, rec))
+				}
+
+				user, err := srv.userRepo.GetUser(context.Background(), "u3")
+				if err != nil {
+					t.Fatalf("GetUser: %v", err)
+				}
+				wantTeam := "frontend"
+				if caller.wantStatus == http.StatusOK {
+					wantTeam = "backend"
+				}
+				if user.TeamName != wantTeam {
+					t.Errorf("u3 team = %s, want %s", user.TeamName, wantTeam)
+				}
+			})
+		}
+	}
+}
+
+func TestPutTeamMembersOfOtherTeam(t *testing.T) {
+	body := map[string]any{"members": []map[string]any{
+		{"user_id": "u2", "username": "Bob", "is_active": false},
+		{"user_id": "u3", "username": "Mallory", "is_active": false},
+	}}
+
+	t.Run("team-lead", func(t *testing.T) {
+		srv := newTestServer(t)
+		srv.addTeam(t, "backend", "u1", "u2")
+		srv.addTeam(t, "frontend", "u3")
+
+		rec := srv.mustDo(t, http.StatusConflict, http.MethodPut, "/api/v1/teams/backend", "backend-lead-token", body)
+		if code := errorCode(t, rec); code != "MEMBER_OF_OTHER_TEAM" {
+			t.Fatalf("error code = %s, want MEMBER_OF_OTHER_TEAM", code)
+		}
+
+		// Запрос отклоняется целиком: не меняются ни чужие, ни свои участники
+		for _, userID := range []string{"u2", "u3"} {
+			user, err := srv.userRepo.GetUser(context.Background(), userID)
+			if err != nil {
+				t.Fatalf("GetUser: %v", err)
+			}
+			if !user.IsActive || user.Username != userID {
+				t.Errorf("%s changed by rejected request: %+v", userID, user)
+			}
+		}
+		u3, _ := srv.userRepo.GetUser(context.Background(), "u3")
+		if u3.TeamName != "frontend" {
+			t.Errorf("u3 team = %s, want frontend", u3.TeamName)
+		}
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		srv := newTestServer(t)
+		srv.addTeam(t, "backend", "u1", "u2")
+		srv.addTeam(t, "frontend", "u3")
+
+		srv.mustDo(t, http.StatusOK, http.MethodPut, "/api/v1/teams/backend", "admin-token", body)
+
+		u3, err := srv.userRepo.GetUser(context.Background(), "u3")
+		if err != nil {
+			t.Fatalf("GetUser: %v", err)
+		}
+		if u3.TeamName != "backend" || u3.IsActive {
+			t.Errorf("u3 = %+v, want inactive member of backend", u3)
+		}
+	})
+}
+
+func TestAddTeamMembersOfOtherTeam(t *testing.T) {
+	body := map[string]any{"team_name": "backend", "members": []map[string]any{
+		{"user_id": "u1", "username": "Alice", "is_active": true},
+		{"user_id": "u3", "username": "Mallory", "is_active": false},
+	}}
+
+	for _, path := range []string{"/team/add", "/api/v1/teams"} {
+		t.Run("team-lead"+path, func(t *testing.T) {
+			srv := newTestServer(t)
+			srv.addTeam(t, "frontend", "u3")
+
+			rec := srv.mustDo(t, http.StatusConflict, http.MethodPost, path, "backend-lead-token", body)
+			if code := errorCode(t, rec); code != "MEMBER_OF_OTHER_TEAM" {
+				t.Fatalf("error code = %s, want MEMBER_OF_OTHER_TEAM", code)
+			}
+
+			// Команда не создана, сотрудник другой команды не изменен
+			if exists, _ := srv.teamRepo.TeamExists(context.Background(), "backend"); exists {
+				t.Error("backend created by rejected request")
+			}
+			u3, err := srv.userRepo.GetUser(context.Background(), "u3")
+			if err != nil {
+				t.Fatalf("GetUser: %v", err)
+			}
+			if u3.TeamName != "frontend" || !u3.IsActive || u3.Username != "u3" {
+				t.Errorf("u3 changed by rejected request: %+v", u3)
+			}
+		})
+	}
+
+	t.Run("admin", func(t *testing.T) {
+		srv := newTestServer(t)
+		srv.addTeam(t, "frontend", "u3")
+
+		srv.mustDo(t, http.StatusCreated, http.MethodPost, "/api/v1/teams", "admin-token", body)
+
+		u3, err := srv.userRepo.GetUser(context.Background(), "u3")
+		if err != nil {
+			t.Fatalf("GetUser: %v", err)
+		}
+		if u3.TeamName != "backend" || u3.IsActive {
+			t.Errorf("u3 = %+v, want inactive member of backend", u3)
+		}
+	})
+}
+
+func TestListPullRequestsByLabel(t *testing.T) {
+	srv := newTestServer(t)
+	srv.addTeam(t, "backend", "u1", "u2", "u3")
+
+	for _, pr := range []map[string]any{
+		{"pull_request_id": "pr-bug", "repository": "org/api", "labels": []string{"bug", "backend"}},
+		{"pull_request_id": "pr-feature", "repository": "org/api", "labels": []string{"feature"}},
+		{"pull_request_id": "pr-web-bug", "repository": "org/web", "labels": []string{"bug"}},
+		{"pull_request_id": "pr-plain"},
+	} {
+		pr["pull_request_name"], pr["author_id"] = pr["pull_request_id"], "u1"
+		srv.mustDo(t, http.StatusCreated, http.MethodPost, "/api/v1/pull-requests", "admin-token", pr)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "label=bug", want: []string{"pr-bug", "pr-web-bug"}},
+		{query: "label=bug&repository=org/api", want: []string{"pr-bug"}},
+		{query: "label=backend", want: []string{"pr-bug"}},
+		{query: "label=Bug", want: nil},
+		{query: "label=docs", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := srv.mustDo(t, http.StatusOK, http.MethodGet, "/api/v1/pull-requests?"+tt.query, "admin-token", nil)
+
+			var resp struct {
+				PullRequests []struct {
+					PullRequestID string `json:"pull_request_id"`
+				} `json:"pull_requests"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("unmarshal response %q: %v", rec.Body.String(), err)
+			}
+			var got []string
+			for _, pr := range resp.PullRequests {
+				got = append(got, pr.PullRequestID)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("pull requests = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatePullRequestRejectsInvalidLabels(t *testing.T) {
+	srv := newTestServer(t)
+	srv.addTeam(t, "backend", "u1", "u2")
+
+	tooMany := make([]string, 21)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("label-%d", i)
+	}
+	tests := []struct {
+		name   string
+		labels []string
+	}{
+		{name: "too many", labels: tooMany},
+		{name: "duplicate", labels: []string{"bug", "bug"}},
+		{name: "empty", labels: []string{""}},
+		{name: "too long", labels: []string{strings.Repeat("a", 65)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := srv.mustDo(t, http.StatusBadRequest, http.MethodPost, "/api/v1/pull-requests", "admin-token",
+				map[string]any{"pull_request_id": "pr-1", "pull_request_name": "pr-1", "author_id": "u1", "labels": tt.labels})
+			if code := errorCode(t, rec); code != "VALIDATION_ERROR" {
+				t.Fatalf("error code = %s, want VALIDATION_ERROR", code)
+			}
+		})
+	}
+
+	srv.mustDo(t, http.StatusCreated, http.MethodPost, "/api/v1/pull-requests", "admin-token",
+		map[string]any{"pull_request_id": "pr-1", "pull_request_name": "pr-1", "author_id": "u1", "labels": tooMany[:20]})
+}
+
+func TestManualReviewerAssignment(t *testing.T) {
+	srv := newTestServer(t)
+	srv.addTeam(t, "backend", "u1", "u2")
+	srv.addTeam(t, "frontend", "u3")
+	srv.mustDo(t, http.StatusCreated, http.MethodPost, "/api/v1/pull-requests", "admin-token",
+		map[string]any{"pull_request_id": "pr-1", "pull_request_name": "pr-1", "author_id": "u1"})
+
+	const reviewerPath = "/api/v1/pull-requests/pr-1/reviewers/"
+	reviewers := func(t *testing.T, rec *httptest.ResponseRecorder) []string {
+		t.Helper()
+		var resp struct {
+			Pr struct {
+				AssignedReviewers []string `json:"assigned_reviewers"`
+			} `json:"pr"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal response %q: %v", rec.Body.String(), err)
+		}
+		return resp.Pr.AssignedReviewers
+	}
+
+	srv.mustDo(t, http.StatusForbidden, http.MethodDelete, reviewerPath+"u2", "u2-token", nil)
+	srv.mustDo(t, http.StatusForbidden, http.MethodDelete, reviewerPath+"u2", "frontend-lead-token", nil)
+
+	rec := srv.mustDo(t, http.StatusOK, http.MethodDelete, reviewerPath+"u2", "u1-token", nil)
+	if got := reviewers(t, rec); len(got) != 0 {
+		t.Fatalf("reviewers after unassign = %v, want none", got)
+	}
+	rec = srv.mustDo(t, http.StatusConflict, http.MethodDelete, reviewerPath+"u2", "u1-token", nil)
+	if code := errorCode(t, rec); code != "NOT_ASSIGNED" {
+		t.Errorf("repeated unassign error code = %s, want NOT_ASSIGNED", code)
+	}
+
+	for _, userID := range []string{"u1", "u3"} {
+		rec := srv.mustDo(t, http.StatusConflict, http.MethodPut, reviewerPath+userID, "u1-token", nil)
+		if code := errorCode(t, rec); code != "NOT_ASSIGNABLE" {
+			t.Errorf("assign %s error code = %s, want NOT_ASSIGNABLE", userID, code)
+		}
+	}
+
+	rec = srv.mustDo(t, http.StatusOK, http.MethodPut, reviewerPath+"u2", "backend-lead-token", nil)
+	if got := reviewers(t, rec); !slices.Equal(got, []string{"u2"}) {
+		t.Fatalf("reviewers after assign = %v, want [u2]", got)
+	}
+	rec = srv.mustDo(t, http.StatusConflict, http.MethodPut, reviewerPath+"u2", "backend-lead-token", nil)
+	if code := errorCode(t, rec); code != "NOT_ASSIGNABLE" {
+		t.Errorf("repeated assign error code = %s, want NOT_ASSIGNABLE", code)
+	}
+
+	rec = srv.mustDo(t, http.StatusOK, http.MethodGet, "/api/v1/pull-requests/pr-1/reviewer-history", "admin-token", nil)
+	var timeline struct {
+		Assignments []struct {
+			UserID         string  `json:"user_id"`
+			Reason         string  `json:"reason"`
+			UnassignReason *string `json:"unassign_reason"`
+		} `json:"assignments"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &timeline); err != nil {
+		t.Fatalf("unmarshal timeline %q: %v", rec.Body.String(), err)
+	}
+	if len(timeline.Assignments) != 2 {
+		t.Fatalf("assignments = %s, want 2", rec.Body.String())
+	}
+	initial, manual := timeline.Assignments[0], timeline.Assignments[1]
+	if initial.Reason != "initial" || initial.UnassignReason == nil || *initial.UnassignReason != "manual" {
+		t.Errorf("first assignment = %+v, want initial unassigned as manual", initial)
+	}
+	if manual.Reason != "manual" || manual.UnassignReason != nil {
+		t.Errorf("second assignment = %+v, want active manual", manual)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/salex06/pr-service/internal/config"
+	"github.com/salex06/pr-service/internal/database"
+	"github.com/salex06/pr-service/internal/entity"
+	prRepository "github.com/salex06/pr-service/internal/repos/pr"
+	"github.com/salex06/pr-service/internal/tracing"
+)
+
+// tracedPullRequestRepository оборачивает in-memory репозиторий и создает
+// спаны запросов через database.QueryTracer, как это делает пул pgx
+type tracedPullRequestRepository struct {
+	prRepository.PullRequestRepository
+}
+
+func (repo *tracedPullRequestRepository) GetPullRequest(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	tracer := &database.QueryTracer{}
+	ctx = tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{
+		SQL: "SELECT pull_request_id FROM pull_requests WHERE pull_request_id = $1",
+	})
+	defer tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+
+	return repo.PullRequestRepository.GetPullRequest(ctx, prID)
+}
+
+func TestRequestSpansAreNested(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewTracerProvider(exporter, &config.TracingConfig{ServiceName: "pr-service-test", SampleRatio: 1})
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+
+	srv := newTestServer(t)
+	srv.addTeam(t, "backend", "u1", "u2")
+	srv.mustDo(t, http.StatusCreated, http.MethodPost, "/api/v1/pull-requests", "u1-token",
+		map[string]any{"pull_request_id": "pr-1", "pull_request_name": "Add search", "author_id": "u1"})
+
+	srv.prRepo = &tracedPullRequestRepository{PullRequestRepository: srv.prRepo}
+	srv.build(t, otelgin.Middleware("pr-service-test"))
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+	exporter.Reset()
+
+	srv.mustDo(t, http.StatusOK, http.MethodGet, "/api/v1/pull-requests/pr-1", "u1-token", nil)
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	byName := make(map[string]tracetest.SpanStub, len(spans))
+	for _, span := range spans {
+		byName[span.Name] = span
+	}
+
+	httpSpan, ok := byName["GET /api/v1/pull-requests/:pull_request_id"]
+	if !ok {
+		t.Fatalf("no HTTP span among %v", spanNames(spans))
+	}
+	serviceSpan, ok := byName["PullRequestService.GetPullRequest"]
+	if !ok {
+		t.Fatalf("no service span among %v", spanNames(spans))
+	}
+	dbSpan, ok := byName["db SELECT"]
+	if !ok {
+		t.Fatalf("no db span among %v", spanNames(spans))
+	}
+
+	if serviceSpan.Parent.SpanID() != httpSpan.SpanContext.SpanID() {
+		t.Errorf("service span parent = %s, want HTTP span %s", serviceSpan.Parent.SpanID(), httpSpan.SpanContext.SpanID())
+	}
+	if dbSpan.Parent.SpanID() != serviceSpan.SpanContext.SpanID() {
+		t.Errorf("db span parent = %s, want service span %s", dbSpan.Parent.SpanID(), serviceSpan.SpanContext.SpanID())
+	}
+	if dbSpan.SpanContext.TraceID() != httpSpan.SpanContext.TraceID() {
+		t.Errorf("db span belongs to trace %s, want %s", dbSpan.SpanContext.TraceID(), httpSpan.SpanContext.TraceID())
+	}
+}
+
+func spanNames(spans tracetest.SpanStubs) []string {
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+	return names
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/salex06/pr-service/internal/dto"
+)
+
+// client представляет клиент REST API pr-service
+type client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func newClient(baseURL, token string) *client {
+	return &client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// apiError представляет ответ сервиса с кодом ошибки
+type apiError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *apiError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%d %s: %s", e.Status, http.StatusText(e.Status), e.Message)
+	}
+
+	return fmt.Sprintf("%d %s: %s", e.Status, e.Code, e.Message)
+}
+
+// get выполняет GET-запрос к path с параметрами query
+// и декодирует тело ответа в out
+func (cl *client) get(ctx context.Context, path string, query url.Values, out any) error {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	return cl.do(ctx, http.MethodGet, path, nil, out)
+}
+
+// post выполняет POST-запрос к path с телом body (JSON)
+// и декодирует тело ответа в out
+func (cl *client) post(ctx context.Context, path string, body any, out any) error {
+	return cl.do(ctx, http.MethodPost, path, body, out)
+}
+
+func (cl *client) do(ctx context.Context, method, path string, body any, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("unable to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, cl.baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("unable to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if cl.token != "" {
+		req.Header.Set("Authorization", "Bearer "+cl.token)
+	}
+
+	resp, err := cl.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeError(resp.StatusCode, respBody)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("unable to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// decodeError формирует ошибку по телу ответа сервиса (dto.ErrorResponse)
+func decodeError(status int, body []byte) error {
+	var resp dto.ErrorResponse
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Error == nil {
+		return &apiError{Status: status, Message: strings.TrimSpace(string(body))}
+	}
+
+	message := resp.Error["message"]
+	for _, violation := range resp.Details {
+		message += fmt.Sprintf("; %s %s", violation.Field, violation.Reason)
+	}
+
+	return &apiError{Status: status, Code: resp.Error["code"], Message: message}
+}
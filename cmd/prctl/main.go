@@ -0,0 +1,107 @@
+// Команда prctl - утилита командной строки для администрирования
+// pr-service (команды, сотрудники, PR's, статистика) через REST API
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+const usage = `usage: prctl <command> [flags]
+
+commands:
+  team add <team_name> --member user_id:username[:inactive] ...
+  team get <team_name>
+  team import <file>              (JSON/YAML: команда или список команд)
+  team export <team_name> ... [--file <file>]
+  user activate <user_id>
+  user deactivate <user_id>
+  user move <user_id> --team <team_name>
+  pr create <pull_request_id> --name <name> --author <user_id>
+  pr merge <pull_request_id>
+  pr reassign <pull_request_id> --old-reviewer <user_id>
+  pr list [--status OPEN|MERGED] [--author <user_id>] [--reviewer <user_id>]
+  stats [--team <team_name>] [--from <RFC 3339>] [--to <RFC 3339>] [--granularity day|week]
+
+global flags (environment variables):
+  --url      base URL of pr-service (PRCTL_URL, default http://localhost:8080)
+  --token    access token or API key (PRCTL_TOKEN)
+  -o         output format: table, json, yaml (PRCTL_OUTPUT, default table)
+  --timeout  request timeout (default 30s)
+`
+
+// commands - подкоманды prctl, сгруппированные по ресурсам
+var commands = map[string]map[string]func(args []string) error{
+	"team": {
+		"add":    runTeamAdd,
+		"get":    runTeamGet,
+		"import": runTeamImport,
+		"export": runTeamExport,
+	},
+	"user": {
+		"activate":   runUserActivate,
+		"deactivate": runUserDeactivate,
+		"move":       runUserMove,
+	},
+	"pr": {
+		"create":   runPRCreate,
+		"merge":    runPRMerge,
+		"reassign": runPRReassign,
+		"list":     runPRList,
+	},
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "prctl: %v\n", err)
+		}
+
+		var usageErr *usageError
+		if errors.As(err, &usageErr) || errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Print(usage)
+		return nil
+	}
+
+	if args[0] == "stats" {
+		return runStats(args[1:])
+	}
+
+	group, ok := commands[args[0]]
+	if !ok {
+		return newUsageError("unknown command %q", args[0])
+	}
+	if len(args) < 2 {
+		return newUsageError("%s: missing subcommand", args[0])
+	}
+
+	command, ok := group[args[1]]
+	if !ok {
+		return newUsageError("unknown command %q", args[0]+" "+args[1])
+	}
+
+	return command(args[2:])
+}
+
+// usageError представляет ошибку в аргументах командной строки
+type usageError struct {
+	message string
+}
+
+func newUsageError(format string, args ...any) *usageError {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+func (e *usageError) Error() string {
+	return e.message + " (see prctl help)"
+}
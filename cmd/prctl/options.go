@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"time"
+)
+
+// options представляет общие для всех подкоманд параметры:
+// адрес сервиса, токен доступа, формат вывода и время ожидания ответа
+type options struct {
+	baseURL string
+	token   string
+	output  string
+	timeout time.Duration
+}
+
+// newFlagSet создает набор флагов подкоманды name
+// и регистрирует в нем общие параметры
+func newFlagSet(name string) (*flag.FlagSet, *options) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	opts := &options{}
+	fs.StringVar(&opts.baseURL, "url", getEnv("PRCTL_URL", "http://localhost:8080"), "base URL of pr-service")
+	fs.StringVar(&opts.token, "token", os.Getenv("PRCTL_TOKEN"), "access token or API key")
+	fs.StringVar(&opts.output, "o", getEnv("PRCTL_OUTPUT", formatTable), "output format: table, json, yaml")
+	fs.StringVar(&opts.output, "output", getEnv("PRCTL_OUTPUT", formatTable), "output format: table, json, yaml")
+	fs.DurationVar(&opts.timeout, "timeout", 30*time.Second, "request timeout")
+
+	return fs, opts
+}
+
+// parseArgs разбирает флаги, расположенные как до, так и после
+// позиционных аргументов, и возвращает позиционные аргументы
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := fs.Parse(args); err != nil {
+			if err == flag.ErrHelp {
+				return nil, err
+			}
+			return nil, newUsageError("%s: %v", fs.Name(), err)
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// context возвращает контекст запроса, ограниченный timeout
+func (o *options) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), o.timeout)
+}
+
+// client возвращает клиент REST API сервиса
+func (o *options) client() *client {
+	return newClient(o.baseURL, o.token)
+}
+
+// stringsFlag представляет повторяемый строковый флаг
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return ""
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+
+	return defaultValue
+}
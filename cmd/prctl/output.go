@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Поддерживаемые форматы вывода
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// table представляет таблицу для вывода в формате table
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func newTable(header ...string) *table {
+	return &table{header: header}
+}
+
+func (t *table) add(row ...string) {
+	t.rows = append(t.rows, row)
+}
+
+func (t *table) write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// render выводит v в stdout в формате format:
+// JSON и YAML строятся по JSON-представлению dto,
+// а для table используются таблицы tables
+func render(format string, v any, tables ...*table) error {
+	return write(os.Stdout, format, v, tables...)
+}
+
+func write(w io.Writer, format string, v any, tables ...*table) error {
+	switch format {
+	case formatTable:
+		for i, t := range tables {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			if err := t.write(w); err != nil {
+				return err
+			}
+		}
+		return nil
+	case formatJSON:
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	case formatYAML:
+		out, err := toYAML(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	default:
+		return newUsageError("unknown output format %q", format)
+	}
+}
+
+// toYAML кодирует v в YAML с сохранением JSON-имен полей dto
+func toYAML(v any) ([]byte, error) {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.JSONToYAML(out)
+}
+
+// decodeFile декодирует JSON- или YAML-файл в v
+// (ключи соответствуют JSON-именам полей dto)
+func decodeFile(path string, v any) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if !json.Valid(content) {
+		content, err = yaml.YAMLToJSON(content)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	if err := json.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+func formatBool(v bool) string {
+	if v {
+		return "yes"
+	}
+
+	return "no"
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	return t.Local().Format(time.DateTime)
+}
+
+func formatOptional(s *string) string {
+	if s == nil || *s == "" {
+		return "-"
+	}
+
+	return *s
+}
+
+func formatSeconds(seconds *float64) string {
+	if seconds == nil {
+		return "-"
+	}
+
+	return (time.Duration(*seconds) * time.Second).String()
+}
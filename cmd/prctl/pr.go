@@ -0,0 +1,158 @@
+package main
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/salex06/pr-service/internal/dto"
+)
+
+// runPRCreate открывает PR с автоматическим назначением ревьюеров
+func runPRCreate(args []string) error {
+	fs, opts := newFlagSet("pr create")
+	name := fs.String("name", "", "pull request name")
+	author := fs.String("author", "", "author user_id")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || *name == "" || *author == "" {
+		return newUsageError("pr create: expected <pull_request_id> --name <name> --author <user_id>")
+	}
+
+	req := &dto.CreatePullRequest{PullRequestID: positional[0], PullRequestName: *name, AuthorID: *author}
+	return postPullRequest(opts, "/pullRequest/create", req)
+}
+
+// runPRMerge переводит PR в статус MERGED
+func runPRMerge(args []string) error {
+	fs, opts := newFlagSet("pr merge")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return newUsageError("pr merge: expected <pull_request_id>")
+	}
+
+	return postPullRequest(opts, "/pullRequest/merge", &dto.MergePullRequest{PullRequestID: positional[0]})
+}
+
+func postPullRequest(opts *options, path string, req any) error {
+	ctx, cancel := opts.context()
+	defer cancel()
+
+	var resp struct {
+		PullRequest *dto.PullRequest `json:"pr"`
+	}
+	if err := opts.client().post(ctx, path, req, &resp); err != nil {
+		return err
+	}
+
+	return render(opts.output, resp.PullRequest, pullRequestTable(resp.PullRequest))
+}
+
+// runPRReassign заменяет ревьюера PR другим сотрудником из его команды
+func runPRReassign(args []string) error {
+	fs, opts := newFlagSet("pr reassign")
+	oldReviewer := fs.String("old-reviewer", "", "reviewer user_id to replace")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || *oldReviewer == "" {
+		return newUsageError("pr reassign: expected <pull_request_id> --old-reviewer <user_id>")
+	}
+
+	ctx, cancel := opts.context()
+	defer cancel()
+
+	var resp dto.ReassignPrResponse
+	req := &dto.ReassignPullRequest{PullRequestID: positional[0], OldReviewerID: *oldReviewer}
+	if err := opts.client().post(ctx, "/pullRequest/reassign", req, &resp); err != nil {
+		return err
+	}
+
+	t := pullRequestTable(&resp.Pr)
+	t.header = append(t.header, "REPLACED_BY")
+	t.rows[0] = append(t.rows[0], resp.ReplacedBy)
+
+	return render(opts.output, &resp, t)
+}
+
+// runPRList выводит PR's с фильтрами по статусу, автору или ревьюеру
+func runPRList(args []string) error {
+	fs, opts := newFlagSet("pr list")
+	status := fs.String("status", "", "OPEN or MERGED")
+	author := fs.String("author", "", "author user_id")
+	reviewer := fs.String("reviewer", "", "list pull requests assigned to reviewer user_id")
+	limit := fs.Int("limit", 0, "maximum number of pull requests")
+	offset := fs.Int("offset", 0, "number of pull requests to skip")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return newUsageError("pr list: unexpected argument %q", positional[0])
+	}
+	*status = strings.ToUpper(*status)
+
+	ctx, cancel := opts.context()
+	defer cancel()
+
+	prs := make([]dto.PullRequestShort, 0)
+	if *reviewer != "" {
+		var resp dto.AssignedPullRequests
+		if err := opts.client().get(ctx, "/users/getReview", url.Values{"user_id": {*reviewer}}, &resp); err != nil {
+			return err
+		}
+		for _, pr := range resp.PullRequests {
+			if (*status == "" || string(pr.Status) == *status) && (*author == "" || pr.AuthorID == *author) {
+				prs = append(prs, pr)
+			}
+		}
+	} else {
+		query := url.Values{}
+		if *status != "" {
+			query.Set("status", *status)
+		}
+		if *author != "" {
+			query.Set("author_id", *author)
+		}
+		if *limit > 0 {
+			query.Set("limit", strconv.Itoa(*limit))
+		}
+		if *offset > 0 {
+			query.Set("offset", strconv.Itoa(*offset))
+		}
+
+		var resp struct {
+			PullRequests []dto.PullRequestShort `json:"pull_requests"`
+		}
+		if err := opts.client().get(ctx, "/pullRequest/list", query, &resp); err != nil {
+			return err
+		}
+		prs = resp.PullRequests
+	}
+
+	t := newTable("PULL_REQUEST_ID", "NAME", "AUTHOR", "STATUS")
+	for _, pr := range prs {
+		t.add(pr.PullRequestID, pr.PullRequestName, pr.AuthorID, string(pr.Status))
+	}
+
+	return render(opts.output, prs, t)
+}
+
+func pullRequestTable(pr *dto.PullRequest) *table {
+	reviewers := "-"
+	if len(pr.AssignedReviewers) > 0 {
+		reviewers = strings.Join(pr.AssignedReviewers, ",")
+	}
+
+	t := newTable("PULL_REQUEST_ID", "NAME", "AUTHOR", "STATUS", "REVIEWERS", "CREATED_AT", "MERGED_AT")
+	t.add(pr.PullRequestID, pr.PullRequestName, pr.AuthorID, string(pr.Status), reviewers,
+		formatTime(pr.CreatedAt), formatTime(pr.MergedAt))
+
+	return t
+}
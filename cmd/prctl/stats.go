@@ -0,0 +1,77 @@
+package main
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/salex06/pr-service/internal/dto"
+)
+
+// runStats выводит статистику сервиса и аналитику ревью за временное окно
+func runStats(args []string) error {
+	fs, opts := newFlagSet("stats")
+	team := fs.String("team", "", "team name")
+	from := fs.String("from", "", "window start (RFC 3339)")
+	to := fs.String("to", "", "window end (RFC 3339)")
+	granularity := fs.String("granularity", "", "throughput granularity: day or week")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return newUsageError("stats: unexpected argument %q", positional[0])
+	}
+
+	query := url.Values{}
+	for key, value := range map[string]string{"team": *team, "from": *from, "to": *to, "granularity": *granularity} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	ctx, cancel := opts.context()
+	defer cancel()
+
+	var stat dto.AppStat
+	if err := opts.client().get(ctx, "/stats", query, &stat); err != nil {
+		return err
+	}
+
+	return render(opts.output, &stat, statsTables(&stat)...)
+}
+
+func statsTables(stat *dto.AppStat) []*table {
+	summary := newTable("METRIC", "VALUE")
+	summary.add("users", strconv.Itoa(stat.TotalUsersCount))
+	summary.add("active users", strconv.Itoa(stat.ActiveUsersCount))
+	summary.add("teams", strconv.Itoa(stat.TotalTeamsCount))
+	summary.add("open pull requests", strconv.Itoa(stat.OpenedPRCount))
+	summary.add("merged pull requests", strconv.Itoa(stat.MergedPRCount))
+
+	assignments := newTable("USER_ID", "ASSIGNMENTS")
+	for _, v := range stat.AssignmentsCountByUser {
+		assignments.add(v.UserID, strconv.Itoa(v.AssignmentsCount))
+	}
+
+	tables := []*table{summary, assignments}
+
+	analytics := stat.ReviewAnalytics
+	if analytics == nil {
+		return tables
+	}
+
+	summary.add("window", formatTime(&analytics.From)+" - "+formatTime(&analytics.To))
+	summary.add("reassignments", strconv.Itoa(analytics.ReassignmentsCount))
+	if analytics.MergeTime != nil {
+		summary.add("merged in window", strconv.Itoa(analytics.MergeTime.MergedCount))
+		summary.add("median time to merge", formatSeconds(analytics.MergeTime.MedianSeconds))
+		summary.add("p90 time to merge", formatSeconds(analytics.MergeTime.P90Seconds))
+	}
+
+	throughput := newTable("PERIOD_START", "OPENED", "MERGED")
+	for _, v := range analytics.Throughput {
+		throughput.add(formatTime(&v.PeriodStart), strconv.Itoa(v.Opened), strconv.Itoa(v.Merged))
+	}
+
+	return append(tables, throughput)
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/salex06/pr-service/internal/dto"
+)
+
+// runTeamAdd создает команду с участниками, заданными флагами --member
+func runTeamAdd(args []string) error {
+	fs, opts := newFlagSet("team add")
+	var members stringsFlag
+	fs.Var(&members, "member", "team member as user_id:username[:inactive] (repeatable)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return newUsageError("team add: expected <team_name>")
+	}
+
+	team := &dto.Team{TeamName: positional[0], Members: make([]*dto.TeamMember, 0, len(members))}
+	for _, v := range members {
+		member, err := parseMember(v)
+		if err != nil {
+			return err
+		}
+		team.Members = append(team.Members, member)
+	}
+
+	ctx, cancel := opts.context()
+	defer cancel()
+
+	var resp struct {
+		Team *dto.Team `json:"team"`
+	}
+	if err := opts.client().post(ctx, "/team/add", team, &resp); err != nil {
+		return err
+	}
+
+	return render(opts.output, resp.Team, teamTable(resp.Team))
+}
+
+// runTeamGet выводит команду и её участников
+func runTeamGet(args []string) error {
+	fs, opts := newFlagSet("team get")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return newUsageError("team get: expected <team_name>")
+	}
+
+	ctx, cancel := opts.context()
+	defer cancel()
+
+	var team dto.Team
+	if err := opts.client().get(ctx, "/team/get", url.Values{"team_name": {positional[0]}}, &team); err != nil {
+		return err
+	}
+
+	return render(opts.output, &team, teamTable(&team))
+}
+
+// importResult представляет результат импорта одной команды
+type importResult struct {
+	TeamName string `json:"team_name"`
+	Members  int    `json:"members"`
+	Error    string `json:"error,omitempty"`
+}
+
+// runTeamImport создает команды, описанные в JSON- или YAML-файле
+// (одна команда или список команд в формате dto.Team)
+func runTeamImport(args []string) error {
+	fs, opts := newFlagSet("team import")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return newUsageError("team import: expected <file>")
+	}
+
+	teams, err := readTeams(positional[0])
+	if err != nil {
+		return err
+	}
+
+	results := make([]*importResult, 0, len(teams))
+	failed := 0
+	for _, team := range teams {
+		result := &importResult{TeamName: team.TeamName, Members: len(team.Members)}
+
+		ctx, cancel := opts.context()
+		if err := opts.client().post(ctx, "/team/add", team, nil); err != nil {
+			result.Error = err.Error()
+			failed++
+		}
+		cancel()
+
+		results = append(results, result)
+	}
+
+	t := newTable("TEAM", "MEMBERS", "RESULT")
+	for _, result := range results {
+		status := "created"
+		if result.Error != "" {
+			status = result.Error
+		}
+		t.add(result.TeamName, strconv.Itoa(result.Members), status)
+	}
+	if err := render(opts.output, results, t); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d teams were not imported", failed, len(teams))
+	}
+
+	return nil
+}
+
+// runTeamExport выводит команды в формате, пригодном для team import,
+// или сохраняет их в файл (формат определяется расширением: .json, .yaml, .yml)
+func runTeamExport(args []string) error {
+	fs, opts := newFlagSet("team export")
+	file := fs.String("file", "", "write teams to file (.json, .yaml or .yml)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return newUsageError("team export: expected <team_name> ...")
+	}
+
+	teams := make([]*dto.Team, 0, len(positional))
+	for _, name := range positional {
+		ctx, cancel := opts.context()
+		var team dto.Team
+		err := opts.client().get(ctx, "/team/get", url.Values{"team_name": {name}}, &team)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("team %s: %w", name, err)
+		}
+		teams = append(teams, &team)
+	}
+
+	if *file == "" {
+		tables := make([]*table, 0, len(teams))
+		for _, team := range teams {
+			tables = append(tables, teamTable(team))
+		}
+		return render(opts.output, teams, tables...)
+	}
+
+	format := formatJSON
+	if ext := strings.ToLower(filepath.Ext(*file)); ext == ".yaml" || ext == ".yml" {
+		format = formatYAML
+	}
+
+	var out bytes.Buffer
+	if err := write(&out, format, teams); err != nil {
+		return err
+	}
+
+	return os.WriteFile(*file, out.Bytes(), 0o644)
+}
+
+// readTeams читает из файла одну команду или список команд
+func readTeams(path string) ([]*dto.Team, error) {
+	var raw json.RawMessage
+	if err := decodeFile(path, &raw); err != nil {
+		return nil, err
+	}
+
+	teams := make([]*dto.Team, 0)
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '{' {
+		var team dto.Team
+		if err := json.Unmarshal(raw, &team); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return append(teams, &team), nil
+	}
+
+	if err := json.Unmarshal(raw, &teams); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return teams, nil
+}
+
+// parseMember разбирает описание участника команды user_id:username[:inactive]
+func parseMember(value string) (*dto.TeamMember, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return nil, newUsageError("invalid member %q, expected user_id:username[:inactive]", value)
+	}
+
+	member := &dto.TeamMember{UserID: parts[0], Username: parts[1], IsActive: true}
+	if len(parts) == 3 {
+		if parts[2] != "inactive" {
+			return nil, newUsageError("invalid member %q, expected user_id:username[:inactive]", value)
+		}
+		member.IsActive = false
+	}
+
+	return member, nil
+}
+
+func teamTable(team *dto.Team) *table {
+	t := newTable("TEAM", "USER_ID", "USERNAME", "ACTIVE", "EMAIL")
+	for _, member := range team.Members {
+		t.add(team.TeamName, member.UserID, member.Username, formatBool(member.IsActive), formatOptional(member.Email))
+	}
+
+	return t
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"github.com/salex06/pr-service/internal/dto"
+)
+
+// runUserActivate переводит сотрудника в активное состояние
+func runUserActivate(args []string) error {
+	return setIsActive("user activate", args, true)
+}
+
+// runUserDeactivate переводит сотрудника в неактивное состояние
+func runUserDeactivate(args []string) error {
+	return setIsActive("user deactivate", args, false)
+}
+
+func setIsActive(name string, args []string, isActive bool) error {
+	fs, opts := newFlagSet(name)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return newUsageError("%s: expected <user_id>", name)
+	}
+
+	ctx, cancel := opts.context()
+	defer cancel()
+
+	var resp struct {
+		User *dto.User `json:"user"`
+	}
+	req := &dto.UserShort{UserID: positional[0], IsActive: isActive}
+	if err := opts.client().post(ctx, "/users/setIsActive", req, &resp); err != nil {
+		return err
+	}
+
+	return render(opts.output, resp.User, userTable(resp.User))
+}
+
+// runUserMove переводит сотрудника в другую команду
+func runUserMove(args []string) error {
+	fs, opts := newFlagSet("user move")
+	teamName := fs.String("team", "", "destination team")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || *teamName == "" {
+		return newUsageError("user move: expected <user_id> --team <team_name>")
+	}
+
+	ctx, cancel := opts.context()
+	defer cancel()
+
+	var resp struct {
+		User *dto.User `json:"user"`
+	}
+	req := &dto.MoveTeamMember{UserID: positional[0], TeamName: *teamName}
+	if err := opts.client().post(ctx, "/team/moveMember", req, &resp); err != nil {
+		return err
+	}
+
+	return render(opts.output, resp.User, userTable(resp.User))
+}
+
+func userTable(user *dto.User) *table {
+	t := newTable("USER_ID", "USERNAME", "TEAM", "ACTIVE")
+	t.add(user.UserID, user.Username, user.TeamName, formatBool(user.IsActive))
+
+	return t
+}
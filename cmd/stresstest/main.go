@@ -1,11 +1,14 @@
 package main
 
 import (
+	"bufio"
 	"encoding/json"
+	"fmt"
 	"log"
 	"math/rand/v2"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	vegeta "github.com/tsenart/vegeta/lib"
@@ -116,16 +119,24 @@ func main() {
 		prCreateRequest, prMergeRequest, prReassignRequest,
 	}
 
+	// Запросы выполняются от имени администратора: токен
+	// должен совпадать с одним из токенов AUTH_STATIC_TOKENS
+	token := os.Getenv("STRESS_TEST_TOKEN")
+	if token == "" {
+		log.Fatal("STRESS_TEST_TOKEN is not set: export an admin token from AUTH_STATIC_TOKENS")
+	}
+
 	targeter := func(t *vegeta.Target) error {
 		request := requests[rand.IntN(len(requests))]
 
 		t.Method = request.Method
 		t.URL = request.URL
+		t.Header = http.Header{
+			"Authorization": []string{"Bearer " + token},
+		}
 		if request.Method == "POST" {
 			t.Body, _ = json.Marshal(request.Body)
-			t.Header = http.Header{
-				"Content-Type": []string{"application/json"},
-			}
+			t.Header.Set("Content-Type", "application/json")
 		}
 
 		return nil
@@ -151,4 +162,34 @@ func main() {
 	if err != nil {
 		log.Printf("couldn't print stress test report: %s", err.Error())
 	}
+
+	err = printServiceMetrics("http://localhost:8080/metrics")
+	if err != nil {
+		log.Printf("couldn't scrape service metrics: %s", err.Error())
+	}
+}
+
+// printServiceMetrics забирает метрики сервиса в формате Prometheus
+// и выводит метрики приложения (без бакетов гистограмм)
+func printServiceMetrics(url string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	fmt.Println("\nService metrics:")
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "pr_service_") && !strings.Contains(line, "_bucket{") {
+			fmt.Println(line)
+		}
+	}
+
+	return scanner.Err()
 }
@@ -0,0 +1,33 @@
+// Package audit - пакет, определяющий сведения об инициаторе изменения,
+// которые передаются через контекст запроса в журнал аудита
+package audit
+
+import "context"
+
+// SystemActor - инициатор изменений, выполняемых
+// вне HTTP-запросов (фоновые задачи)
+const SystemActor = "system"
+
+// Meta представляет сведения об инициаторе изменения:
+// идентификатор клиента и идентификатор запроса
+type Meta struct {
+	Actor     string
+	RequestID string
+}
+
+type metaKey struct{}
+
+// WithMeta возвращает копию контекста, содержащую сведения об инициаторе изменения
+func WithMeta(ctx context.Context, meta Meta) context.Context {
+	return context.WithValue(ctx, metaKey{}, meta)
+}
+
+// MetaFromContext возвращает сведения об инициаторе изменения из контекста
+// (для контекста без сведений инициатором считается SystemActor)
+func MetaFromContext(ctx context.Context) Meta {
+	if meta, ok := ctx.Value(metaKey{}).(Meta); ok {
+		return meta
+	}
+
+	return Meta{Actor: SystemActor}
+}
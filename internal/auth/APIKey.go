@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	apiKeyPrefix      = "prs"
+	apiKeyIDBytes     = 8
+	apiKeySecretBytes = 32
+)
+
+// GenerateAPIKey формирует новый ключ доступа в формате "prs_<key_id>_<secret>"
+// и возвращает публичный идентификатор ключа и сам ключ
+func GenerateAPIKey() (keyID, key string, err error) {
+	id := make([]byte, apiKeyIDBytes)
+	secret := make([]byte, apiKeySecretBytes)
+
+	if _, err := rand.Read(id); err != nil {
+		return "", "", fmt.Errorf("unable to generate api key id: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("unable to generate api key secret: %w", err)
+	}
+
+	keyID = hex.EncodeToString(id)
+	return keyID, fmt.Sprintf("%s_%s_%s", apiKeyPrefix, keyID, hex.EncodeToString(secret)), nil
+}
+
+// ParseAPIKeyID извлекает публичный идентификатор из ключа доступа
+func ParseAPIKeyID(key string) (string, bool) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+
+	return parts[1], true
+}
+
+// HashAPIKey возвращает хеш ключа доступа, который хранится в БД
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
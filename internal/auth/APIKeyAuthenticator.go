@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"log/slog"
+	"time"
+
+	apiKeyRepos "github.com/salex06/pr-service/internal/repos/apikey"
+)
+
+// lastUsedResolution - минимальный интервал между обновлениями
+// времени последнего использования ключа
+const lastUsedResolution = time.Minute
+
+// APIKeyAuthenticator представляет собой компонент,
+// аутентифицирующий машинных клиентов по ключам доступа
+type APIKeyAuthenticator struct {
+	apiKeyRepo *apiKeyRepos.APIKeyRepository
+}
+
+// NewAPIKeyAuthenticator конструирует и возвращает объект APIKeyAuthenticator
+func NewAPIKeyAuthenticator(apiKeyRepo *apiKeyRepos.APIKeyRepository) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{apiKeyRepo: apiKeyRepo}
+}
+
+// Authenticate проверяет, что ключ существует, не отозван и не истек,
+// фиксирует время его использования и возвращает клиента с ролью service
+// и набором разрешений ключа
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	keyID, ok := ParseAPIKeyID(token)
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+
+	key, err := (*a.apiKeyRepo).GetAPIKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if key == nil || !key.IsActive(now) ||
+		subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(HashAPIKey(token))) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := (*a.apiKeyRepo).TouchAPIKey(ctx, keyID, now); err != nil {
+			slog.WarnContext(ctx, "error occured when updating api key usage", "key_id", keyID, "error", err)
+		}
+	}
+
+	scopes := make([]Scope, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, Scope(scope))
+	}
+
+	return &Principal{
+		Subject:  key.Name,
+		Role:     RoleService,
+		APIKeyID: key.KeyID,
+		Scopes:   scopes,
+	}, nil
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidCredentials возвращается, если предъявленный токен
+// не удалось сопоставить ни с одним клиентом
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Authenticator представляет собой интерфейс
+// аутентификации клиента по предъявленному токену
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Principal, error)
+}
+
+// ChainAuthenticator представляет собой компонент, который
+// последовательно опрашивает набор аутентификаторов до первого успешного
+type ChainAuthenticator struct {
+	authenticators []Authenticator
+}
+
+// NewChainAuthenticator конструирует и возвращает объект ChainAuthenticator
+func NewChainAuthenticator(authenticators ...Authenticator) *ChainAuthenticator {
+	return &ChainAuthenticator{authenticators: authenticators}
+}
+
+// Authenticate возвращает клиента, распознанного первым из аутентификаторов
+func (a *ChainAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	for _, authenticator := range a.authenticators {
+		principal, err := authenticator.Authenticate(ctx, token)
+		if err == nil {
+			return principal, nil
+		}
+
+		if !errors.Is(err, ErrInvalidCredentials) {
+			return nil, err
+		}
+	}
+
+	return nil, ErrInvalidCredentials
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/salex06/pr-service/internal/config"
+)
+
+// Claims представляет набор утверждений JWT,
+// необходимых для аутентификации клиента
+type Claims struct {
+	Role     Role   `json:"role"`
+	TeamName string `json:"team,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// JWTAuthenticator представляет собой компонент, аутентифицирующий
+// клиентов по JWT, подписанным алгоритмом HS256 или RS256
+type JWTAuthenticator struct {
+	hmacSecret   []byte
+	rsaPublicKey *rsa.PublicKey
+	parser       *jwt.Parser
+}
+
+// NewJWTAuthenticator конструирует на основе конфига и возвращает объект JWTAuthenticator
+func NewJWTAuthenticator(cfg *config.AuthConfig) (*JWTAuthenticator, error) {
+	authenticator := &JWTAuthenticator{}
+
+	if cfg.JWTSecret != "" {
+		authenticator.hmacSecret = []byte(cfg.JWTSecret)
+	}
+
+	if cfg.JWTPublicKeyFile != "" {
+		pem, err := os.ReadFile(cfg.JWTPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read jwt public key: %w", err)
+		}
+
+		authenticator.rsaPublicKey, err = jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse jwt public key: %w", err)
+		}
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(authenticator.validMethods()),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.JWTIssuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.JWTIssuer))
+	}
+	authenticator.parser = jwt.NewParser(options...)
+
+	return authenticator, nil
+}
+
+// Authenticate проверяет подпись и срок действия токена
+// и возвращает клиента, описанного в утверждениях токена
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	var claims Claims
+	if _, err := a.parser.ParseWithClaims(token, &claims, a.keyFunc); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	if claims.Subject == "" || !claims.Role.IsValid() {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &Principal{
+		Subject:  claims.Subject,
+		Role:     claims.Role,
+		TeamName: claims.TeamName,
+	}, nil
+}
+
+func (a *JWTAuthenticator) keyFunc(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		if a.hmacSecret != nil {
+			return a.hmacSecret, nil
+		}
+	case jwt.SigningMethodRS256.Alg():
+		if a.rsaPublicKey != nil {
+			return a.rsaPublicKey, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected signing method: %s", token.Method.Alg())
+}
+
+func (a *JWTAuthenticator) validMethods() []string {
+	methods := make([]string, 0, 2)
+	if a.hmacSecret != nil {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if a.rsaPublicKey != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	return methods
+}
@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	prRepos "github.com/salex06/pr-service/internal/repos/pr"
+	userRepos "github.com/salex06/pr-service/internal/repos/user"
+)
+
+// Rule представляет правило доступа: возвращает true,
+// если клиенту разрешено выполнить запрос
+type Rule func(c *gin.Context, p *Principal) bool
+
+// Extractor представляет функцию, извлекающую из запроса
+// идентификатор ресурса, к которому обращается клиент
+type Extractor func(c *gin.Context) string
+
+// FromQuery возвращает Extractor, извлекающий значение query-параметра
+func FromQuery(name string) Extractor {
+	return func(c *gin.Context) string {
+		return c.Query(name)
+	}
+}
+
+// FromPath возвращает Extractor, извлекающий значение параметра пути маршрута
+func FromPath(name string) Extractor {
+	return func(c *gin.Context) string {
+		return c.Param(name)
+	}
+}
+
+// FromJSONBody возвращает Extractor, извлекающий строковое поле JSON-тела запроса.
+// Тело кешируется в контексте, поэтому обработчик может повторно его разобрать
+func FromJSONBody(field string) Extractor {
+	return func(c *gin.Context) string {
+		var body map[string]any
+		if err := c.ShouldBindBodyWithJSON(&body); err != nil {
+			return ""
+		}
+
+		value, _ := body[field].(string)
+		return value
+	}
+}
+
+// Authenticated разрешает доступ любому аутентифицированному клиенту
+func Authenticated() Rule {
+	return func(c *gin.Context, p *Principal) bool {
+		return p != nil
+	}
+}
+
+// Roles разрешает доступ клиентам с одной из заданных ролей
+func Roles(roles ...Role) Rule {
+	return func(c *gin.Context, p *Principal) bool {
+		return p.HasRole(roles...)
+	}
+}
+
+// AnyOf разрешает доступ, если выполняется хотя бы одно из правил
+func AnyOf(rules ...Rule) Rule {
+	return func(c *gin.Context, p *Principal) bool {
+		for _, rule := range rules {
+			if rule(c, p) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// AllOf разрешает доступ, если выполняются все правила
+func AllOf(rules ...Rule) Rule {
+	return func(c *gin.Context, p *Principal) bool {
+		for _, rule := range rules {
+			if !rule(c, p) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Self разрешает доступ сотруднику к собственным данным
+func Self(userID Extractor) Rule {
+	return func(c *gin.Context, p *Principal) bool {
+		return p.IsUser(userID(c))
+	}
+}
+
+// LeadOf разрешает доступ руководителю заданной команды
+func LeadOf(teamName Extractor) Rule {
+	return func(c *gin.Context, p *Principal) bool {
+		return p.IsLeadOf(teamName(c))
+	}
+}
+
+// Policy представляет собой компонент, формирующий правила доступа,
+// для проверки которых необходимо обращение к хранилищам
+type Policy struct {
+	userRepo *userRepos.UserRepository
+	prRepo   *prRepos.PullRequestRepository
+}
+
+// NewPolicy конструирует и возвращает объект Policy
+func NewPolicy(userRepo *userRepos.UserRepository, prRepo *prRepos.PullRequestRepository) *Policy {
+	return &Policy{
+		userRepo: userRepo,
+		prRepo:   prRepo,
+	}
+}
+
+// LeadOfUser разрешает доступ руководителю команды, в которой состоит сотрудник
+func (policy *Policy) LeadOfUser(userID Extractor) Rule {
+	return func(c *gin.Context, p *Principal) bool {
+		return policy.IsLeadOfUser(c.Request.Context(), p, userID(c))
+	}
+}
+
+// PullRequestAuthor разрешает доступ автору PR
+func (policy *Policy) PullRequestAuthor(prID Extractor) Rule {
+	return func(c *gin.Context, p *Principal) bool {
+		return policy.IsPullRequestAuthor(c.Request.Context(), p, prID(c))
+	}
+}
+
+// LeadOfPullRequestAuthor разрешает доступ руководителю команды автора PR
+func (policy *Policy) LeadOfPullRequestAuthor(prID Extractor) Rule {
+	return func(c *gin.Context, p *Principal) bool {
+		return policy.IsLeadOfPullRequestAuthor(c.Request.Context(), p, prID(c))
+	}
+}
+
+// IsLeadOfUser проверяет, является ли клиент руководителем
+// команды, в которой состоит сотрудник userID
+func (policy *Policy) IsLeadOfUser(ctx context.Context, p *Principal, userID string) bool {
+	if p.Role != RoleTeamLead {
+		return false
+	}
+
+	user, _ := (*policy.userRepo).GetUser(ctx, userID)
+	return user != nil && user.TeamName == p.TeamName
+}
+
+// IsPullRequestAuthor проверяет, является ли клиент автором PR prID
+func (policy *Policy) IsPullRequestAuthor(ctx context.Context, p *Principal, prID string) bool {
+	pr, _ := (*policy.prRepo).GetPullRequest(ctx, prID)
+	return pr != nil && pr.AuthorID == p.Subject
+}
+
+// IsLeadOfPullRequestAuthor проверяет, является ли клиент
+// руководителем команды автора PR prID
+func (policy *Policy) IsLeadOfPullRequestAuthor(ctx context.Context, p *Principal, prID string) bool {
+	if p.Role != RoleTeamLead {
+		return false
+	}
+
+	pr, _ := (*policy.prRepo).GetPullRequest(ctx, prID)
+	if pr == nil {
+		return false
+	}
+
+	author, _ := (*policy.userRepo).GetUser(ctx, pr.AuthorID)
+	return author != nil && author.TeamName == p.TeamName
+}
@@ -0,0 +1,99 @@
+// Package auth - пакет с компонентами, отвечающими за
+// аутентификацию клиентов и проверку их прав доступа
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Role представляет тип, определяющий роль клиента сервиса
+type Role string
+
+// Константы, определяющие допустимые роли клиентов
+const (
+	RoleAdmin    Role = "admin"
+	RoleTeamLead Role = "team-lead"
+	RoleMember   Role = "member"
+	RoleService  Role = "service"
+)
+
+// IsValid проверяет, является ли роль допустимой
+func (r Role) IsValid() bool {
+	return slices.Contains([]Role{RoleAdmin, RoleTeamLead, RoleMember, RoleService}, r)
+}
+
+// Principal представляет аутентифицированного клиента:
+// идентификатор (совпадает с user_id для сотрудников), роль,
+// название команды (для руководителей и участников команд)
+// и набор разрешений (только для клиентов с ключом доступа)
+type Principal struct {
+	Subject  string
+	Role     Role
+	TeamName string
+	APIKeyID string
+	Scopes   []Scope
+}
+
+// HasRole проверяет, имеет ли клиент одну из заданных ролей
+func (p *Principal) HasRole(roles ...Role) bool {
+	return slices.Contains(roles, p.Role)
+}
+
+// IsUser проверяет, является ли клиент сотрудником userID
+func (p *Principal) IsUser(userID string) bool {
+	return userID != "" && p.Subject == userID
+}
+
+// IsLeadOf проверяет, является ли клиент руководителем команды teamName
+func (p *Principal) IsLeadOf(teamName string) bool {
+	return p.Role == RoleTeamLead && teamName != "" && p.TeamName == teamName
+}
+
+// IsAPIKey проверяет, аутентифицирован ли клиент по ключу доступа
+func (p *Principal) IsAPIKey() bool {
+	return p.APIKeyID != ""
+}
+
+// HasScope проверяет, разрешено ли клиенту действие scope.
+// Ограничения по разрешениям действуют только для ключей доступа
+func (p *Principal) HasScope(scope Scope) bool {
+	return !p.IsAPIKey() || slices.Contains(p.Scopes, scope)
+}
+
+// principalContextKey - ключ контекста, в котором хранится клиент
+// вызова, не связанного с gin.Context (gRPC API)
+type principalContextKey struct{}
+
+// WithPrincipal возвращает контекст с аутентифицированным клиентом p
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, p)
+}
+
+// PrincipalFromContext возвращает клиента, сохраненного
+// в контексте WithPrincipal (nil - если его нет)
+func PrincipalFromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalContextKey{}).(*Principal)
+	return p
+}
+
+const principalKey = "auth.principal"
+
+// SetPrincipal сохраняет аутентифицированного клиента в контексте запроса
+func SetPrincipal(c *gin.Context, p *Principal) {
+	c.Set(principalKey, p)
+}
+
+// GetPrincipal возвращает аутентифицированного клиента
+// из контекста запроса (nil - если запрос не аутентифицирован)
+func GetPrincipal(c *gin.Context) *Principal {
+	if value, ok := c.Get(principalKey); ok {
+		if p, ok := value.(*Principal); ok {
+			return p
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package auth
+
+import "slices"
+
+// Scope представляет тип, определяющий разрешение
+// ключа доступа на выполнение группы действий
+type Scope string
+
+// Константы, определяющие допустимые разрешения ключей доступа
+const (
+	ScopeTeamRead  Scope = "team:read"
+	ScopeTeamWrite Scope = "team:write"
+	ScopeUserRead  Scope = "user:read"
+	ScopeUserWrite Scope = "user:write"
+	ScopePRRead    Scope = "pr:read"
+	ScopePRWrite   Scope = "pr:write"
+	ScopeStatsRead Scope = "stats:read"
+	ScopeAuditRead Scope = "audit:read"
+)
+
+// Scopes возвращает все допустимые разрешения
+func Scopes() []Scope {
+	return []Scope{
+		ScopeTeamRead, ScopeTeamWrite,
+		ScopeUserRead, ScopeUserWrite,
+		ScopePRRead, ScopePRWrite,
+		ScopeStatsRead,
+		ScopeAuditRead,
+	}
+}
+
+// IsValid проверяет, является ли разрешение допустимым
+func (s Scope) IsValid() bool {
+	return slices.Contains(Scopes(), s)
+}
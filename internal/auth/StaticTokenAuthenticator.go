@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+)
+
+// StaticTokenAuthenticator представляет собой компонент,
+// аутентифицирующий клиентов по заранее заданному набору токенов
+type StaticTokenAuthenticator struct {
+	tokens map[string]*Principal
+}
+
+// NewStaticTokenAuthenticator разбирает описание токенов в формате
+// "token=subject:role[:team];..." и возвращает объект StaticTokenAuthenticator
+func NewStaticTokenAuthenticator(spec string) (*StaticTokenAuthenticator, error) {
+	tokens := make(map[string]*Principal)
+
+	for entry := range strings.SplitSeq(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		token, identity, ok := strings.Cut(entry, "=")
+		if !ok || token == "" {
+			return nil, fmt.Errorf("invalid static token entry: %q", entry)
+		}
+
+		parts := strings.Split(identity, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("invalid static token identity: %q", identity)
+		}
+
+		principal := &Principal{Subject: parts[0], Role: Role(parts[1])}
+		if !principal.Role.IsValid() {
+			return nil, fmt.Errorf("unknown role %q for subject %s", parts[1], parts[0])
+		}
+		if len(parts) == 3 {
+			principal.TeamName = parts[2]
+		}
+
+		tokens[token] = principal
+	}
+
+	return &StaticTokenAuthenticator{tokens: tokens}, nil
+}
+
+// Authenticate возвращает клиента, которому принадлежит токен
+func (a *StaticTokenAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	for known, principal := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(known), []byte(token)) == 1 {
+			return principal, nil
+		}
+	}
+
+	return nil, ErrInvalidCredentials
+}
@@ -0,0 +1,106 @@
+// Package cache - пакет с in-memory кешем с ограниченным
+// временем жизни записей (используется декораторами
+// репозиториев и сервисов для кеширования чтения)
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Observer получает результат каждого обращения к кешу name
+// (hit - значение найдено) и используется для сбора метрик
+type Observer func(name string, hit bool)
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Cache представляет потокобезопасный кеш, записи которого
+// действуют в течение ttl после сохранения
+type Cache[V any] struct {
+	name     string
+	ttl      time.Duration
+	observer Observer
+
+	mu        sync.Mutex
+	entries   map[string]entry[V]
+	lastSweep time.Time
+}
+
+// New конструирует и возвращает объект Cache с именем name
+// (observer может быть nil)
+func New[V any](name string, ttl time.Duration, observer Observer) *Cache[V] {
+	return &Cache[V]{
+		name:     name,
+		ttl:      ttl,
+		observer: observer,
+		entries:  make(map[string]entry[V]),
+	}
+}
+
+// Get возвращает действующее значение по ключу key
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if ok && !time.Now().Before(e.expiresAt) {
+		delete(c.entries, key)
+		ok = false
+	}
+	c.mu.Unlock()
+
+	if c.observer != nil {
+		c.observer(c.name, ok)
+	}
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	return e.value, true
+}
+
+// Set сохраняет значение value по ключу key
+func (c *Cache[V]) Set(key string, value V) {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sweep(now)
+	c.entries[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+// Delete удаляет значения по ключам keys
+func (c *Cache[V]) Delete(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+}
+
+// Clear удаляет все значения
+func (c *Cache[V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+}
+
+// sweep удаляет истекшие записи (не чаще, чем раз в ttl),
+// чтобы записи по редко запрашиваемым ключам не накапливались
+func (c *Cache[V]) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < c.ttl {
+		return
+	}
+	c.lastSweep = now
+
+	for key, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -3,6 +3,9 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // DBConfig представляет набор параметров,
@@ -13,14 +16,108 @@ type DBConfig struct {
 	DBUser     string
 	DBPassword string
 	DBName     string
+
+	QueryTimeout time.Duration
 }
 
 // AppConfig представляет набор параметров,
 // определяющих конфигурацию приложения
 type AppConfig struct {
 	ServerPort string
+	GRPCPort   string
+
+	RequestTimeout  time.Duration
+	ShutdownTimeout time.Duration
+
+	// TrustedProxies - адреса и подсети прокси, которым доверяется
+	// заголовок X-Forwarded-For (пусто - IP клиента берется из соединения)
+	TrustedProxies []string
+
+	IdempotencyTTL   time.Duration
+	IdempotencyLease time.Duration
+
+	MigrateOnStartup bool
+}
+
+// LogConfig представляет набор параметров,
+// определяющих формат (json/text) и уровень логирования
+type LogConfig struct {
+	Format string
+	Level  string
+}
+
+// TracingConfig представляет набор параметров, определяющих экспорт
+// трассировок OpenTelemetry: экспортер (none/stdout/otlp), адрес
+// OTLP-коллектора, имя сервиса и долю сэмплируемых трассировок
+type TracingConfig struct {
+	Exporter     string
+	OTLPEndpoint string
+	OTLPInsecure bool
+	ServiceName  string
+	SampleRatio  float64
+}
+
+// SMTPConfig представляет набор параметров
+// подключения к SMTP-серверу для отправки писем
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	From     string
+}
+
+// DigestConfig представляет набор параметров,
+// определяющих рассылку дайджеста ожидающих ревью PR's
+type DigestConfig struct {
+	Enabled bool
+	Period  time.Duration
+	SMTP    *SMTPConfig
+}
+
+// AuthConfig представляет набор параметров,
+// определяющих аутентификацию клиентов сервиса
+type AuthConfig struct {
+	Enabled          bool
+	StaticTokens     string
+	JWTSecret        string
+	JWTPublicKeyFile string
+	JWTIssuer        string
+
+	APIKeyRotationOverlap time.Duration
 }
 
+// RateLimitConfig представляет набор параметров, определяющих ограничение
+// частоты запросов клиента: скорость пополнения (запросов в секунду)
+// и емкость корзины токенов отдельно для чтения и для изменения данных,
+// а также общий бюджет IP-адреса, который расходуется до аутентификации
+type RateLimitConfig struct {
+	Enabled bool
+
+	ReadRate   float64
+	ReadBurst  int
+	WriteRate  float64
+	WriteBurst int
+	IPRate     float64
+	IPBurst    int
+}
+
+// CacheConfig представляет набор параметров, определяющих кеширование
+// чтения команд, сотрудников и статистики (время жизни записей)
+type CacheConfig struct {
+	Enabled bool
+
+	TeamTTL  time.Duration
+	UserTTL  time.Duration
+	StatsTTL time.Duration
+}
+
+// Допустимые периоды рассылки дайджеста
+const (
+	DigestPeriodDaily  = "daily"
+	DigestPeriodWeekly = "weekly"
+)
+
 // LoadDBConfig формирует конфигурацию БД
 // на основе переменных окружения
 func LoadDBConfig() *DBConfig {
@@ -30,6 +127,8 @@ func LoadDBConfig() *DBConfig {
 		DBUser:     getEnv("POSTGRES_USER", "pr-service-admin"),
 		DBPassword: getEnv("POSTGRES_PASSWORD", ""),
 		DBName:     getEnv("POSTGRES_DB", "postgres"),
+
+		QueryTimeout: getEnvDuration("DB_QUERY_TIMEOUT", 5*time.Second),
 	}
 }
 
@@ -38,6 +137,100 @@ func LoadDBConfig() *DBConfig {
 func LoadAppConfig() *AppConfig {
 	return &AppConfig{
 		ServerPort: getEnv("SERVER_PORT", "8080"),
+		GRPCPort:   getEnv("GRPC_PORT", "9090"),
+
+		RequestTimeout:  getEnvDuration("REQUEST_TIMEOUT", 10*time.Second),
+		ShutdownTimeout: getEnvDuration("SHUTDOWN_TIMEOUT", 15*time.Second),
+
+		TrustedProxies: getEnvList("TRUSTED_PROXIES"),
+
+		IdempotencyTTL:   getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		IdempotencyLease: getEnvDuration("IDEMPOTENCY_LEASE", time.Minute),
+
+		MigrateOnStartup: getEnvBool("MIGRATE_ON_STARTUP", true),
+	}
+}
+
+// LoadLogConfig формирует конфигурацию логирования
+// на основе переменных окружения
+func LoadLogConfig() *LogConfig {
+	return &LogConfig{
+		Format: getEnv("LOG_FORMAT", "json"),
+		Level:  getEnv("LOG_LEVEL", "info"),
+	}
+}
+
+// LoadTracingConfig формирует конфигурацию трассировки
+// на основе переменных окружения
+func LoadTracingConfig() *TracingConfig {
+	return &TracingConfig{
+		Exporter:     getEnv("TRACING_EXPORTER", "none"),
+		OTLPEndpoint: getEnv("TRACING_OTLP_ENDPOINT", "localhost:4318"),
+		OTLPInsecure: getEnvBool("TRACING_OTLP_INSECURE", true),
+		ServiceName:  getEnv("TRACING_SERVICE_NAME", "pr-service"),
+		SampleRatio:  getEnvFloat("TRACING_SAMPLE_RATIO", 1),
+	}
+}
+
+// LoadDigestConfig формирует конфигурацию рассылки дайджеста
+// на основе переменных окружения
+func LoadDigestConfig() *DigestConfig {
+	period := 24 * time.Hour
+	if getEnv("DIGEST_PERIOD", DigestPeriodDaily) == DigestPeriodWeekly {
+		period = 7 * 24 * time.Hour
+	}
+
+	return &DigestConfig{
+		Enabled: getEnvBool("DIGEST_ENABLED", false),
+		Period:  period,
+		SMTP: &SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnv("SMTP_PORT", "25"),
+			User:     getEnv("SMTP_USER", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", "pr-service@localhost"),
+		},
+	}
+}
+
+// LoadAuthConfig формирует конфигурацию аутентификации
+// на основе переменных окружения
+func LoadAuthConfig() *AuthConfig {
+	return &AuthConfig{
+		Enabled:          getEnvBool("AUTH_ENABLED", true),
+		StaticTokens:     getEnv("AUTH_STATIC_TOKENS", ""),
+		JWTSecret:        getEnv("AUTH_JWT_HS256_SECRET", ""),
+		JWTPublicKeyFile: getEnv("AUTH_JWT_RS256_PUBLIC_KEY_FILE", ""),
+		JWTIssuer:        getEnv("AUTH_JWT_ISSUER", ""),
+
+		APIKeyRotationOverlap: getEnvDuration("AUTH_API_KEY_ROTATION_OVERLAP", 24*time.Hour),
+	}
+}
+
+// LoadRateLimitConfig формирует конфигурацию ограничения
+// частоты запросов на основе переменных окружения
+func LoadRateLimitConfig() *RateLimitConfig {
+	return &RateLimitConfig{
+		Enabled: getEnvBool("RATE_LIMIT_ENABLED", true),
+
+		ReadRate:   getEnvFloat("RATE_LIMIT_READ_RPS", 50),
+		ReadBurst:  getEnvInt("RATE_LIMIT_READ_BURST", 100),
+		WriteRate:  getEnvFloat("RATE_LIMIT_WRITE_RPS", 10),
+		WriteBurst: getEnvInt("RATE_LIMIT_WRITE_BURST", 20),
+		IPRate:     getEnvFloat("RATE_LIMIT_IP_RPS", 100),
+		IPBurst:    getEnvInt("RATE_LIMIT_IP_BURST", 200),
+	}
+}
+
+// LoadCacheConfig формирует конфигурацию кеширования
+// на основе переменных окружения
+func LoadCacheConfig() *CacheConfig {
+	return &CacheConfig{
+		Enabled: getEnvBool("CACHE_ENABLED", true),
+
+		TeamTTL:  getEnvDuration("CACHE_TEAM_TTL", 30*time.Second),
+		UserTTL:  getEnvDuration("CACHE_USER_TTL", 30*time.Second),
+		StatsTTL: getEnvDuration("CACHE_STATS_TTL", time.Minute),
 	}
 }
 
@@ -48,3 +241,50 @@ func getEnv(key, defaultValue string) string {
 
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func getEnvList(key string) []string {
+	var values []string
+	for value := range strings.SplitSeq(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
@@ -14,6 +14,7 @@ func ConvertTeamMemberToUser(member *dto.TeamMember, teamName string) *entity.Us
 		Username: member.Username,
 		TeamName: teamName,
 		IsActive: member.IsActive,
+		Email:    member.Email,
 	}
 }
 
@@ -24,6 +25,8 @@ func ConvertUserToTeamMember(user *entity.User) *dto.TeamMember {
 		UserID:   user.UserID,
 		Username: user.Username,
 		IsActive: user.IsActive,
+		Email:    user.Email,
+		Version:  user.Version,
 	}
 }
 
@@ -46,6 +49,7 @@ func ConvertUserEntityToDto(user *entity.User) *dto.User {
 		Username: user.Username,
 		TeamName: user.TeamName,
 		IsActive: user.IsActive,
+		Version:  user.Version,
 	}
 }
 
@@ -59,20 +63,42 @@ func ConvertPrDtoToPrEntity(pr *dto.PullRequest) *entity.PullRequest {
 		Status:          pr.Status,
 		CreatedAt:       pr.CreatedAt,
 		MergedAt:        pr.MergedAt,
+		Version:         pr.Version,
+		Repository:      pr.Repository,
+		SourceBranch:    pr.SourceBranch,
+		TargetBranch:    pr.TargetBranch,
+		URL:             pr.URL,
+		Labels:          pr.Labels,
+		LinesChanged:    pr.LinesChanged,
 	}
 }
 
+// ConvertCreatePrToMetadata возвращает метаданные PR
+// из запроса на его создание (отсутствующие метки - пустой список)
+func ConvertCreatePrToMetadata(req *dto.CreatePullRequest) dto.PullRequestMetadata {
+	return ConvertPrToMetadata(&entity.PullRequest{
+		Repository:   req.Repository,
+		SourceBranch: req.SourceBranch,
+		TargetBranch: req.TargetBranch,
+		URL:          req.URL,
+		Labels:       req.Labels,
+		LinesChanged: req.LinesChanged,
+	})
+}
+
 // ConvertPrToDto преобразовывает сущность PullRequest и список
 // назначенных ревьюеров в форму представления PullRequest
 func ConvertPrToDto(pr *entity.PullRequest, reviewers []string) *dto.PullRequest {
 	return &dto.PullRequest{
-		PullRequestID:     pr.PullRequestID,
-		PullRequestName:   pr.PullRequestName,
-		AuthorID:          pr.AuthorID,
-		Status:            pr.Status,
-		AssignedReviewers: reviewers,
-		CreatedAt:         pr.CreatedAt,
-		MergedAt:          pr.MergedAt,
+		PullRequestID:       pr.PullRequestID,
+		PullRequestName:     pr.PullRequestName,
+		AuthorID:            pr.AuthorID,
+		Status:              pr.Status,
+		AssignedReviewers:   reviewers,
+		CreatedAt:           pr.CreatedAt,
+		MergedAt:            pr.MergedAt,
+		Version:             pr.Version,
+		PullRequestMetadata: ConvertPrToMetadata(pr),
 	}
 }
 
@@ -82,13 +108,15 @@ func ConvertPrToDto(pr *entity.PullRequest, reviewers []string) *dto.PullRequest
 func ConvertPrToReassigningDto(pr *entity.PullRequest, reviewers []string, replacedBy string) *dto.ReassignPrResponse {
 	return &dto.ReassignPrResponse{
 		Pr: dto.PullRequest{
-			PullRequestID:     pr.PullRequestID,
-			PullRequestName:   pr.PullRequestName,
-			AuthorID:          pr.AuthorID,
-			Status:            pr.Status,
-			AssignedReviewers: reviewers,
-			CreatedAt:         pr.CreatedAt,
-			MergedAt:          pr.MergedAt,
+			PullRequestID:       pr.PullRequestID,
+			PullRequestName:     pr.PullRequestName,
+			AuthorID:            pr.AuthorID,
+			Status:              pr.Status,
+			AssignedReviewers:   reviewers,
+			CreatedAt:           pr.CreatedAt,
+			MergedAt:            pr.MergedAt,
+			Version:             pr.Version,
+			PullRequestMetadata: ConvertPrToMetadata(pr),
 		},
 		ReplacedBy: replacedBy,
 	}
@@ -113,12 +141,124 @@ func ConvertPrToShortPr(prs []*entity.PullRequest) []dto.PullRequestShort {
 
 	for _, v := range prs {
 		converted = append(converted, dto.PullRequestShort{
-			PullRequestID:   v.PullRequestID,
-			PullRequestName: v.PullRequestName,
-			AuthorID:        v.AuthorID,
-			Status:          v.Status,
+			PullRequestID:       v.PullRequestID,
+			PullRequestName:     v.PullRequestName,
+			AuthorID:            v.AuthorID,
+			Status:              v.Status,
+			PullRequestMetadata: ConvertPrToMetadata(v),
 		})
 	}
 
 	return converted
 }
+
+// ConvertPrToMetadata возвращает метаданные сущности PullRequest
+// (отсутствующие метки - пустой список)
+func ConvertPrToMetadata(pr *entity.PullRequest) dto.PullRequestMetadata {
+	labels := pr.Labels
+	if labels == nil {
+		labels = make([]string, 0)
+	}
+
+	return dto.PullRequestMetadata{
+		Repository:   pr.Repository,
+		SourceBranch: pr.SourceBranch,
+		TargetBranch: pr.TargetBranch,
+		URL:          pr.URL,
+		Labels:       labels,
+		LinesChanged: pr.LinesChanged,
+	}
+}
+
+// ConvertAPIKeyToDto преобразовывает сущность APIKey
+// в форму представления APIKey (без хеша секрета)
+func ConvertAPIKeyToDto(key *entity.APIKey) *dto.APIKey {
+	return &dto.APIKey{
+		KeyID:       key.KeyID,
+		Name:        key.Name,
+		Scopes:      key.Scopes,
+		CreatedAt:   key.CreatedAt,
+		ExpiresAt:   key.ExpiresAt,
+		LastUsedAt:  key.LastUsedAt,
+		RevokedAt:   key.RevokedAt,
+		RotatedFrom: key.RotatedFrom,
+	}
+}
+
+// ConvertAPIKeysToDto преобразовывает слайс сущностей APIKey
+// в слайс объектов формы представления APIKey
+func ConvertAPIKeysToDto(keys []*entity.APIKey) []*dto.APIKey {
+	converted := make([]*dto.APIKey, 0, len(keys))
+	for _, key := range keys {
+		converted = append(converted, ConvertAPIKeyToDto(key))
+	}
+
+	return converted
+}
+
+// ConvertAuditRecordToDto преобразовывает сущность AuditRecord
+// в форму представления AuditRecord
+func ConvertAuditRecordToDto(record *entity.AuditRecord) *dto.AuditRecord {
+	return &dto.AuditRecord{
+		ID:         record.ID,
+		OccurredAt: record.OccurredAt,
+		Actor:      record.Actor,
+		Action:     record.Action,
+		TargetType: record.TargetType,
+		TargetIDs:  record.TargetIDs,
+		Before:     record.Before,
+		After:      record.After,
+		RequestID:  record.RequestID,
+		PrevHash:   record.PrevHash,
+		Hash:       record.Hash,
+	}
+}
+
+// ConvertAuditRecordsToDto преобразовывает слайс сущностей AuditRecord
+// в слайс объектов формы представления AuditRecord
+func ConvertAuditRecordsToDto(records []*entity.AuditRecord) []*dto.AuditRecord {
+	converted := make([]*dto.AuditRecord, 0, len(records))
+	for _, record := range records {
+		converted = append(converted, ConvertAuditRecordToDto(record))
+	}
+
+	return converted
+}
+
+// ConvertAssignmentToDto преобразовывает сущность AssignedReviewers
+// в форму представления ReviewerAssignment
+func ConvertAssignmentToDto(assignment *entity.AssignedReviewers) *dto.ReviewerAssignment {
+	return &dto.ReviewerAssignment{
+		UserID:         assignment.UserID,
+		AssignedAt:     assignment.AssignedAt,
+		Reason:         assignment.Reason,
+		UnassignedAt:   assignment.UnassignedAt,
+		UnassignReason: assignment.UnassignReason,
+		ReplacedBy:     assignment.ReplacedBy,
+	}
+}
+
+// ConvertAssignmentsToTimeline преобразовывает слайс назначений
+// на PR с идентификатором prID в форму представления ReviewerTimeline
+func ConvertAssignmentsToTimeline(prID string, assignments []*entity.AssignedReviewers) *dto.ReviewerTimeline {
+	converted := make([]*dto.ReviewerAssignment, 0, len(assignments))
+	for _, assignment := range assignments {
+		converted = append(converted, ConvertAssignmentToDto(assignment))
+	}
+
+	return &dto.ReviewerTimeline{
+		PullRequestID: prID,
+		Assignments:   converted,
+	}
+}
+
+// ConvertVerdictToDto преобразовывает сущность ReviewVerdict
+// в форму представления ReviewVerdict
+func ConvertVerdictToDto(verdict *entity.ReviewVerdict) *dto.ReviewVerdict {
+	return &dto.ReviewVerdict{
+		PullRequestID: verdict.PullRequestID,
+		UserID:        verdict.UserID,
+		Verdict:       verdict.Verdict,
+		CreatedAt:     verdict.CreatedAt,
+	}
+}
@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Pool представляет пул соединений к БД, который ограничивает
+// время выполнения каждого запроса (и транзакции) значением queryTimeout.
+// Срок выполнения запроса не превышает срок контекста вызывающего кода,
+// поэтому отмена запроса клиентом прерывает запрос к БД и освобождает соединение
+type Pool struct {
+	*pgxpool.Pool
+	queryTimeout time.Duration
+}
+
+// NewPool конструирует и возвращает объект Pool
+// (queryTimeout <= 0 - время выполнения не ограничивается)
+func NewPool(pool *pgxpool.Pool, queryTimeout time.Duration) *Pool {
+	return &Pool{
+		Pool:         pool,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// Exec выполняет запрос, не возвращающий строк
+func (p *Pool) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	ctx, cancel := p.withQueryTimeout(ctx)
+	defer cancel()
+
+	return p.Pool.Exec(ctx, sql, args...)
+}
+
+// Query выполняет запрос, возвращающий набор строк
+// (срок выполнения истекает при закрытии или полном чтении набора)
+func (p *Pool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	ctx, cancel := p.withQueryTimeout(ctx)
+
+	rows, err := p.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return &timedRows{Rows: rows, cancel: cancel}, nil
+}
+
+// QueryRow выполняет запрос, возвращающий не более одной строки
+// (срок выполнения истекает после чтения строки)
+func (p *Pool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	ctx, cancel := p.withQueryTimeout(ctx)
+
+	return &timedRow{Row: p.Pool.QueryRow(ctx, sql, args...), cancel: cancel}
+}
+
+// Begin начинает транзакцию
+// (срок выполнения истекает при ее фиксации или откате)
+func (p *Pool) Begin(ctx context.Context) (pgx.Tx, error) {
+	ctx, cancel := p.withQueryTimeout(ctx)
+
+	tx, err := p.Pool.Begin(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return &timedTx{Tx: tx, cancel: cancel}, nil
+}
+
+func (p *Pool) withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, p.queryTimeout)
+}
+
+type timedRows struct {
+	pgx.Rows
+	cancel context.CancelFunc
+}
+
+func (r *timedRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+
+	r.cancel()
+	return false
+}
+
+func (r *timedRows) Close() {
+	r.Rows.Close()
+	r.cancel()
+}
+
+type timedRow struct {
+	pgx.Row
+	cancel context.CancelFunc
+}
+
+func (r *timedRow) Scan(dest ...any) error {
+	defer r.cancel()
+	return r.Row.Scan(dest...)
+}
+
+type timedTx struct {
+	pgx.Tx
+	cancel context.CancelFunc
+}
+
+func (tx *timedTx) Commit(ctx context.Context) error {
+	defer tx.cancel()
+	return tx.Tx.Commit(ctx)
+}
+
+func (tx *timedTx) Rollback(ctx context.Context) error {
+	defer tx.cancel()
+	return tx.Tx.Rollback(ctx)
+}
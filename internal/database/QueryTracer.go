@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/salex06/pr-service/internal/tracing"
+)
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	sql   string
+	start time.Time
+	span  trace.Span
+}
+
+// QueryTracer представляет трассировщик запросов pgx, который создает
+// спан OpenTelemetry для каждого запроса и записывает в лог завершившиеся
+// ошибкой запросы (уровень warn) и все запросы (уровень debug)
+// с атрибутами из контекста вызывающего кода
+type QueryTracer struct{}
+
+// TraceQueryStart начинает спан запроса и сохраняет
+// в контексте текст запроса и время его начала
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	sql := strings.Join(strings.Fields(data.SQL), " ")
+	operation, _, _ := strings.Cut(sql, " ")
+
+	ctx, span := tracing.Start(ctx, "db "+strings.ToUpper(operation),
+		attribute.String("db.system.name", "postgresql"),
+		attribute.String("db.operation.name", strings.ToUpper(operation)),
+		attribute.String("db.query.text", sql),
+	)
+
+	return context.WithValue(ctx, queryStartKey{}, queryStart{
+		sql:   sql,
+		start: time.Now(),
+		span:  span,
+	})
+}
+
+// TraceQueryEnd записывает в лог результат выполнения запроса
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	query, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+
+	defer query.span.End()
+
+	attrs := []any{
+		"sql", query.sql,
+		"duration_ms", time.Since(query.start).Milliseconds(),
+	}
+
+	if data.Err != nil && !errors.Is(data.Err, pgx.ErrNoRows) {
+		query.span.RecordError(data.Err)
+		query.span.SetStatus(codes.Error, data.Err.Error())
+		slog.WarnContext(ctx, "query failed", append(attrs, "error", data.Err)...)
+		return
+	}
+	query.span.SetAttributes(attribute.Int64("db.response.rows_affected", data.CommandTag.RowsAffected()))
+
+	slog.DebugContext(ctx, "query executed", append(attrs, "rows_affected", data.CommandTag.RowsAffected())...)
+}
@@ -4,7 +4,7 @@ package database
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -15,7 +15,7 @@ import (
 // DB представляет собой структуру,
 // хранящую пул соединений к БД PostgreSQL
 type DB struct {
-	Pool *pgxpool.Pool
+	Pool *Pool
 }
 
 // NewDB конструирует на основе конфига
@@ -34,6 +34,7 @@ func NewDB(cfg *config.DBConfig) (*DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse connection string: %w", err)
 	}
+	poolConfig.ConnConfig.Tracer = &QueryTracer{}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -47,8 +48,8 @@ func NewDB(cfg *config.DBConfig) (*DB, error) {
 		return nil, fmt.Errorf("unable to ping database: %w", err)
 	}
 
-	log.Println("Successfully connected to PostgreSQL")
-	return &DB{Pool: pool}, nil
+	slog.Info("successfully connected to PostgreSQL", "host", cfg.DBHost, "database", cfg.DBName)
+	return &DB{Pool: NewPool(pool, cfg.QueryTimeout)}, nil
 }
 
 // Close закрывает соединение с БД
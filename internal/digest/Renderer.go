@@ -0,0 +1,82 @@
+// Package digest - пакет, отвечающий за формирование
+// текстового и HTML-представления дайджеста ожидающих ревью PR's
+package digest
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmlTemplate "html/template"
+	textTemplate "text/template"
+	"time"
+
+	"github.com/salex06/pr-service/internal/dto"
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+// Renderer представляет собой компонент,
+// формирующий тело письма с дайджестом по шаблонам
+type Renderer struct {
+	text *textTemplate.Template
+	html *htmlTemplate.Template
+}
+
+// NewRenderer загружает шаблоны дайджеста и возвращает объект Renderer
+func NewRenderer() (*Renderer, error) {
+	text, err := textTemplate.New("digest.txt.tmpl").
+		Funcs(textTemplate.FuncMap{"age": formatAge}).
+		ParseFS(templatesFS, "templates/digest.txt.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse text template: %w", err)
+	}
+
+	html, err := htmlTemplate.New("digest.html.tmpl").
+		Funcs(htmlTemplate.FuncMap{"age": formatAge}).
+		ParseFS(templatesFS, "templates/digest.html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse html template: %w", err)
+	}
+
+	return &Renderer{text: text, html: html}, nil
+}
+
+// Subject возвращает тему письма с дайджестом
+func (r *Renderer) Subject(digest *dto.ReviewDigest) string {
+	return fmt.Sprintf("Pending reviews: %d open pull request(s)", len(digest.PullRequests))
+}
+
+// RenderText формирует текстовое представление дайджеста
+func (r *Renderer) RenderText(digest *dto.ReviewDigest) (string, error) {
+	var buf bytes.Buffer
+	if err := r.text.Execute(&buf, digest); err != nil {
+		return "", fmt.Errorf("unable to render text digest: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// RenderHTML формирует HTML-представление дайджеста
+func (r *Renderer) RenderHTML(digest *dto.ReviewDigest) (string, error) {
+	var buf bytes.Buffer
+	if err := r.html.Execute(&buf, digest); err != nil {
+		return "", fmt.Errorf("unable to render html digest: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func formatAge(age time.Duration) string {
+	days := int(age.Hours()) / 24
+	hours := int(age.Hours()) % 24
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	}
+}
@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// APIKey является формой представления сущности APIKey
+// (без хеша секрета)
+type APIKey struct {
+	KeyID       string     `json:"key_id"`
+	Name        string     `json:"name"`
+	Scopes      []string   `json:"scopes"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	RotatedFrom *string    `json:"rotated_from,omitempty"`
+}
+
+// IssuedAPIKey представляет структуру ответа на запрос создания
+// или ротации ключа: информацию о ключе и сам ключ, который
+// возвращается только один раз
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// CreateAPIKey определяет структуру запроса на создание ключа
+// с названием, набором разрешений и (необязательным) сроком действия
+type CreateAPIKey struct {
+	Name      string     `json:"name" binding:"required,max=128"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,dive,scope"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// RevokeAPIKey определяет структуру запроса на отзыв ключа
+type RevokeAPIKey struct {
+	KeyID string `json:"key_id" binding:"required,max=32"`
+}
+
+// RotateAPIKey определяет структуру запроса на ротацию ключа:
+// выпускается новый ключ, а прежний остается действительным
+// в течение периода перекрытия (в секундах)
+type RotateAPIKey struct {
+	KeyID          string `json:"key_id" binding:"required,max=32"`
+	OverlapSeconds *int   `json:"overlap_seconds,omitempty" binding:"omitempty,min=0"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // AppStat - основная структура для хранения и передачи статистики сервиса
 type AppStat struct {
 	TotalUsersCount  int `json:"total_users_count"`
@@ -13,6 +15,8 @@ type AppStat struct {
 	UserCountByTeam []*TeamSize `json:"users_count_by_team"`
 
 	AssignmentsCountByUser []*AssignmentsByUser `json:"assignments_count_by_user"`
+
+	ReviewAnalytics *ReviewAnalytics `json:"review_analytics"`
 }
 
 // TeamSize представляет структуру для хранения
@@ -28,3 +32,67 @@ type AssignmentsByUser struct {
 	UserID           string `json:"user_id"`
 	AssignmentsCount int    `json:"assignments_count"`
 }
+
+// StatsGranularity представляет тип, определяющий
+// размер интервала группировки PR's при подсчете динамики (день/неделя)
+type StatsGranularity string
+
+// Константы, определяющие допустимые интервалы группировки
+const (
+	GranularityDay  StatsGranularity = "day"
+	GranularityWeek StatsGranularity = "week"
+)
+
+// StatsFilter определяет временное окно [From, To) и набор
+// сотрудников (UserIDs, nil - все сотрудники), по которым
+// рассчитывается аналитика ревью
+type StatsFilter struct {
+	From        time.Time
+	To          time.Time
+	TeamName    string
+	UserIDs     []string
+	Granularity StatsGranularity
+}
+
+// ReviewAnalytics представляет аналитику ревью за временное окно:
+// время от создания PR до слияния и до первого решения ревьюера
+// (медиана и 90-й перцентиль, в секундах), динамику открытия
+// и слияния PR's и число переназначений ревьюеров
+type ReviewAnalytics struct {
+	From        time.Time        `json:"from"`
+	To          time.Time        `json:"to"`
+	TeamName    string           `json:"team_name,omitempty"`
+	Granularity StatsGranularity `json:"granularity"`
+
+	MergeTime *MergeTimeStats `json:"merge_time"`
+
+	FirstVerdictTime *FirstVerdictTimeStats `json:"first_verdict_time"`
+
+	Throughput []*ThroughputBucket `json:"throughput"`
+
+	ReassignmentsCount int `json:"reassignments_count"`
+}
+
+// MergeTimeStats представляет распределение времени
+// от создания PR до его слияния (nil - нет слитых PR's)
+type MergeTimeStats struct {
+	MergedCount   int      `json:"merged_count"`
+	MedianSeconds *float64 `json:"median_seconds"`
+	P90Seconds    *float64 `json:"p90_seconds"`
+}
+
+// FirstVerdictTimeStats представляет распределение времени от создания PR
+// до первого решения ревьюера по нему (nil - нет PR's с решениями)
+type FirstVerdictTimeStats struct {
+	PullRequestsCount int      `json:"pull_requests_count"`
+	MedianSeconds     *float64 `json:"median_seconds"`
+	P90Seconds        *float64 `json:"p90_seconds"`
+}
+
+// ThroughputBucket представляет число открытых и слитых
+// PR's за интервал, начинающийся с PeriodStart
+type ThroughputBucket struct {
+	PeriodStart time.Time `json:"period_start"`
+	Opened      int       `json:"opened"`
+	Merged      int       `json:"merged"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// AssignmentEventType представляет тип события
+// назначения ревьюера (назначен/снят с PR)
+type AssignmentEventType string
+
+// Константы, определяющие допустимые типы событий назначения
+const (
+	AssignmentAssigned   AssignmentEventType = "ASSIGNED"
+	AssignmentUnassigned AssignmentEventType = "UNASSIGNED"
+)
+
+// AssignmentEvent представляет событие назначения сотрудника
+// ревьюером PR или снятия его с PR (ReplacedBy - заменивший ревьюер)
+type AssignmentEvent struct {
+	Type          AssignmentEventType     `json:"type"`
+	PullRequestID string                  `json:"pull_request_id"`
+	ReviewerID    string                  `json:"reviewer_id"`
+	Reason        entity.AssignmentReason `json:"reason"`
+	ReplacedBy    *string                 `json:"replaced_by,omitempty"`
+	OccurredAt    time.Time               `json:"occurred_at"`
+}
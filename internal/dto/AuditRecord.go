@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditRecord является формой представления сущности AuditRecord
+type AuditRecord struct {
+	ID         int64           `json:"id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Actor      string          `json:"actor"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetIDs  []string        `json:"target_ids"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	PrevHash   string          `json:"prev_hash"`
+	Hash       string          `json:"hash"`
+}
+
+// AuditFilter определяет набор фильтров для выборки записей журнала аудита
+type AuditFilter struct {
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+// AuditChainVerification представляет результат проверки
+// целостности цепочки хешей журнала аудита
+type AuditChainVerification struct {
+	Valid          bool   `json:"valid"`
+	RecordsChecked int    `json:"records_checked"`
+	BrokenAtID     *int64 `json:"broken_at_id,omitempty"`
+}
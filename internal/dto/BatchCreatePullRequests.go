@@ -0,0 +1,22 @@
+package dto
+
+// BatchCreatePullRequests определяет структуру запроса
+// на создание нескольких (не более 100) PR's за один вызов
+type BatchCreatePullRequests struct {
+	PullRequests []*CreatePullRequest `json:"pull_requests" binding:"required,min=1,max=100,dive,required"`
+}
+
+// BatchItemError определяет причину, по которой PR из пакета не создан
+type BatchItemError struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+// BatchCreateResult определяет результат создания одного PR из пакета:
+// созданный PR с назначенными ревьюерами или причину отказа
+type BatchCreateResult struct {
+	PullRequestID string          `json:"pull_request_id"`
+	Created       bool            `json:"created"`
+	PullRequest   *PullRequest    `json:"pr,omitempty"`
+	Error         *BatchItemError `json:"error,omitempty"`
+}
@@ -2,9 +2,16 @@ package dto
 
 // CreatePullRequest представляет структуру запроса
 // на создание PR с уникальным идентификатором,
-// именем и идентификатором автора
+// именем, идентификатором автора и необязательными метаданными
+// (не более 20 уникальных меток длиной до 64 символов)
 type CreatePullRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID   string   `json:"pull_request_id" binding:"required,max=255"`
+	PullRequestName string   `json:"pull_request_name" binding:"required"`
+	AuthorID        string   `json:"author_id" binding:"required,max=32"`
+	Repository      *string  `json:"repository,omitempty" binding:"omitempty,min=1,max=255"`
+	SourceBranch    *string  `json:"source_branch,omitempty" binding:"omitempty,min=1,max=255"`
+	TargetBranch    *string  `json:"target_branch,omitempty" binding:"omitempty,min=1,max=255"`
+	URL             *string  `json:"url,omitempty" binding:"omitempty,url,max=2048"`
+	Labels          []string `json:"labels,omitempty" binding:"omitempty,max=20,unique,dive,required,max=64"`
+	LinesChanged    *int     `json:"lines_changed,omitempty" binding:"omitempty,min=0"`
 }
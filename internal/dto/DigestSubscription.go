@@ -0,0 +1,10 @@
+package dto
+
+// DigestSubscription определяет структуру запроса
+// на отказ от рассылки (или возобновление рассылки)
+// дайджеста ожидающих ревью PR's
+type DigestSubscription struct {
+	UserID       string `json:"user_id" binding:"required,max=255"`
+	DigestOptOut bool   `json:"digest_opt_out"`
+	IfMatch      string `json:"-"`
+}
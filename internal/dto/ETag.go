@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ETag формирует значение заголовка ETag по версии ресурса
+func ETag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// ETag возвращает значение заголовка ETag команды. Оно зависит от версии
+// команды и версий всех её участников, поэтому меняется и при изменении
+// отдельного участника (например, его активности)
+func (team *Team) ETag() string {
+	members := make([]string, 0, len(team.Members))
+	for _, member := range team.Members {
+		members = append(members, fmt.Sprintf("%s:%d", member.UserID, member.Version))
+	}
+	slices.Sort(members)
+
+	hash := sha256.Sum256(fmt.Appendf(nil, "%d\n%s", team.Version, strings.Join(members, "\n")))
+	return `"` + hex.EncodeToString(hash[:8]) + `"`
+}
+
+// MatchesETag проверяет условие запроса If-Match (список значений ETag
+// через запятую) для текущего значения etag ресурса. Пустое условие
+// (заголовок не передан) и "*" выполняются для любой версии
+func MatchesETag(ifMatch, etag string) bool {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return true
+	}
+
+	for candidate := range strings.SplitSeq(ifMatch, ",") {
+		// слабые ETag (W/"...") сравниваются по значению
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -13,11 +13,40 @@ const (
 	NotAssigned ErrorCode = "NOT_ASSIGNED"
 	NoCandidate ErrorCode = "NO_CANDIDATE"
 	NotFound    ErrorCode = "NOT_FOUND"
+	KeyInactive ErrorCode = "KEY_INACTIVE"
+	Timeout     ErrorCode = "TIMEOUT"
+
+	MemberOfOtherTeam ErrorCode = "MEMBER_OF_OTHER_TEAM"
+	NotAssignable     ErrorCode = "NOT_ASSIGNABLE"
+
+	BadRequest    ErrorCode = "BAD_REQUEST"
+	InternalError ErrorCode = "INTERNAL_ERROR"
+
+	ValidationError ErrorCode = "VALIDATION_ERROR"
+
+	Unauthorized ErrorCode = "UNAUTHORIZED"
+	Forbidden    ErrorCode = "FORBIDDEN"
+
+	PreconditionFailed ErrorCode = "PRECONDITION_FAILED"
+	VersionConflict    ErrorCode = "VERSION_CONFLICT"
+
+	IdempotencyKeyReused ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	IdempotencyKeyInUse  ErrorCode = "IDEMPOTENCY_KEY_IN_USE"
+
+	RateLimited ErrorCode = "RATE_LIMITED"
 )
 
 // ErrorResponse определяет структуру ответа
 // на запрос, обработка которого завершилась неудачно
 type ErrorResponse struct {
-	Status int               `json:"-"`
-	Error  map[string]string `json:"error"` // code-message
+	Status  int               `json:"-"`
+	Error   map[string]string `json:"error"` // code-message
+	Details []*FieldViolation `json:"details,omitempty"`
+}
+
+// FieldViolation описывает нарушение правила валидации
+// поля запроса: путь к полю и причину
+type FieldViolation struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
 }
@@ -0,0 +1,55 @@
+package dto
+
+// LoadStatus представляет тип, определяющий загрузку ревьюера
+// относительно равномерного распределения назначений в команде
+type LoadStatus string
+
+// Константы, определяющие допустимые значения загрузки ревьюера
+const (
+	LoadBalanced    LoadStatus = "BALANCED"
+	LoadOverloaded  LoadStatus = "OVERLOADED"
+	LoadUnderloaded LoadStatus = "UNDERLOADED"
+	LoadInactive    LoadStatus = "INACTIVE"
+)
+
+// ReviewerAssignmentsCount представляет число назначений ревьюера
+// на открытые PR's и общее число его назначений (включая снятые)
+type ReviewerAssignmentsCount struct {
+	UserID           string
+	OpenAssignments  int
+	TotalAssignments int
+}
+
+// FairnessReport представляет отчет о равномерности
+// распределения назначений на ревью по командам
+type FairnessReport struct {
+	Teams []*TeamFairness `json:"teams"`
+}
+
+// TeamFairness представляет распределение назначений внутри команды:
+// загрузку каждого участника, коэффициент Джини (0 - полностью равномерное
+// распределение, 1 - все назначения у одного сотрудника) и списки
+// перегруженных и недогруженных сотрудников
+type TeamFairness struct {
+	TeamName           string          `json:"team_name"`
+	TotalAssignments   int             `json:"total_assignments"`
+	GiniCoefficient    float64         `json:"gini_coefficient"`
+	Members            []*ReviewerLoad `json:"members"`
+	OverloadedUserIDs  []string        `json:"overloaded_user_ids"`
+	UnderloadedUserIDs []string        `json:"underloaded_user_ids"`
+}
+
+// ReviewerLoad представляет загрузку ревьюера: число назначений на открытые PR's,
+// общее число назначений (включая снятые), долю от назначений активных
+// участников команды и долю при равномерном распределении между ними
+// (для неактивных сотрудников обе доли - 0)
+type ReviewerLoad struct {
+	UserID           string     `json:"user_id"`
+	Username         string     `json:"username"`
+	IsActive         bool       `json:"is_active"`
+	OpenAssignments  int        `json:"open_assignments"`
+	TotalAssignments int        `json:"total_assignments"`
+	Share            float64    `json:"share"`
+	ExpectedShare    float64    `json:"expected_share"`
+	LoadStatus       LoadStatus `json:"load_status"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+// Константы, определяющие состояние сервиса и его зависимостей
+const (
+	HealthOK          = "ok"
+	HealthUnavailable = "unavailable"
+)
+
+// HealthStatus представляет результат проверки готовности сервиса:
+// общее состояние, результаты отдельных проверок и версию схемы БД
+type HealthStatus struct {
+	Status        string            `json:"status"`
+	Checks        map[string]string `json:"checks,omitempty"`
+	SchemaVersion *int64            `json:"schema_version,omitempty"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// ManualAssignment определяет структуру запроса на ручное
+// назначение сотрудника ревьюером PR или снятие его с PR
+type ManualAssignment struct {
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255"`
+	UserID        string `json:"user_id" binding:"required,max=255"`
+	IfMatch       string `json:"-"`
+}
@@ -3,5 +3,6 @@ package dto
 // MergePullRequest определяет структуру запроса
 // на закрытие PR и перевода его в статус MERGED
 type MergePullRequest struct {
-	PullRequestID string `json:"pull_request_id"`
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255"`
+	IfMatch       string `json:"-"`
 }
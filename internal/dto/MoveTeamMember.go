@@ -0,0 +1,9 @@
+package dto
+
+// MoveTeamMember определяет структуру запроса на перевод
+// сотрудника с идентификатором UserID в команду TeamName
+type MoveTeamMember struct {
+	UserID   string `json:"user_id" binding:"required,max=255"`
+	TeamName string `json:"team_name" binding:"required,max=128"`
+	IfMatch  string `json:"-"`
+}
@@ -13,6 +13,7 @@ const MaxAssignedReviewers = 2
 // PullRequest является формой представления сущности PullRequest
 // с идентификатором, названием, идентификатором автора, статусом,
 // назначенными сотрудниками, временем создания PR и временем его закрытия
+// и метаданными PR (см. PullRequestMetadata). Версия передается в заголовке ETag
 type PullRequest struct {
 	PullRequestID     string                   `json:"pull_request_id"`
 	PullRequestName   string                   `json:"pull_request_name"`
@@ -21,4 +22,29 @@ type PullRequest struct {
 	AssignedReviewers []string                 `json:"assigned_reviewers"`
 	CreatedAt         *time.Time               `json:"createdAt,omitempty"`
 	MergedAt          *time.Time               `json:"mergedAt,omitempty"`
+	Version           int64                    `json:"-"`
+	PullRequestMetadata
+}
+
+// PullRequestMetadata содержит необязательные метаданные PR:
+// репозиторий, исходную и целевую ветки, ссылку на PR,
+// метки (всегда передаются списком) и число измененных строк
+type PullRequestMetadata struct {
+	Repository   *string  `json:"repository,omitempty"`
+	SourceBranch *string  `json:"source_branch,omitempty"`
+	TargetBranch *string  `json:"target_branch,omitempty"`
+	URL          *string  `json:"url,omitempty"`
+	Labels       []string `json:"labels"`
+	LinesChanged *int     `json:"lines_changed,omitempty"`
+}
+
+// PullRequestFilter определяет набор фильтров
+// для выборки PR's (пустое значение - без фильтра)
+type PullRequestFilter struct {
+	Status     entity.PullRequestStatus
+	AuthorID   string
+	Repository string
+	Label      string
+	Limit      int
+	Offset     int
 }
@@ -3,6 +3,7 @@ package dto
 // ReassignPullRequest определяет структуру запроса
 // на переназначение сотрудника на PR
 type ReassignPullRequest struct {
-	PullRequestID string `json:"pull_request_id"`
-	OldReviewerID string `json:"old_reviewer_id"`
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255"`
+	OldReviewerID string `json:"old_reviewer_id" binding:"required,max=255"`
+	IfMatch       string `json:"-"`
 }
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// ReviewDigest представляет дайджест ожидающих ревью PR's
+// для одного сотрудника: получателя, время формирования
+// и список открытых PR's, отсортированных по возрасту
+type ReviewDigest struct {
+	UserID       string         `json:"user_id"`
+	Username     string         `json:"username"`
+	GeneratedAt  time.Time      `json:"generated_at"`
+	PullRequests []*DigestEntry `json:"pull_requests"`
+}
+
+// DigestEntry представляет запись дайджеста - открытый PR
+// с временем создания и возрастом на момент формирования дайджеста
+type DigestEntry struct {
+	PullRequestID   string        `json:"pull_request_id"`
+	PullRequestName string        `json:"pull_request_name"`
+	AuthorID        string        `json:"author_id"`
+	CreatedAt       *time.Time    `json:"createdAt,omitempty"`
+	Age             time.Duration `json:"-"`
+}
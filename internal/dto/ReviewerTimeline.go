@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// ReviewerTimeline представляет полную историю назначений
+// ревьюеров на PR (в порядке назначения)
+type ReviewerTimeline struct {
+	PullRequestID string                `json:"pull_request_id"`
+	Assignments   []*ReviewerAssignment `json:"assignments"`
+}
+
+// ReviewerAssignment является формой представления сущности AssignedReviewers:
+// сотрудник, время и причина назначения, время и причина снятия,
+// идентификатор сотрудника, назначенного на замену
+type ReviewerAssignment struct {
+	UserID         string                   `json:"user_id"`
+	AssignedAt     *time.Time               `json:"assigned_at,omitempty"`
+	Reason         entity.AssignmentReason  `json:"reason"`
+	UnassignedAt   *time.Time               `json:"unassigned_at,omitempty"`
+	UnassignReason *entity.AssignmentReason `json:"unassign_reason,omitempty"`
+	ReplacedBy     *string                  `json:"replaced_by,omitempty"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// SubmitVerdict определяет структуру запроса
+// на вынесение ревьюером решения по PR
+type SubmitVerdict struct {
+	PullRequestID string         `json:"pull_request_id" binding:"required,max=255"`
+	UserID        string         `json:"user_id" binding:"required,max=255"`
+	Verdict       entity.Verdict `json:"verdict" binding:"required,oneof=APPROVED CHANGES_REQUESTED"`
+}
+
+// ReviewVerdict является формой представления сущности ReviewVerdict
+type ReviewVerdict struct {
+	PullRequestID string         `json:"pull_request_id"`
+	UserID        string         `json:"user_id"`
+	Verdict       entity.Verdict `json:"verdict"`
+	CreatedAt     *time.Time     `json:"created_at,omitempty"`
+}
@@ -1,8 +1,14 @@
 package dto
 
 // Team является формой представления сущности Team
-// с названием команды и её представителями
+// с названием команды и её представителями. Версия команды
+// используется для формирования заголовка ETag (см. ETag),
+// IfMatch - условие If-Match запроса на изменение команды,
+// MoveMembers - разрешение переводить в команду участников других команд
 type Team struct {
-	TeamName string        `json:"team_name"`
-	Members  []*TeamMember `json:"members"`
+	TeamName    string        `json:"team_name" binding:"required,max=128"`
+	Members     []*TeamMember `json:"members" binding:"required,dive"`
+	Version     int64         `json:"-"`
+	IfMatch     string        `json:"-"`
+	MoveMembers bool          `json:"-"`
 }
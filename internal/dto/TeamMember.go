@@ -1,9 +1,12 @@
 package dto
 
 // TeamMember является формой представления сущности User
-// с уникальным идентификатором, именем и флагом активности
+// с уникальным идентификатором, именем, флагом активности
+// и (необязательным) адресом электронной почты
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID   string  `json:"user_id" binding:"required,max=255"`
+	Username string  `json:"username" binding:"required,max=32"`
+	IsActive bool    `json:"is_active"`
+	Email    *string `json:"email,omitempty" binding:"omitempty,email,max=255"`
+	Version  int64   `json:"-"`
 }
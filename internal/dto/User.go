@@ -2,10 +2,11 @@ package dto
 
 // User является формой представления сущности User
 // с уникальным идентификатором, именем, названием команды
-// и флагом активности
+// и флагом активности. Версия передается в заголовке ETag
 type User struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
+	Version  int64  `json:"-"`
 }
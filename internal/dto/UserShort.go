@@ -5,6 +5,7 @@ package dto
 // UserShort является формой представления сущности User
 // с уникальным идентификатором и флагом активности
 type UserShort struct {
-	UserID   string `json:"user_id"`
+	UserID   string `json:"user_id" binding:"required,max=255"`
 	IsActive bool   `json:"is_active"`
+	IfMatch  string `json:"-"`
 }
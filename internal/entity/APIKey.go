@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// APIKey представляет сущность ключа доступа для машинных клиентов
+// с публичным идентификатором, хешем секрета, набором разрешений (scopes),
+// сроком действия, временем последнего использования и отзыва
+type APIKey struct {
+	KeyID       string
+	Name        string
+	KeyHash     string
+	Scopes      []string
+	CreatedAt   *time.Time
+	ExpiresAt   *time.Time
+	LastUsedAt  *time.Time
+	RevokedAt   *time.Time
+	RotatedFrom *string
+}
+
+// IsActive проверяет, что ключ не отозван и не истек к моменту now
+func (key *APIKey) IsActive(now time.Time) bool {
+	if key.RevokedAt != nil {
+		return false
+	}
+
+	return key.ExpiresAt == nil || now.Before(*key.ExpiresAt)
+}
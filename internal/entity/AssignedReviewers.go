@@ -1,8 +1,33 @@
 package entity
 
+import "time"
+
+// AssignmentReason представляет тип, определяющий причину
+// назначения сотрудника на PR или снятия его с PR
+type AssignmentReason string
+
+// Константы, определяющие допустимые причины назначения/снятия ревьюера
+const (
+	InitialAssignment      AssignmentReason = "initial"
+	ReassignAssignment     AssignmentReason = "reassign"
+	DeactivationAssignment AssignmentReason = "deactivation"
+	ManualAssignment       AssignmentReason = "manual"
+)
+
 // AssignedReviewers представляет собой сущность,
-// связывающую PR с назначенными сотрудниками
+// связывающую PR с назначенными сотрудниками.
+// Назначение считается действующим, пока не задано время снятия UnassignedAt
 type AssignedReviewers struct {
-	UserID        string
-	PullRequestID string
+	UserID         string
+	PullRequestID  string
+	AssignedAt     *time.Time
+	UnassignedAt   *time.Time
+	Reason         AssignmentReason
+	UnassignReason *AssignmentReason
+	ReplacedBy     *string
+}
+
+// IsActive проверяет, является ли назначение действующим
+func (a *AssignedReviewers) IsActive() bool {
+	return a.UnassignedAt == nil
 }
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// AuditRecord представляет сущность записи журнала аудита:
+// кто (Actor), когда и какое действие выполнил над какими объектами,
+// состояние объектов до и после изменения, идентификатор запроса,
+// а также хеш предыдущей записи и собственный хеш (цепочка хешей)
+type AuditRecord struct {
+	ID         int64
+	OccurredAt time.Time
+	Actor      string
+	Action     string
+	TargetType string
+	TargetIDs  []string
+	Before     json.RawMessage
+	After      json.RawMessage
+	RequestID  string
+	PrevHash   string
+	Hash       string
+}
+
+// ComputeHash вычисляет хеш записи с учетом хеша предыдущей записи.
+// Изменение любой записи журнала нарушает цепочку хешей всех последующих записей
+func (r *AuditRecord) ComputeHash() string {
+	h := sha256.New()
+	for _, field := range []string{
+		r.PrevHash,
+		r.OccurredAt.UTC().Format(time.RFC3339Nano),
+		r.Actor,
+		r.Action,
+		r.TargetType,
+		strings.Join(r.TargetIDs, ","),
+		string(r.Before),
+		string(r.After),
+		r.RequestID,
+	} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
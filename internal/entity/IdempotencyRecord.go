@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+// IdempotencyRecord представляет сущность ключа идемпотентности:
+// ключ (с учетом клиента), хеш исходного запроса и сохраненный ответ на него.
+// Пока запрос обрабатывается, ответ отсутствует (StatusCode == 0), а ключ
+// удерживается до LockedUntil; CreatedAt отличает резервирования ключа друг от друга.
+// ResponseHeaders - заголовки ответа, воспроизводимые вместе с телом
+type IdempotencyRecord struct {
+	Key             string
+	RequestHash     string
+	StatusCode      int
+	ContentType     string
+	ResponseHeaders map[string]string
+	ResponseBody    []byte
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+	LockedUntil     time.Time
+}
+
+// IsCompleted проверяет, сохранен ли ответ на запрос
+func (record *IdempotencyRecord) IsCompleted() bool {
+	return record.StatusCode != 0
+}
+
+// IsExpired проверяет, истек ли срок хранения ключа к моменту now
+func (record *IdempotencyRecord) IsExpired(now time.Time) bool {
+	return !now.Before(record.ExpiresAt)
+}
+
+// IsStale проверяет, истек ли к моменту now срок резервирования ключа
+// запросом, ответ на который так и не был сохранен (например, если
+// экземпляр сервиса остановился во время обработки)
+func (record *IdempotencyRecord) IsStale(now time.Time) bool {
+	return !record.IsCompleted() && !now.Before(record.LockedUntil)
+}
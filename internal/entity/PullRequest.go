@@ -13,8 +13,10 @@ const (
 )
 
 // PullRequest представляет сущность
-// с идентификатором, названием, автором, статусом и
-// набором назначенных ревьюеров (до 2)
+// с идентификатором, названием, автором, статусом,
+// временем создания и закрытия, версией (увеличивается при каждом изменении)
+// и необязательными метаданными: репозиторием, исходной и целевой ветками,
+// ссылкой, метками и размером изменений
 type PullRequest struct {
 	PullRequestID   string
 	PullRequestName string
@@ -22,4 +24,11 @@ type PullRequest struct {
 	Status          PullRequestStatus
 	CreatedAt       *time.Time
 	MergedAt        *time.Time
+	Version         int64
+	Repository      *string
+	SourceBranch    *string
+	TargetBranch    *string
+	URL             *string
+	Labels          []string
+	LinesChanged    *int
 }
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimit представляет бюджет запросов в виде корзины токенов:
+// корзина вмещает Burst токенов и пополняется со скоростью Rate токенов
+// в секунду, каждый запрос расходует один токен
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// IsEnabled проверяет, ограничивает ли бюджет число запросов
+func (limit RateLimit) IsEnabled() bool {
+	return limit.Rate > 0 && limit.Burst > 0
+}
+
+// RefillTime возвращает время, за которое корзина
+// пополняется на tokens токенов
+func (limit RateLimit) RefillTime(tokens float64) time.Duration {
+	return time.Duration(math.Ceil(tokens / limit.Rate * float64(time.Second)))
+}
+
+// RateLimitDecision представляет результат списания токена: разрешен ли
+// запрос, сколько токенов осталось и через какое время появится следующий
+// токен (для отклоненного запроса)
+type RateLimitDecision struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// Verdict представляет тип, определяющий
+// решение ревьюера по PR (одобрить/запросить изменения)
+type Verdict string
+
+// Константы, определяющие допустимые решения ревьюера
+const (
+	Approved         Verdict = "APPROVED"
+	ChangesRequested Verdict = "CHANGES_REQUESTED"
+)
+
+// ReviewVerdict представляет сущность решения ревьюера по PR.
+// Ревьюер может выносить решение повторно: сохраняются все решения
+type ReviewVerdict struct {
+	PullRequestID string
+	UserID        string
+	Verdict       Verdict
+	CreatedAt     *time.Time
+}
@@ -1,7 +1,8 @@
 package entity
 
 // Team представляет сущность группы пользователей
-// с уникальным именем
+// с уникальным именем и версией (увеличивается при каждом изменении команды)
 type Team struct {
 	TeamName string
+	Version  int64
 }
@@ -3,10 +3,15 @@ package entity
 
 // User представляет сущность пользователя -
 // участника команды с уникальным идентификатором,
-// именем и флагом активности
+// именем, флагом активности, адресом электронной почты,
+// флагом отказа от рассылки дайджеста и версией
+// (увеличивается при каждом изменении)
 type User struct {
-	UserID   string
-	Username string
-	TeamName string
-	IsActive bool
+	UserID       string
+	Username     string
+	TeamName     string
+	IsActive     bool
+	Email        *string
+	DigestOptOut bool
+	Version      int64
 }
@@ -0,0 +1,8 @@
+package entity
+
+import "errors"
+
+// ErrStaleVersion возвращается репозиториями при обновлении сущности,
+// версия которой в хранилище отличается от версии, прочитанной клиентом
+// (сущность была изменена параллельным запросом)
+var ErrStaleVersion = errors.New("entity version is stale")
@@ -0,0 +1,158 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/salex06/pr-service/internal/auth"
+	pb "github.com/salex06/pr-service/internal/grpcapi/prservicev1"
+)
+
+// rule представляет правило доступа к методу: возвращает true,
+// если клиенту разрешен вызов с сообщением запроса req
+// (для потоковых методов req - nil)
+type rule func(ctx context.Context, p *auth.Principal, req any) bool
+
+// extractor извлекает из сообщения запроса идентификатор ресурса,
+// к которому обращается клиент
+type extractor func(req any) string
+
+// access определяет разрешение ключа доступа, необходимое для вызова
+// метода, и правило доступа для остальных клиентов
+type access struct {
+	scope auth.Scope
+	rule  rule
+}
+
+// newMethodAccess определяет права, необходимые для вызова методов
+// (методы, отсутствующие в таблице, запрещены). Правила совпадают
+// с правилами соответствующих маршрутов REST API
+func newMethodAccess(policy *auth.Policy) map[string]access {
+	prID := func(req any) string {
+		switch r := req.(type) {
+		case *pb.MergePullRequestRequest:
+			return r.GetPullRequestId()
+		case *pb.ReassignReviewerRequest:
+			return r.GetPullRequestId()
+		}
+		return ""
+	}
+
+	return map[string]access{
+		pb.TeamService_AddTeam_FullMethodName: {scope: auth.ScopeTeamWrite,
+			rule: leadOf(field(func(r *pb.AddTeamRequest) string { return r.GetTeam().GetTeamName() }))},
+		pb.TeamService_GetTeam_FullMethodName: {scope: auth.ScopeTeamRead, rule: authenticated},
+		pb.TeamService_DeactivateTeamMembers_FullMethodName: {scope: auth.ScopeTeamWrite,
+			rule: leadOf(field((*pb.DeactivateTeamMembersRequest).GetTeamName))},
+		pb.TeamService_MoveTeamMember_FullMethodName: {scope: auth.ScopeTeamWrite, rule: allOf(
+			leadOfUser(policy, field((*pb.MoveTeamMemberRequest).GetUserId)),
+			leadOf(field((*pb.MoveTeamMemberRequest).GetTeamName)),
+		)},
+
+		pb.UserService_SetIsActive_FullMethodName: {scope: auth.ScopeUserWrite,
+			rule: leadOfUser(policy, field((*pb.SetIsActiveRequest).GetUserId))},
+		pb.UserService_GetReview_FullMethodName: {scope: auth.ScopeUserRead, rule: authenticated},
+
+		pb.PullRequestService_CreatePullRequest_FullMethodName: {scope: auth.ScopePRWrite, rule: anyOf(
+			roles(auth.RoleService),
+			self(field((*pb.CreatePullRequestRequest).GetAuthorId)),
+		)},
+		pb.PullRequestService_MergePullRequest_FullMethodName: {scope: auth.ScopePRWrite,
+			rule: pullRequestAuthor(policy, prID)},
+		pb.PullRequestService_ReassignReviewer_FullMethodName: {scope: auth.ScopePRWrite, rule: anyOf(
+			roles(auth.RoleService),
+			pullRequestAuthor(policy, prID),
+			leadOfPullRequestAuthor(policy, prID),
+		)},
+		pb.PullRequestService_SubmitVerdict_FullMethodName: {scope: auth.ScopePRWrite,
+			rule: self(field((*pb.SubmitVerdictRequest).GetUserId))},
+		pb.PullRequestService_ListPullRequests_FullMethodName: {scope: auth.ScopePRRead, rule: authenticated},
+		pb.PullRequestService_WatchAssignments_FullMethodName: {scope: auth.ScopePRRead, rule: authenticated},
+
+		pb.StatsService_GetStats_FullMethodName:    {scope: auth.ScopeStatsRead, rule: authenticated},
+		pb.StatsService_GetFairness_FullMethodName: {scope: auth.ScopeStatsRead, rule: authenticated},
+	}
+}
+
+// field возвращает extractor, читающий поле запроса типа T
+// (для запроса другого типа - пустую строку)
+func field[T any](get func(T) string) extractor {
+	return func(req any) string {
+		if r, ok := req.(T); ok {
+			return get(r)
+		}
+		return ""
+	}
+}
+
+// authenticated разрешает вызов любому аутентифицированному клиенту
+func authenticated(ctx context.Context, p *auth.Principal, req any) bool {
+	return p != nil
+}
+
+// roles разрешает вызов клиентам с одной из заданных ролей
+func roles(roles ...auth.Role) rule {
+	return func(ctx context.Context, p *auth.Principal, req any) bool {
+		return p.HasRole(roles...)
+	}
+}
+
+// anyOf разрешает вызов, если выполняется хотя бы одно из правил
+func anyOf(rules ...rule) rule {
+	return func(ctx context.Context, p *auth.Principal, req any) bool {
+		for _, r := range rules {
+			if r(ctx, p, req) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// allOf разрешает вызов, если выполняются все правила
+func allOf(rules ...rule) rule {
+	return func(ctx context.Context, p *auth.Principal, req any) bool {
+		for _, r := range rules {
+			if !r(ctx, p, req) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// self разрешает вызов сотруднику для собственных данных
+func self(userID extractor) rule {
+	return func(ctx context.Context, p *auth.Principal, req any) bool {
+		return p.IsUser(userID(req))
+	}
+}
+
+// leadOf разрешает вызов руководителю заданной команды
+func leadOf(teamName extractor) rule {
+	return func(ctx context.Context, p *auth.Principal, req any) bool {
+		return p.IsLeadOf(teamName(req))
+	}
+}
+
+// leadOfUser разрешает вызов руководителю команды, в которой состоит сотрудник
+func leadOfUser(policy *auth.Policy, userID extractor) rule {
+	return func(ctx context.Context, p *auth.Principal, req any) bool {
+		return policy.IsLeadOfUser(ctx, p, userID(req))
+	}
+}
+
+// pullRequestAuthor разрешает вызов автору PR
+func pullRequestAuthor(policy *auth.Policy, prID extractor) rule {
+	return func(ctx context.Context, p *auth.Principal, req any) bool {
+		return policy.IsPullRequestAuthor(ctx, p, prID(req))
+	}
+}
+
+// leadOfPullRequestAuthor разрешает вызов руководителю команды автора PR
+func leadOfPullRequestAuthor(policy *auth.Policy, prID extractor) rule {
+	return func(ctx context.Context, p *auth.Principal, req any) bool {
+		return policy.IsLeadOfPullRequestAuthor(ctx, p, prID(req))
+	}
+}
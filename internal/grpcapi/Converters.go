@@ -0,0 +1,289 @@
+package grpcapi
+
+import (
+	"math"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+	pb "github.com/salex06/pr-service/internal/grpcapi/prservicev1"
+)
+
+func teamFromProto(team *pb.Team) *dto.Team {
+	if team == nil {
+		return &dto.Team{}
+	}
+
+	members := make([]*dto.TeamMember, 0, len(team.GetMembers()))
+	for _, member := range team.GetMembers() {
+		members = append(members, &dto.TeamMember{
+			UserID:   member.GetUserId(),
+			Username: member.GetUsername(),
+			IsActive: member.GetIsActive(),
+			Email:    member.Email,
+		})
+	}
+
+	return &dto.Team{TeamName: team.GetTeamName(), Members: members}
+}
+
+func teamToProto(team *dto.Team) *pb.Team {
+	members := make([]*pb.TeamMember, 0, len(team.Members))
+	for _, member := range team.Members {
+		members = append(members, &pb.TeamMember{
+			UserId:   member.UserID,
+			Username: member.Username,
+			IsActive: member.IsActive,
+			Email:    member.Email,
+		})
+	}
+
+	return &pb.Team{TeamName: team.TeamName, Members: members}
+}
+
+func userToProto(user *dto.User) *pb.User {
+	return &pb.User{
+		UserId:   user.UserID,
+		Username: user.Username,
+		TeamName: user.TeamName,
+		IsActive: user.IsActive,
+	}
+}
+
+func statusToProto(status entity.PullRequestStatus) pb.PullRequestStatus {
+	switch status {
+	case entity.OPEN:
+		return pb.PullRequestStatus_PULL_REQUEST_STATUS_OPEN
+	case entity.MERGED:
+		return pb.PullRequestStatus_PULL_REQUEST_STATUS_MERGED
+	default:
+		return pb.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+	}
+}
+
+func statusFromProto(status pb.PullRequestStatus) entity.PullRequestStatus {
+	switch status {
+	case pb.PullRequestStatus_PULL_REQUEST_STATUS_OPEN:
+		return entity.OPEN
+	case pb.PullRequestStatus_PULL_REQUEST_STATUS_MERGED:
+		return entity.MERGED
+	default:
+		return ""
+	}
+}
+
+func verdictToProto(verdict entity.Verdict) pb.ReviewVerdict {
+	switch verdict {
+	case entity.Approved:
+		return pb.ReviewVerdict_REVIEW_VERDICT_APPROVED
+	case entity.ChangesRequested:
+		return pb.ReviewVerdict_REVIEW_VERDICT_CHANGES_REQUESTED
+	default:
+		return pb.ReviewVerdict_REVIEW_VERDICT_UNSPECIFIED
+	}
+}
+
+func verdictFromProto(verdict pb.ReviewVerdict) entity.Verdict {
+	switch verdict {
+	case pb.ReviewVerdict_REVIEW_VERDICT_APPROVED:
+		return entity.Approved
+	case pb.ReviewVerdict_REVIEW_VERDICT_CHANGES_REQUESTED:
+		return entity.ChangesRequested
+	default:
+		return ""
+	}
+}
+
+func pullRequestToProto(pr *dto.PullRequest) *pb.PullRequest {
+	return &pb.PullRequest{
+		PullRequestId:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorId:          pr.AuthorID,
+		Status:            statusToProto(pr.Status),
+		AssignedReviewers: pr.AssignedReviewers,
+		CreatedAt:         timestampToProto(pr.CreatedAt),
+		MergedAt:          timestampToProto(pr.MergedAt),
+		Repository:        pr.Repository,
+		SourceBranch:      pr.SourceBranch,
+		TargetBranch:      pr.TargetBranch,
+		Url:               pr.URL,
+		Labels:            pr.Labels,
+		LinesChanged:      optionalInt32ToProto(pr.LinesChanged),
+	}
+}
+
+func pullRequestsShortToProto(prs []dto.PullRequestShort) []*pb.PullRequestShort {
+	result := make([]*pb.PullRequestShort, 0, len(prs))
+	for _, pr := range prs {
+		result = append(result, &pb.PullRequestShort{
+			PullRequestId:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorId:        pr.AuthorID,
+			Status:          statusToProto(pr.Status),
+			Repository:      pr.Repository,
+			SourceBranch:    pr.SourceBranch,
+			TargetBranch:    pr.TargetBranch,
+			Url:             pr.URL,
+			Labels:          pr.Labels,
+			LinesChanged:    optionalInt32ToProto(pr.LinesChanged),
+		})
+	}
+
+	return result
+}
+
+func assignmentEventToProto(event *dto.AssignmentEvent) *pb.AssignmentEvent {
+	eventType := pb.AssignmentEventType_ASSIGNMENT_EVENT_TYPE_ASSIGNED
+	if event.Type == dto.AssignmentUnassigned {
+		eventType = pb.AssignmentEventType_ASSIGNMENT_EVENT_TYPE_UNASSIGNED
+	}
+
+	return &pb.AssignmentEvent{
+		Type:          eventType,
+		PullRequestId: event.PullRequestID,
+		ReviewerId:    event.ReviewerID,
+		Reason:        string(event.Reason),
+		ReplacedBy:    event.ReplacedBy,
+		OccurredAt:    timestamppb.New(event.OccurredAt),
+	}
+}
+
+func granularityFromProto(granularity pb.StatsGranularity) dto.StatsGranularity {
+	if granularity == pb.StatsGranularity_STATS_GRANULARITY_WEEK {
+		return dto.GranularityWeek
+	}
+
+	return dto.GranularityDay
+}
+
+func granularityToProto(granularity dto.StatsGranularity) pb.StatsGranularity {
+	if granularity == dto.GranularityWeek {
+		return pb.StatsGranularity_STATS_GRANULARITY_WEEK
+	}
+
+	return pb.StatsGranularity_STATS_GRANULARITY_DAY
+}
+
+func statToProto(stat *dto.AppStat) *pb.GetStatsResponse {
+	resp := &pb.GetStatsResponse{
+		TotalUsersCount:         toInt32(stat.TotalUsersCount),
+		ActiveUsersCount:        toInt32(stat.ActiveUsersCount),
+		TotalTeamsCount:         toInt32(stat.TotalTeamsCount),
+		OpenedPullRequestsCount: toInt32(stat.OpenedPRCount),
+		MergedPullRequestsCount: toInt32(stat.MergedPRCount),
+	}
+
+	for _, teamSize := range stat.UserCountByTeam {
+		resp.UsersCountByTeam = append(resp.UsersCountByTeam, &pb.TeamSize{
+			TeamName:  teamSize.TeamName,
+			UserCount: toInt32(teamSize.UserCount),
+		})
+	}
+	for _, assignments := range stat.AssignmentsCountByUser {
+		resp.AssignmentsCountByUser = append(resp.AssignmentsCountByUser, &pb.AssignmentsByUser{
+			UserId:           assignments.UserID,
+			AssignmentsCount: toInt32(assignments.AssignmentsCount),
+		})
+	}
+
+	if analytics := stat.ReviewAnalytics; analytics != nil {
+		resp.ReviewAnalytics = &pb.ReviewAnalytics{
+			From:               timestamppb.New(analytics.From),
+			To:                 timestamppb.New(analytics.To),
+			TeamName:           analytics.TeamName,
+			Granularity:        granularityToProto(analytics.Granularity),
+			ReassignmentsCount: toInt32(analytics.ReassignmentsCount),
+		}
+		if analytics.MergeTime != nil {
+			resp.ReviewAnalytics.MergeTime = &pb.MergeTimeStats{
+				MergedCount:   toInt32(analytics.MergeTime.MergedCount),
+				MedianSeconds: analytics.MergeTime.MedianSeconds,
+				P90Seconds:    analytics.MergeTime.P90Seconds,
+			}
+		}
+		if analytics.FirstVerdictTime != nil {
+			resp.ReviewAnalytics.FirstVerdictTime = &pb.FirstVerdictTimeStats{
+				PullRequestsCount: toInt32(analytics.FirstVerdictTime.PullRequestsCount),
+				MedianSeconds:     analytics.FirstVerdictTime.MedianSeconds,
+				P90Seconds:        analytics.FirstVerdictTime.P90Seconds,
+			}
+		}
+		for _, bucket := range analytics.Throughput {
+			resp.ReviewAnalytics.Throughput = append(resp.ReviewAnalytics.Throughput, &pb.ThroughputBucket{
+				PeriodStart: timestamppb.New(bucket.PeriodStart),
+				Opened:      toInt32(bucket.Opened),
+				Merged:      toInt32(bucket.Merged),
+			})
+		}
+	}
+
+	return resp
+}
+
+func fairnessToProto(report *dto.FairnessReport) *pb.GetFairnessResponse {
+	resp := &pb.GetFairnessResponse{}
+	for _, team := range report.Teams {
+		teamFairness := &pb.TeamFairness{
+			TeamName:           team.TeamName,
+			TotalAssignments:   toInt32(team.TotalAssignments),
+			GiniCoefficient:    team.GiniCoefficient,
+			OverloadedUserIds:  team.OverloadedUserIDs,
+			UnderloadedUserIds: team.UnderloadedUserIDs,
+		}
+		for _, member := range team.Members {
+			teamFairness.Members = append(teamFairness.Members, &pb.ReviewerLoad{
+				UserId:           member.UserID,
+				Username:         member.Username,
+				IsActive:         member.IsActive,
+				OpenAssignments:  toInt32(member.OpenAssignments),
+				TotalAssignments: toInt32(member.TotalAssignments),
+				Share:            member.Share,
+				ExpectedShare:    member.ExpectedShare,
+				LoadStatus:       string(member.LoadStatus),
+			})
+		}
+		resp.Teams = append(resp.Teams, teamFairness)
+	}
+
+	return resp
+}
+
+func timestampToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+// toInt32 приводит счетчик к int32, ограничивая его диапазоном типа
+func toInt32(n int) int32 {
+	switch {
+	case n > math.MaxInt32:
+		return math.MaxInt32
+	case n < math.MinInt32:
+		return math.MinInt32
+	default:
+		return int32(n)
+	}
+}
+
+func optionalInt32ToProto(n *int) *int32 {
+	if n == nil {
+		return nil
+	}
+
+	converted := toInt32(*n)
+	return &converted
+}
+
+func optionalIntFromProto(n *int32) *int {
+	if n == nil {
+		return nil
+	}
+
+	converted := int(*n)
+	return &converted
+}
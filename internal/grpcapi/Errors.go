@@ -0,0 +1,109 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/rest"
+	"github.com/salex06/pr-service/internal/service"
+)
+
+// errorDomain - домен ошибок в деталях статуса (ErrorInfo)
+const errorDomain = "pr-service"
+
+// conflictCodes переопределяет gRPC-код для отдельных
+// кодов конфликта (по умолчанию - FailedPrecondition)
+var conflictCodes = map[dto.ErrorCode]codes.Code{
+	dto.TeamExists:      codes.AlreadyExists,
+	dto.PrExists:        codes.AlreadyExists,
+	dto.VersionConflict: codes.Aborted,
+}
+
+// toStatus преобразует ошибку сервиса в статус gRPC; код ошибки
+// REST API (NOT_FOUND, PR_MERGED и т.д.) передается в деталях ErrorInfo
+func toStatus(ctx context.Context, err error) error {
+	var (
+		notFoundErr        *service.NotFoundError
+		conflictErr        *service.ConflictError
+		invalidArgumentErr *service.InvalidArgumentError
+		internalErr        *service.InternalError
+	)
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		return newStatus(codes.NotFound, dto.NotFound, notFoundErr.Message)
+	case errors.As(err, &conflictErr):
+		code, ok := conflictCodes[conflictErr.Code]
+		if !ok {
+			code = codes.FailedPrecondition
+		}
+		return newStatus(code, conflictErr.Code, conflictErr.Message)
+	case errors.As(err, &invalidArgumentErr):
+		return newStatus(codes.InvalidArgument, dto.BadRequest, invalidArgumentErr.Message)
+	case errors.Is(err, context.Canceled):
+		return newStatus(codes.Canceled, dto.Timeout, "request canceled")
+	case ctx.Err() != nil || errors.Is(err, context.DeadlineExceeded):
+		return newStatus(codes.DeadlineExceeded, dto.Timeout, "request deadline exceeded")
+	}
+
+	slog.ErrorContext(ctx, "rpc failed", "error", err)
+
+	// текст исходной ошибки (например, ошибки БД) клиенту не передается
+	message := "internal server error"
+	if errors.As(err, &internalErr) {
+		message = internalErr.Message
+	}
+
+	return newStatus(codes.Internal, dto.InternalError, message)
+}
+
+// validate проверяет запрос по правилам, заданным тегами binding
+// в dto (как и REST API), и возвращает статус InvalidArgument
+// с нарушениями по полям в деталях BadRequest
+func validate(req any) error {
+	err := rest.ValidateStruct(req)
+	if err == nil {
+		return nil
+	}
+
+	violations := rest.FieldViolations(err)
+	if violations == nil {
+		return newStatus(codes.InvalidArgument, dto.BadRequest, "invalid request")
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Reason,
+		})
+	}
+
+	st, detailsErr := status.New(codes.InvalidArgument, "request validation failed").WithDetails(
+		&errdetails.ErrorInfo{Reason: string(dto.ValidationError), Domain: errorDomain},
+		badRequest,
+	)
+	if detailsErr != nil {
+		return status.Error(codes.InvalidArgument, "request validation failed")
+	}
+
+	return st.Err()
+}
+
+func newStatus(code codes.Code, errorCode dto.ErrorCode, message string) error {
+	st, err := status.New(code, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: string(errorCode),
+		Domain: errorDomain,
+	})
+	if err != nil {
+		return status.Error(code, message)
+	}
+
+	return st.Err()
+}
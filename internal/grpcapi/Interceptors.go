@@ -0,0 +1,230 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/salex06/pr-service/internal/audit"
+	"github.com/salex06/pr-service/internal/auth"
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/logging"
+	"github.com/salex06/pr-service/internal/metrics"
+)
+
+// requestIDKey - ключ метаданных, в котором передается идентификатор запроса
+const requestIDKey = "x-request-id"
+
+// maxRequestIDLength - максимальная длина идентификатора запроса,
+// принимаемого от клиента (более длинный заменяется сгенерированным)
+const maxRequestIDLength = 128
+
+// interceptor выполняет общую для унарных и потоковых вызовов обработку:
+// идентификатор запроса и лог, метрики, аутентификацию и проверку прав
+type interceptor struct {
+	authenticator auth.Authenticator
+	access        map[string]access
+}
+
+// Unary возвращает перехватчик унарных вызовов; срок обработки
+// вызова ограничивается значением timeout (<= 0 - без ограничения)
+func (i *interceptor) Unary(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, requestID := requestContext(ctx, info.FullMethod)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, requestID))
+
+		var resp any
+		err := observe(ctx, info.FullMethod, func() error {
+			authCtx, err := i.authorize(ctx, info.FullMethod, req)
+			if err != nil {
+				return err
+			}
+
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				authCtx, cancel = context.WithTimeout(authCtx, timeout)
+				defer cancel()
+			}
+
+			resp, err = handler(authCtx, req)
+			return err
+		})
+
+		return resp, err
+	}
+}
+
+// Stream возвращает перехватчик потоковых вызовов
+func (i *interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, requestID := requestContext(ss.Context(), info.FullMethod)
+		_ = ss.SetHeader(metadata.Pairs(requestIDKey, requestID))
+
+		return observe(ctx, info.FullMethod, func() error {
+			authCtx, err := i.authorize(ctx, info.FullMethod, nil)
+			if err != nil {
+				return err
+			}
+
+			return handler(srv, &serverStream{ServerStream: ss, ctx: authCtx})
+		})
+	}
+}
+
+// observe выполняет вызов call и записывает его результат в лог и метрики
+func observe(ctx context.Context, method string, call func() error) error {
+	start := time.Now()
+	err := call()
+	code := status.Code(err)
+
+	elapsed := time.Since(start)
+	metrics.GRPCRequestsTotal.WithLabelValues(method, code.String()).Inc()
+	metrics.GRPCRequestDuration.WithLabelValues(method, code.String()).Observe(elapsed.Seconds())
+
+	level := slog.LevelInfo
+	if code == codes.Internal || code == codes.Unknown {
+		level = slog.LevelError
+	}
+	slog.Log(ctx, level, "rpc completed",
+		"code", code.String(),
+		"latency_ms", elapsed.Milliseconds(),
+	)
+
+	return err
+}
+
+// authorize аутентифицирует клиента по метаданным "authorization: Bearer <token>"
+// (или "x-api-key: <key>") и проверяет его права на вызов метода method
+// с сообщением запроса req (nil - для потоковых методов)
+func (i *interceptor) authorize(ctx context.Context, method string, req any) (context.Context, error) {
+	if isPublicMethod(method) {
+		return ctx, nil
+	}
+
+	principal := anonymous
+	if i.authenticator != nil {
+		token, ok := tokenFromMetadata(ctx)
+		if !ok {
+			return nil, newStatus(codes.Unauthenticated, dto.Unauthorized, "missing bearer token")
+		}
+
+		var err error
+		principal, err = i.authenticator.Authenticate(ctx, token)
+		if err != nil {
+			if errors.Is(err, auth.ErrInvalidCredentials) {
+				return nil, newStatus(codes.Unauthenticated, dto.Unauthorized, "invalid token")
+			}
+
+			slog.ErrorContext(ctx, "unable to authenticate rpc", "error", err)
+			return nil, newStatus(codes.Internal, dto.InternalError, "unable to authenticate request")
+		}
+	}
+
+	if err := i.checkAccess(ctx, principal, method, req); err != nil {
+		return nil, err
+	}
+
+	meta := audit.Meta{
+		Actor:     principal.Subject,
+		RequestID: logging.RequestIDFromContext(ctx),
+	}
+	if principal.IsAPIKey() {
+		meta.Actor = "apikey:" + principal.APIKeyID
+	}
+	ctx = audit.WithMeta(ctx, meta)
+	ctx = auth.WithPrincipal(ctx, principal)
+
+	return logging.With(ctx, "actor", meta.Actor), nil
+}
+
+// checkAccess проверяет, разрешен ли клиенту вызов метода method:
+// ключам доступа - при наличии разрешения метода, администраторам -
+// всегда, остальным - если выполняется правило доступа метода
+func (i *interceptor) checkAccess(ctx context.Context, principal *auth.Principal, method string, req any) error {
+	access, ok := i.access[method]
+	if !ok {
+		return newStatus(codes.PermissionDenied, dto.Forbidden, "access denied")
+	}
+
+	if principal.IsAPIKey() {
+		if !principal.HasScope(access.scope) {
+			return newStatus(codes.PermissionDenied, dto.Forbidden, fmt.Sprintf("api key has no %s scope", access.scope))
+		}
+		return nil
+	}
+
+	if !principal.HasRole(auth.RoleAdmin) && !access.rule(ctx, principal, req) {
+		return newStatus(codes.PermissionDenied, dto.Forbidden, "access denied")
+	}
+
+	return nil
+}
+
+// isPublicMethod проверяет, относится ли метод к служебным сервисам
+// (проверка состояния и рефлексия), доступным без аутентификации
+func isPublicMethod(method string) bool {
+	return strings.HasPrefix(method, "/grpc.health.v1.") || strings.HasPrefix(method, "/grpc.reflection.")
+}
+
+// anonymous - клиент, от имени которого выполняются
+// вызовы при отключенной аутентификации
+var anonymous = &auth.Principal{Subject: "anonymous", Role: auth.RoleAdmin}
+
+// requestContext принимает идентификатор запроса из метаданных
+// (или генерирует новый) и сохраняет его в контексте вызова
+// вместе с именем метода для записей лога
+func requestContext(ctx context.Context, method string) (context.Context, string) {
+	requestID := firstMetadataValue(ctx, requestIDKey)
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		requestID = newRequestID()
+	}
+
+	ctx = logging.WithRequestID(ctx, requestID)
+	return logging.With(ctx, "rpc_method", method), requestID
+}
+
+func tokenFromMetadata(ctx context.Context) (string, bool) {
+	scheme, token, ok := strings.Cut(firstMetadataValue(ctx, "authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		token = strings.TrimSpace(token)
+		return token, token != ""
+	}
+
+	token = firstMetadataValue(ctx, "x-api-key")
+	return token, token != ""
+}
+
+func firstMetadataValue(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// serverStream подменяет контекст потока контекстом,
+// дополненным перехватчиком (клиент, идентификатор запроса)
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
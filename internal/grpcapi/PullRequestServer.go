@@ -0,0 +1,175 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/salex06/pr-service/internal/dto"
+	pb "github.com/salex06/pr-service/internal/grpcapi/prservicev1"
+	"github.com/salex06/pr-service/internal/service"
+)
+
+// pullRequestServer реализует gRPC-сервис PullRequestService
+// поверх service.PullRequestService и брокера событий назначения
+type pullRequestServer struct {
+	pb.UnimplementedPullRequestServiceServer
+
+	pullRequestService *service.PullRequestService
+	events             *service.AssignmentEventBroker
+}
+
+func (s *pullRequestServer) CreatePullRequest(
+	ctx context.Context,
+	req *pb.CreatePullRequestRequest,
+) (*pb.CreatePullRequestResponse, error) {
+	createReq := &dto.CreatePullRequest{
+		PullRequestID:   req.GetPullRequestId(),
+		PullRequestName: req.GetPullRequestName(),
+		AuthorID:        req.GetAuthorId(),
+		Repository:      req.Repository,
+		SourceBranch:    req.SourceBranch,
+		TargetBranch:    req.TargetBranch,
+		URL:             req.Url,
+		Labels:          req.GetLabels(),
+		LinesChanged:    optionalIntFromProto(req.LinesChanged),
+	}
+	if err := validate(createReq); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.pullRequestService.CreatePullRequest(ctx, createReq)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &pb.CreatePullRequestResponse{Pr: pullRequestToProto(resp)}, nil
+}
+
+func (s *pullRequestServer) MergePullRequest(
+	ctx context.Context,
+	req *pb.MergePullRequestRequest,
+) (*pb.MergePullRequestResponse, error) {
+	mergeReq := &dto.MergePullRequest{PullRequestID: req.GetPullRequestId()}
+	if err := validate(mergeReq); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.pullRequestService.MergePullRequest(ctx, mergeReq)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &pb.MergePullRequestResponse{Pr: pullRequestToProto(resp)}, nil
+}
+
+func (s *pullRequestServer) ReassignReviewer(
+	ctx context.Context,
+	req *pb.ReassignReviewerRequest,
+) (*pb.ReassignReviewerResponse, error) {
+	reassignReq := &dto.ReassignPullRequest{
+		PullRequestID: req.GetPullRequestId(),
+		OldReviewerID: req.GetOldReviewerId(),
+	}
+	if err := validate(reassignReq); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.pullRequestService.ReassignPullRequest(ctx, reassignReq)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &pb.ReassignReviewerResponse{
+		Pr:         pullRequestToProto(&resp.Pr),
+		ReplacedBy: resp.ReplacedBy,
+	}, nil
+}
+
+func (s *pullRequestServer) SubmitVerdict(
+	ctx context.Context,
+	req *pb.SubmitVerdictRequest,
+) (*pb.SubmitVerdictResponse, error) {
+	verdictReq := &dto.SubmitVerdict{
+		PullRequestID: req.GetPullRequestId(),
+		UserID:        req.GetUserId(),
+		Verdict:       verdictFromProto(req.GetVerdict()),
+	}
+	if err := validate(verdictReq); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.pullRequestService.SubmitVerdict(ctx, verdictReq)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &pb.SubmitVerdictResponse{
+		PullRequestId: resp.PullRequestID,
+		UserId:        resp.UserID,
+		Verdict:       verdictToProto(resp.Verdict),
+		CreatedAt:     timestampToProto(resp.CreatedAt),
+	}, nil
+}
+
+func (s *pullRequestServer) ListPullRequests(
+	ctx context.Context,
+	req *pb.ListPullRequestsRequest,
+) (*pb.ListPullRequestsResponse, error) {
+	if req.GetLimit() < 0 || req.GetOffset() < 0 {
+		return nil, newStatus(codes.InvalidArgument, dto.BadRequest, "limit and offset must not be negative")
+	}
+
+	prs, err := s.pullRequestService.ListPullRequests(ctx, &dto.PullRequestFilter{
+		Status:     statusFromProto(req.GetStatus()),
+		AuthorID:   req.GetAuthorId(),
+		Repository: req.GetRepository(),
+		Label:      req.GetLabel(),
+		Limit:      int(req.GetLimit()),
+		Offset:     int(req.GetOffset()),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &pb.ListPullRequestsResponse{PullRequests: pullRequestsShortToProto(prs)}, nil
+}
+
+// WatchAssignments передает клиенту события назначения ревьюеров,
+// соответствующие фильтрам запроса, пока клиент не закроет поток
+// или сервер не начнет остановку
+func (s *pullRequestServer) WatchAssignments(
+	req *pb.WatchAssignmentsRequest,
+	stream grpc.ServerStreamingServer[pb.AssignmentEvent],
+) error {
+	events, unsubscribe := s.events.Subscribe()
+	defer unsubscribe()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if !matchesWatchFilter(req, event) {
+				continue
+			}
+
+			if err := stream.Send(assignmentEventToProto(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func matchesWatchFilter(req *pb.WatchAssignmentsRequest, event *dto.AssignmentEvent) bool {
+	if req.GetReviewerId() != "" && req.GetReviewerId() != event.ReviewerID {
+		return false
+	}
+
+	return req.GetPullRequestId() == "" || req.GetPullRequestId() == event.PullRequestID
+}
@@ -0,0 +1,56 @@
+// Package grpcapi - пакет с gRPC API сервиса, который работает рядом
+// с REST API и использует те же сервисы бизнес-логики
+package grpcapi
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/salex06/pr-service/internal/auth"
+	pb "github.com/salex06/pr-service/internal/grpcapi/prservicev1"
+	"github.com/salex06/pr-service/internal/service"
+)
+
+// Services - набор сервисов бизнес-логики, вызываемых gRPC API
+type Services struct {
+	Team        *service.TeamService
+	User        *service.UserService
+	PullRequest *service.PullRequestService
+	Stats       service.StatsProvider
+	Events      *service.AssignmentEventBroker
+}
+
+// NewServer конструирует gRPC-сервер с зарегистрированными сервисами API,
+// проверкой состояния (grpc.health.v1) и рефлексией. Клиенты аутентифицируются
+// authenticator (nil - аутентификация отключена, все вызовы выполняются
+// от имени администратора), права проверяются правилами policy,
+// срок унарного вызова ограничен requestTimeout
+func NewServer(
+	services *Services,
+	authenticator auth.Authenticator,
+	policy *auth.Policy,
+	requestTimeout time.Duration,
+) *grpc.Server {
+	i := &interceptor{authenticator: authenticator, access: newMethodAccess(policy)}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(i.Unary(requestTimeout)),
+		grpc.ChainStreamInterceptor(i.Stream()),
+	)
+
+	pb.RegisterTeamServiceServer(server, &teamServer{teamService: services.Team})
+	pb.RegisterUserServiceServer(server, &userServer{userService: services.User})
+	pb.RegisterPullRequestServiceServer(server, &pullRequestServer{
+		pullRequestService: services.PullRequest,
+		events:             services.Events,
+	})
+	pb.RegisterStatsServiceServer(server, &statsServer{statsService: services.Stats})
+
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	reflection.Register(server)
+
+	return server
+}
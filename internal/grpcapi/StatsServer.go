@@ -0,0 +1,56 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/salex06/pr-service/internal/dto"
+	pb "github.com/salex06/pr-service/internal/grpcapi/prservicev1"
+	"github.com/salex06/pr-service/internal/service"
+)
+
+// defaultStatsWindow - временное окно аналитики ревью по умолчанию
+const defaultStatsWindow = 30 * 24 * time.Hour
+
+// statsServer реализует gRPC-сервис StatsService поверх service.StatsProvider
+type statsServer struct {
+	pb.UnimplementedStatsServiceServer
+
+	statsService service.StatsProvider
+}
+
+func (s *statsServer) GetStats(ctx context.Context, req *pb.GetStatsRequest) (*pb.GetStatsResponse, error) {
+	filter := &dto.StatsFilter{
+		TeamName:    req.GetTeamName(),
+		Granularity: granularityFromProto(req.GetGranularity()),
+		To:          time.Now().UTC(),
+	}
+	if req.GetTo() != nil {
+		filter.To = req.GetTo().AsTime()
+	}
+	filter.From = filter.To.Add(-defaultStatsWindow)
+	if req.GetFrom() != nil {
+		filter.From = req.GetFrom().AsTime()
+	}
+	if !filter.From.Before(filter.To) {
+		return nil, newStatus(codes.InvalidArgument, dto.BadRequest, "from must be before to")
+	}
+
+	stat, err := s.statsService.GetStat(ctx, filter)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return statToProto(stat), nil
+}
+
+func (s *statsServer) GetFairness(ctx context.Context, req *pb.GetFairnessRequest) (*pb.GetFairnessResponse, error) {
+	report, err := s.statsService.GetFairnessReport(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return fairnessToProto(report), nil
+}
@@ -0,0 +1,68 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/salex06/pr-service/internal/auth"
+	"github.com/salex06/pr-service/internal/dto"
+	pb "github.com/salex06/pr-service/internal/grpcapi/prservicev1"
+	"github.com/salex06/pr-service/internal/service"
+)
+
+// teamServer реализует gRPC-сервис TeamService поверх service.TeamService
+type teamServer struct {
+	pb.UnimplementedTeamServiceServer
+
+	teamService *service.TeamService
+}
+
+func (s *teamServer) AddTeam(ctx context.Context, req *pb.AddTeamRequest) (*pb.AddTeamResponse, error) {
+	team := teamFromProto(req.GetTeam())
+	if err := validate(team); err != nil {
+		return nil, err
+	}
+	principal := auth.PrincipalFromContext(ctx)
+	team.MoveMembers = principal != nil && principal.HasRole(auth.RoleAdmin)
+
+	resp, err := s.teamService.AddTeam(ctx, team)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &pb.AddTeamResponse{Team: teamToProto(resp)}, nil
+}
+
+func (s *teamServer) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.GetTeamResponse, error) {
+	resp, err := s.teamService.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &pb.GetTeamResponse{Team: teamToProto(resp)}, nil
+}
+
+func (s *teamServer) DeactivateTeamMembers(
+	ctx context.Context,
+	req *pb.DeactivateTeamMembersRequest,
+) (*pb.DeactivateTeamMembersResponse, error) {
+	resp, err := s.teamService.DeactivateAllMembers(ctx, req.GetTeamName(), "")
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &pb.DeactivateTeamMembersResponse{Team: teamToProto(resp)}, nil
+}
+
+func (s *teamServer) MoveTeamMember(ctx context.Context, req *pb.MoveTeamMemberRequest) (*pb.MoveTeamMemberResponse, error) {
+	moveReq := &dto.MoveTeamMember{UserID: req.GetUserId(), TeamName: req.GetTeamName()}
+	if err := validate(moveReq); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.teamService.MoveMember(ctx, moveReq)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &pb.MoveTeamMemberResponse{User: userToProto(resp)}, nil
+}
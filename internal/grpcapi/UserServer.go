@@ -0,0 +1,42 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/salex06/pr-service/internal/dto"
+	pb "github.com/salex06/pr-service/internal/grpcapi/prservicev1"
+	"github.com/salex06/pr-service/internal/service"
+)
+
+// userServer реализует gRPC-сервис UserService поверх service.UserService
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+
+	userService *service.UserService
+}
+
+func (s *userServer) SetIsActive(ctx context.Context, req *pb.SetIsActiveRequest) (*pb.SetIsActiveResponse, error) {
+	userReq := &dto.UserShort{UserID: req.GetUserId(), IsActive: req.GetIsActive()}
+	if err := validate(userReq); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.userService.SetIsActive(ctx, userReq)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &pb.SetIsActiveResponse{User: userToProto(resp)}, nil
+}
+
+func (s *userServer) GetReview(ctx context.Context, req *pb.GetReviewRequest) (*pb.GetReviewResponse, error) {
+	resp, err := s.userService.GetAssignedPRs(ctx, req.GetUserId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return &pb.GetReviewResponse{
+		UserId:       resp.UserID,
+		PullRequests: pullRequestsShortToProto(resp.PullRequests),
+	}, nil
+}
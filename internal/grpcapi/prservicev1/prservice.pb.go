@@ -0,0 +1,3224 @@
+// gRPC API сервиса назначения ревьюеров: повторяет операции REST API
+// с командами, сотрудниками, PR's и статистикой и дополнительно
+// позволяет подписаться на события назначения ревьюеров
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: prservice/v1/prservice.proto
+
+package prservicev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PullRequestStatus int32
+
+const (
+	PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED PullRequestStatus = 0
+	PullRequestStatus_PULL_REQUEST_STATUS_OPEN        PullRequestStatus = 1
+	PullRequestStatus_PULL_REQUEST_STATUS_MERGED      PullRequestStatus = 2
+)
+
+// Enum value maps for PullRequestStatus.
+var (
+	PullRequestStatus_name = map[int32]string{
+		0: "PULL_REQUEST_STATUS_UNSPECIFIED",
+		1: "PULL_REQUEST_STATUS_OPEN",
+		2: "PULL_REQUEST_STATUS_MERGED",
+	}
+	PullRequestStatus_value = map[string]int32{
+		"PULL_REQUEST_STATUS_UNSPECIFIED": 0,
+		"PULL_REQUEST_STATUS_OPEN":        1,
+		"PULL_REQUEST_STATUS_MERGED":      2,
+	}
+)
+
+func (x PullRequestStatus) Enum() *PullRequestStatus {
+	p := new(PullRequestStatus)
+	*p = x
+	return p
+}
+
+func (x PullRequestStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PullRequestStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_prservice_v1_prservice_proto_enumTypes[0].Descriptor()
+}
+
+func (PullRequestStatus) Type() protoreflect.EnumType {
+	return &file_prservice_v1_prservice_proto_enumTypes[0]
+}
+
+func (x PullRequestStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PullRequestStatus.Descriptor instead.
+func (PullRequestStatus) EnumDescriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{0}
+}
+
+type ReviewVerdict int32
+
+const (
+	ReviewVerdict_REVIEW_VERDICT_UNSPECIFIED       ReviewVerdict = 0
+	ReviewVerdict_REVIEW_VERDICT_APPROVED          ReviewVerdict = 1
+	ReviewVerdict_REVIEW_VERDICT_CHANGES_REQUESTED ReviewVerdict = 2
+)
+
+// Enum value maps for ReviewVerdict.
+var (
+	ReviewVerdict_name = map[int32]string{
+		0: "REVIEW_VERDICT_UNSPECIFIED",
+		1: "REVIEW_VERDICT_APPROVED",
+		2: "REVIEW_VERDICT_CHANGES_REQUESTED",
+	}
+	ReviewVerdict_value = map[string]int32{
+		"REVIEW_VERDICT_UNSPECIFIED":       0,
+		"REVIEW_VERDICT_APPROVED":          1,
+		"REVIEW_VERDICT_CHANGES_REQUESTED": 2,
+	}
+)
+
+func (x ReviewVerdict) Enum() *ReviewVerdict {
+	p := new(ReviewVerdict)
+	*p = x
+	return p
+}
+
+func (x ReviewVerdict) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReviewVerdict) Descriptor() protoreflect.EnumDescriptor {
+	return file_prservice_v1_prservice_proto_enumTypes[1].Descriptor()
+}
+
+func (ReviewVerdict) Type() protoreflect.EnumType {
+	return &file_prservice_v1_prservice_proto_enumTypes[1]
+}
+
+func (x ReviewVerdict) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReviewVerdict.Descriptor instead.
+func (ReviewVerdict) EnumDescriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{1}
+}
+
+type AssignmentEventType int32
+
+const (
+	AssignmentEventType_ASSIGNMENT_EVENT_TYPE_UNSPECIFIED AssignmentEventType = 0
+	// Сотрудник назначен ревьюером PR
+	AssignmentEventType_ASSIGNMENT_EVENT_TYPE_ASSIGNED AssignmentEventType = 1
+	// Сотрудник снят с PR
+	AssignmentEventType_ASSIGNMENT_EVENT_TYPE_UNASSIGNED AssignmentEventType = 2
+)
+
+// Enum value maps for AssignmentEventType.
+var (
+	AssignmentEventType_name = map[int32]string{
+		0: "ASSIGNMENT_EVENT_TYPE_UNSPECIFIED",
+		1: "ASSIGNMENT_EVENT_TYPE_ASSIGNED",
+		2: "ASSIGNMENT_EVENT_TYPE_UNASSIGNED",
+	}
+	AssignmentEventType_value = map[string]int32{
+		"ASSIGNMENT_EVENT_TYPE_UNSPECIFIED": 0,
+		"ASSIGNMENT_EVENT_TYPE_ASSIGNED":    1,
+		"ASSIGNMENT_EVENT_TYPE_UNASSIGNED":  2,
+	}
+)
+
+func (x AssignmentEventType) Enum() *AssignmentEventType {
+	p := new(AssignmentEventType)
+	*p = x
+	return p
+}
+
+func (x AssignmentEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AssignmentEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_prservice_v1_prservice_proto_enumTypes[2].Descriptor()
+}
+
+func (AssignmentEventType) Type() protoreflect.EnumType {
+	return &file_prservice_v1_prservice_proto_enumTypes[2]
+}
+
+func (x AssignmentEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AssignmentEventType.Descriptor instead.
+func (AssignmentEventType) EnumDescriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{2}
+}
+
+type StatsGranularity int32
+
+const (
+	StatsGranularity_STATS_GRANULARITY_UNSPECIFIED StatsGranularity = 0
+	StatsGranularity_STATS_GRANULARITY_DAY         StatsGranularity = 1
+	StatsGranularity_STATS_GRANULARITY_WEEK        StatsGranularity = 2
+)
+
+// Enum value maps for StatsGranularity.
+var (
+	StatsGranularity_name = map[int32]string{
+		0: "STATS_GRANULARITY_UNSPECIFIED",
+		1: "STATS_GRANULARITY_DAY",
+		2: "STATS_GRANULARITY_WEEK",
+	}
+	StatsGranularity_value = map[string]int32{
+		"STATS_GRANULARITY_UNSPECIFIED": 0,
+		"STATS_GRANULARITY_DAY":         1,
+		"STATS_GRANULARITY_WEEK":        2,
+	}
+)
+
+func (x StatsGranularity) Enum() *StatsGranularity {
+	p := new(StatsGranularity)
+	*p = x
+	return p
+}
+
+func (x StatsGranularity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatsGranularity) Descriptor() protoreflect.EnumDescriptor {
+	return file_prservice_v1_prservice_proto_enumTypes[3].Descriptor()
+}
+
+func (StatsGranularity) Type() protoreflect.EnumType {
+	return &file_prservice_v1_prservice_proto_enumTypes[3]
+}
+
+func (x StatsGranularity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatsGranularity.Descriptor instead.
+func (StatsGranularity) EnumDescriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{3}
+}
+
+type TeamMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	IsActive      bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Email         *string                `protobuf:"bytes,4,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamMember) Reset() {
+	*x = TeamMember{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamMember) ProtoMessage() {}
+
+func (x *TeamMember) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamMember.ProtoReflect.Descriptor instead.
+func (*TeamMember) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TeamMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TeamMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *TeamMember) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *TeamMember) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+type Team struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members       []*TeamMember          `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Team) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *Team) GetMembers() []*TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	TeamName      string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	IsActive      bool                   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *User) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *User) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type PullRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId     string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName   string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId          string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status            PullRequestStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=prservice.v1.PullRequestStatus" json:"status,omitempty"`
+	AssignedReviewers []string               `protobuf:"bytes,5,rep,name=assigned_reviewers,json=assignedReviewers,proto3" json:"assigned_reviewers,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MergedAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	// Необязательные метаданные PR
+	Repository    *string  `protobuf:"bytes,8,opt,name=repository,proto3,oneof" json:"repository,omitempty"`
+	SourceBranch  *string  `protobuf:"bytes,9,opt,name=source_branch,json=sourceBranch,proto3,oneof" json:"source_branch,omitempty"`
+	TargetBranch  *string  `protobuf:"bytes,10,opt,name=target_branch,json=targetBranch,proto3,oneof" json:"target_branch,omitempty"`
+	Url           *string  `protobuf:"bytes,11,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	Labels        []string `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty"`
+	LinesChanged  *int32   `protobuf:"varint,13,opt,name=lines_changed,json=linesChanged,proto3,oneof" json:"lines_changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullRequest) Reset() {
+	*x = PullRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequest) ProtoMessage() {}
+
+func (x *PullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequest.ProtoReflect.Descriptor instead.
+func (*PullRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PullRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *PullRequest) GetAssignedReviewers() []string {
+	if x != nil {
+		return x.AssignedReviewers
+	}
+	return nil
+}
+
+func (x *PullRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetMergedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetRepository() string {
+	if x != nil && x.Repository != nil {
+		return *x.Repository
+	}
+	return ""
+}
+
+func (x *PullRequest) GetSourceBranch() string {
+	if x != nil && x.SourceBranch != nil {
+		return *x.SourceBranch
+	}
+	return ""
+}
+
+func (x *PullRequest) GetTargetBranch() string {
+	if x != nil && x.TargetBranch != nil {
+		return *x.TargetBranch
+	}
+	return ""
+}
+
+func (x *PullRequest) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+func (x *PullRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *PullRequest) GetLinesChanged() int32 {
+	if x != nil && x.LinesChanged != nil {
+		return *x.LinesChanged
+	}
+	return 0
+}
+
+type PullRequestShort struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status          PullRequestStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=prservice.v1.PullRequestStatus" json:"status,omitempty"`
+	// Необязательные метаданные PR
+	Repository    *string  `protobuf:"bytes,5,opt,name=repository,proto3,oneof" json:"repository,omitempty"`
+	SourceBranch  *string  `protobuf:"bytes,6,opt,name=source_branch,json=sourceBranch,proto3,oneof" json:"source_branch,omitempty"`
+	TargetBranch  *string  `protobuf:"bytes,7,opt,name=target_branch,json=targetBranch,proto3,oneof" json:"target_branch,omitempty"`
+	Url           *string  `protobuf:"bytes,8,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	Labels        []string `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty"`
+	LinesChanged  *int32   `protobuf:"varint,10,opt,name=lines_changed,json=linesChanged,proto3,oneof" json:"lines_changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullRequestShort) Reset() {
+	*x = PullRequestShort{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequestShort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequestShort) ProtoMessage() {}
+
+func (x *PullRequestShort) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequestShort.ProtoReflect.Descriptor instead.
+func (*PullRequestShort) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PullRequestShort) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *PullRequestShort) GetRepository() string {
+	if x != nil && x.Repository != nil {
+		return *x.Repository
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetSourceBranch() string {
+	if x != nil && x.SourceBranch != nil {
+		return *x.SourceBranch
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetTargetBranch() string {
+	if x != nil && x.TargetBranch != nil {
+		return *x.TargetBranch
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *PullRequestShort) GetLinesChanged() int32 {
+	if x != nil && x.LinesChanged != nil {
+		return *x.LinesChanged
+	}
+	return 0
+}
+
+type AddTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamRequest) Reset() {
+	*x = AddTeamRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamRequest) ProtoMessage() {}
+
+func (x *AddTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamRequest.ProtoReflect.Descriptor instead.
+func (*AddTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AddTeamRequest) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type AddTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamResponse) Reset() {
+	*x = AddTeamResponse{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamResponse) ProtoMessage() {}
+
+func (x *AddTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamResponse.ProtoReflect.Descriptor instead.
+func (*AddTeamResponse) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AddTeamResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type GetTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamRequest) Reset() {
+	*x = GetTeamRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamRequest) ProtoMessage() {}
+
+func (x *GetTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type GetTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamResponse) Reset() {
+	*x = GetTeamResponse{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamResponse) ProtoMessage() {}
+
+func (x *GetTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamResponse.ProtoReflect.Descriptor instead.
+func (*GetTeamResponse) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetTeamResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type DeactivateTeamMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateTeamMembersRequest) Reset() {
+	*x = DeactivateTeamMembersRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateTeamMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateTeamMembersRequest) ProtoMessage() {}
+
+func (x *DeactivateTeamMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateTeamMembersRequest.ProtoReflect.Descriptor instead.
+func (*DeactivateTeamMembersRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeactivateTeamMembersRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type DeactivateTeamMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateTeamMembersResponse) Reset() {
+	*x = DeactivateTeamMembersResponse{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateTeamMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateTeamMembersResponse) ProtoMessage() {}
+
+func (x *DeactivateTeamMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateTeamMembersResponse.ProtoReflect.Descriptor instead.
+func (*DeactivateTeamMembersResponse) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeactivateTeamMembersResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type MoveTeamMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TeamName      string                 `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveTeamMemberRequest) Reset() {
+	*x = MoveTeamMemberRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveTeamMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveTeamMemberRequest) ProtoMessage() {}
+
+func (x *MoveTeamMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveTeamMemberRequest.ProtoReflect.Descriptor instead.
+func (*MoveTeamMemberRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MoveTeamMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MoveTeamMemberRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type MoveTeamMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveTeamMemberResponse) Reset() {
+	*x = MoveTeamMemberResponse{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveTeamMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveTeamMemberResponse) ProtoMessage() {}
+
+func (x *MoveTeamMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveTeamMemberResponse.ProtoReflect.Descriptor instead.
+func (*MoveTeamMemberResponse) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MoveTeamMemberResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type SetIsActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsActive      bool                   `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIsActiveRequest) Reset() {
+	*x = SetIsActiveRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIsActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIsActiveRequest) ProtoMessage() {}
+
+func (x *SetIsActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIsActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetIsActiveRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SetIsActiveRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetIsActiveRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type SetIsActiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIsActiveResponse) Reset() {
+	*x = SetIsActiveResponse{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIsActiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIsActiveResponse) ProtoMessage() {}
+
+func (x *SetIsActiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIsActiveResponse.ProtoReflect.Descriptor instead.
+func (*SetIsActiveResponse) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetIsActiveResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewRequest) Reset() {
+	*x = GetReviewRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewRequest) ProtoMessage() {}
+
+func (x *GetReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetReviewRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PullRequests  []*PullRequestShort    `protobuf:"bytes,2,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewResponse) Reset() {
+	*x = GetReviewResponse{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewResponse) ProtoMessage() {}
+
+func (x *GetReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewResponse.ProtoReflect.Descriptor instead.
+func (*GetReviewResponse) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetReviewResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetReviewResponse) GetPullRequests() []*PullRequestShort {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+type CreatePullRequestRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// Необязательные метаданные PR
+	Repository    *string  `protobuf:"bytes,4,opt,name=repository,proto3,oneof" json:"repository,omitempty"`
+	SourceBranch  *string  `protobuf:"bytes,5,opt,name=source_branch,json=sourceBranch,proto3,oneof" json:"source_branch,omitempty"`
+	TargetBranch  *string  `protobuf:"bytes,6,opt,name=target_branch,json=targetBranch,proto3,oneof" json:"target_branch,omitempty"`
+	Url           *string  `protobuf:"bytes,7,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	Labels        []string `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty"`
+	LinesChanged  *int32   `protobuf:"varint,9,opt,name=lines_changed,json=linesChanged,proto3,oneof" json:"lines_changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestRequest) Reset() {
+	*x = CreatePullRequestRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestRequest) ProtoMessage() {}
+
+func (x *CreatePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetRepository() string {
+	if x != nil && x.Repository != nil {
+		return *x.Repository
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetSourceBranch() string {
+	if x != nil && x.SourceBranch != nil {
+		return *x.SourceBranch
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetTargetBranch() string {
+	if x != nil && x.TargetBranch != nil {
+		return *x.TargetBranch
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreatePullRequestRequest) GetLinesChanged() int32 {
+	if x != nil && x.LinesChanged != nil {
+		return *x.LinesChanged
+	}
+	return 0
+}
+
+type CreatePullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestResponse) Reset() {
+	*x = CreatePullRequestResponse{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestResponse) ProtoMessage() {}
+
+func (x *CreatePullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestResponse.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreatePullRequestResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+type MergePullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergePullRequestRequest) Reset() {
+	*x = MergePullRequestRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestRequest) ProtoMessage() {}
+
+func (x *MergePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*MergePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MergePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type MergePullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergePullRequestResponse) Reset() {
+	*x = MergePullRequestResponse{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestResponse) ProtoMessage() {}
+
+func (x *MergePullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestResponse.ProtoReflect.Descriptor instead.
+func (*MergePullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MergePullRequestResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+type ReassignReviewerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	OldReviewerId string                 `protobuf:"bytes,2,opt,name=old_reviewer_id,json=oldReviewerId,proto3" json:"old_reviewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerRequest) Reset() {
+	*x = ReassignReviewerRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerRequest) ProtoMessage() {}
+
+func (x *ReassignReviewerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerRequest.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReassignReviewerRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetOldReviewerId() string {
+	if x != nil {
+		return x.OldReviewerId
+	}
+	return ""
+}
+
+type ReassignReviewerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	ReplacedBy    string                 `protobuf:"bytes,2,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerResponse) Reset() {
+	*x = ReassignReviewerResponse{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerResponse) ProtoMessage() {}
+
+func (x *ReassignReviewerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerResponse.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerResponse) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReassignReviewerResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+func (x *ReassignReviewerResponse) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+type SubmitVerdictRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Verdict       ReviewVerdict          `protobuf:"varint,3,opt,name=verdict,proto3,enum=prservice.v1.ReviewVerdict" json:"verdict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitVerdictRequest) Reset() {
+	*x = SubmitVerdictRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitVerdictRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitVerdictRequest) ProtoMessage() {}
+
+func (x *SubmitVerdictRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitVerdictRequest.ProtoReflect.Descriptor instead.
+func (*SubmitVerdictRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SubmitVerdictRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *SubmitVerdictRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SubmitVerdictRequest) GetVerdict() ReviewVerdict {
+	if x != nil {
+		return x.Verdict
+	}
+	return ReviewVerdict_REVIEW_VERDICT_UNSPECIFIED
+}
+
+type SubmitVerdictResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Verdict       ReviewVerdict          `protobuf:"varint,3,opt,name=verdict,proto3,enum=prservice.v1.ReviewVerdict" json:"verdict,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitVerdictResponse) Reset() {
+	*x = SubmitVerdictResponse{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitVerdictResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitVerdictResponse) ProtoMessage() {}
+
+func (x *SubmitVerdictResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitVerdictResponse.ProtoReflect.Descriptor instead.
+func (*SubmitVerdictResponse) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SubmitVerdictResponse) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *SubmitVerdictResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SubmitVerdictResponse) GetVerdict() ReviewVerdict {
+	if x != nil {
+		return x.Verdict
+	}
+	return ReviewVerdict_REVIEW_VERDICT_UNSPECIFIED
+}
+
+func (x *SubmitVerdictResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListPullRequestsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пустое значение (UNSPECIFIED) - без фильтра по статусу
+	Status   PullRequestStatus `protobuf:"varint,1,opt,name=status,proto3,enum=prservice.v1.PullRequestStatus" json:"status,omitempty"`
+	AuthorId string            `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// 0 - значение по умолчанию (100), не более 1000
+	Limit  int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Пустое значение - без фильтра по репозиторию
+	Repository string `protobuf:"bytes,5,opt,name=repository,proto3" json:"repository,omitempty"`
+	// Пустое значение - без фильтра по метке
+	Label         string `protobuf:"bytes,6,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsRequest) Reset() {
+	*x = ListPullRequestsRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsRequest) ProtoMessage() {}
+
+func (x *ListPullRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListPullRequestsRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *ListPullRequestsRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPullRequestsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListPullRequestsRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+type ListPullRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequests  []*PullRequestShort    `protobuf:"bytes,1,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsResponse) Reset() {
+	*x = ListPullRequestsResponse{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsResponse) ProtoMessage() {}
+
+func (x *ListPullRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListPullRequestsResponse) GetPullRequests() []*PullRequestShort {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+// Фильтры подписки на события (пустое значение - без фильтра)
+type WatchAssignmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReviewerId    string                 `protobuf:"bytes,1,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	PullRequestId string                 `protobuf:"bytes,2,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAssignmentsRequest) Reset() {
+	*x = WatchAssignmentsRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAssignmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAssignmentsRequest) ProtoMessage() {}
+
+func (x *WatchAssignmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAssignmentsRequest.ProtoReflect.Descriptor instead.
+func (*WatchAssignmentsRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *WatchAssignmentsRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *WatchAssignmentsRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type AssignmentEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          AssignmentEventType    `protobuf:"varint,1,opt,name=type,proto3,enum=prservice.v1.AssignmentEventType" json:"type,omitempty"`
+	PullRequestId string                 `protobuf:"bytes,2,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	ReviewerId    string                 `protobuf:"bytes,3,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	// Причина назначения/снятия: initial, reassign, deactivation, manual
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Заменивший ревьюер (только для UNASSIGNED при переназначении)
+	ReplacedBy    *string                `protobuf:"bytes,5,opt,name=replaced_by,json=replacedBy,proto3,oneof" json:"replaced_by,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentEvent) Reset() {
+	*x = AssignmentEvent{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentEvent) ProtoMessage() {}
+
+func (x *AssignmentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentEvent.ProtoReflect.Descriptor instead.
+func (*AssignmentEvent) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AssignmentEvent) GetType() AssignmentEventType {
+	if x != nil {
+		return x.Type
+	}
+	return AssignmentEventType_ASSIGNMENT_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *AssignmentEvent) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *AssignmentEvent) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *AssignmentEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AssignmentEvent) GetReplacedBy() string {
+	if x != nil && x.ReplacedBy != nil {
+		return *x.ReplacedBy
+	}
+	return ""
+}
+
+func (x *AssignmentEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type GetStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// По умолчанию - последние 30 дней
+	From     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	TeamName string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	// По умолчанию - DAY
+	Granularity   StatsGranularity `protobuf:"varint,4,opt,name=granularity,proto3,enum=prservice.v1.StatsGranularity" json:"granularity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetStatsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetStatsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetStatsRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *GetStatsRequest) GetGranularity() StatsGranularity {
+	if x != nil {
+		return x.Granularity
+	}
+	return StatsGranularity_STATS_GRANULARITY_UNSPECIFIED
+}
+
+type TeamSize struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	UserCount     int32                  `protobuf:"varint,2,opt,name=user_count,json=userCount,proto3" json:"user_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamSize) Reset() {
+	*x = TeamSize{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamSize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamSize) ProtoMessage() {}
+
+func (x *TeamSize) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamSize.ProtoReflect.Descriptor instead.
+func (*TeamSize) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TeamSize) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamSize) GetUserCount() int32 {
+	if x != nil {
+		return x.UserCount
+	}
+	return 0
+}
+
+type AssignmentsByUser struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AssignmentsCount int32                  `protobuf:"varint,2,opt,name=assignments_count,json=assignmentsCount,proto3" json:"assignments_count,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AssignmentsByUser) Reset() {
+	*x = AssignmentsByUser{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentsByUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentsByUser) ProtoMessage() {}
+
+func (x *AssignmentsByUser) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentsByUser.ProtoReflect.Descriptor instead.
+func (*AssignmentsByUser) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AssignmentsByUser) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AssignmentsByUser) GetAssignmentsCount() int32 {
+	if x != nil {
+		return x.AssignmentsCount
+	}
+	return 0
+}
+
+type MergeTimeStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MergedCount   int32                  `protobuf:"varint,1,opt,name=merged_count,json=mergedCount,proto3" json:"merged_count,omitempty"`
+	MedianSeconds *float64               `protobuf:"fixed64,2,opt,name=median_seconds,json=medianSeconds,proto3,oneof" json:"median_seconds,omitempty"`
+	P90Seconds    *float64               `protobuf:"fixed64,3,opt,name=p90_seconds,json=p90Seconds,proto3,oneof" json:"p90_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeTimeStats) Reset() {
+	*x = MergeTimeStats{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTimeStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTimeStats) ProtoMessage() {}
+
+func (x *MergeTimeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTimeStats.ProtoReflect.Descriptor instead.
+func (*MergeTimeStats) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MergeTimeStats) GetMergedCount() int32 {
+	if x != nil {
+		return x.MergedCount
+	}
+	return 0
+}
+
+func (x *MergeTimeStats) GetMedianSeconds() float64 {
+	if x != nil && x.MedianSeconds != nil {
+		return *x.MedianSeconds
+	}
+	return 0
+}
+
+func (x *MergeTimeStats) GetP90Seconds() float64 {
+	if x != nil && x.P90Seconds != nil {
+		return *x.P90Seconds
+	}
+	return 0
+}
+
+type FirstVerdictTimeStats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestsCount int32                  `protobuf:"varint,1,opt,name=pull_requests_count,json=pullRequestsCount,proto3" json:"pull_requests_count,omitempty"`
+	MedianSeconds     *float64               `protobuf:"fixed64,2,opt,name=median_seconds,json=medianSeconds,proto3,oneof" json:"median_seconds,omitempty"`
+	P90Seconds        *float64               `protobuf:"fixed64,3,opt,name=p90_seconds,json=p90Seconds,proto3,oneof" json:"p90_seconds,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *FirstVerdictTimeStats) Reset() {
+	*x = FirstVerdictTimeStats{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FirstVerdictTimeStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirstVerdictTimeStats) ProtoMessage() {}
+
+func (x *FirstVerdictTimeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirstVerdictTimeStats.ProtoReflect.Descriptor instead.
+func (*FirstVerdictTimeStats) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *FirstVerdictTimeStats) GetPullRequestsCount() int32 {
+	if x != nil {
+		return x.PullRequestsCount
+	}
+	return 0
+}
+
+func (x *FirstVerdictTimeStats) GetMedianSeconds() float64 {
+	if x != nil && x.MedianSeconds != nil {
+		return *x.MedianSeconds
+	}
+	return 0
+}
+
+func (x *FirstVerdictTimeStats) GetP90Seconds() float64 {
+	if x != nil && x.P90Seconds != nil {
+		return *x.P90Seconds
+	}
+	return 0
+}
+
+type ThroughputBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeriodStart   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	Opened        int32                  `protobuf:"varint,2,opt,name=opened,proto3" json:"opened,omitempty"`
+	Merged        int32                  `protobuf:"varint,3,opt,name=merged,proto3" json:"merged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThroughputBucket) Reset() {
+	*x = ThroughputBucket{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThroughputBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThroughputBucket) ProtoMessage() {}
+
+func (x *ThroughputBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThroughputBucket.ProtoReflect.Descriptor instead.
+func (*ThroughputBucket) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ThroughputBucket) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *ThroughputBucket) GetOpened() int32 {
+	if x != nil {
+		return x.Opened
+	}
+	return 0
+}
+
+func (x *ThroughputBucket) GetMerged() int32 {
+	if x != nil {
+		return x.Merged
+	}
+	return 0
+}
+
+type ReviewAnalytics struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	From               *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                 *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	TeamName           string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Granularity        StatsGranularity       `protobuf:"varint,4,opt,name=granularity,proto3,enum=prservice.v1.StatsGranularity" json:"granularity,omitempty"`
+	MergeTime          *MergeTimeStats        `protobuf:"bytes,5,opt,name=merge_time,json=mergeTime,proto3" json:"merge_time,omitempty"`
+	Throughput         []*ThroughputBucket    `protobuf:"bytes,6,rep,name=throughput,proto3" json:"throughput,omitempty"`
+	ReassignmentsCount int32                  `protobuf:"varint,7,opt,name=reassignments_count,json=reassignmentsCount,proto3" json:"reassignments_count,omitempty"`
+	FirstVerdictTime   *FirstVerdictTimeStats `protobuf:"bytes,8,opt,name=first_verdict_time,json=firstVerdictTime,proto3" json:"first_verdict_time,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ReviewAnalytics) Reset() {
+	*x = ReviewAnalytics{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewAnalytics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewAnalytics) ProtoMessage() {}
+
+func (x *ReviewAnalytics) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewAnalytics.ProtoReflect.Descriptor instead.
+func (*ReviewAnalytics) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ReviewAnalytics) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ReviewAnalytics) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ReviewAnalytics) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *ReviewAnalytics) GetGranularity() StatsGranularity {
+	if x != nil {
+		return x.Granularity
+	}
+	return StatsGranularity_STATS_GRANULARITY_UNSPECIFIED
+}
+
+func (x *ReviewAnalytics) GetMergeTime() *MergeTimeStats {
+	if x != nil {
+		return x.MergeTime
+	}
+	return nil
+}
+
+func (x *ReviewAnalytics) GetThroughput() []*ThroughputBucket {
+	if x != nil {
+		return x.Throughput
+	}
+	return nil
+}
+
+func (x *ReviewAnalytics) GetReassignmentsCount() int32 {
+	if x != nil {
+		return x.ReassignmentsCount
+	}
+	return 0
+}
+
+func (x *ReviewAnalytics) GetFirstVerdictTime() *FirstVerdictTimeStats {
+	if x != nil {
+		return x.FirstVerdictTime
+	}
+	return nil
+}
+
+type GetStatsResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	TotalUsersCount         int32                  `protobuf:"varint,1,opt,name=total_users_count,json=totalUsersCount,proto3" json:"total_users_count,omitempty"`
+	ActiveUsersCount        int32                  `protobuf:"varint,2,opt,name=active_users_count,json=activeUsersCount,proto3" json:"active_users_count,omitempty"`
+	TotalTeamsCount         int32                  `protobuf:"varint,3,opt,name=total_teams_count,json=totalTeamsCount,proto3" json:"total_teams_count,omitempty"`
+	OpenedPullRequestsCount int32                  `protobuf:"varint,4,opt,name=opened_pull_requests_count,json=openedPullRequestsCount,proto3" json:"opened_pull_requests_count,omitempty"`
+	MergedPullRequestsCount int32                  `protobuf:"varint,5,opt,name=merged_pull_requests_count,json=mergedPullRequestsCount,proto3" json:"merged_pull_requests_count,omitempty"`
+	UsersCountByTeam        []*TeamSize            `protobuf:"bytes,6,rep,name=users_count_by_team,json=usersCountByTeam,proto3" json:"users_count_by_team,omitempty"`
+	AssignmentsCountByUser  []*AssignmentsByUser   `protobuf:"bytes,7,rep,name=assignments_count_by_user,json=assignmentsCountByUser,proto3" json:"assignments_count_by_user,omitempty"`
+	ReviewAnalytics         *ReviewAnalytics       `protobuf:"bytes,8,opt,name=review_analytics,json=reviewAnalytics,proto3" json:"review_analytics,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetStatsResponse) GetTotalUsersCount() int32 {
+	if x != nil {
+		return x.TotalUsersCount
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetActiveUsersCount() int32 {
+	if x != nil {
+		return x.ActiveUsersCount
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetTotalTeamsCount() int32 {
+	if x != nil {
+		return x.TotalTeamsCount
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetOpenedPullRequestsCount() int32 {
+	if x != nil {
+		return x.OpenedPullRequestsCount
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetMergedPullRequestsCount() int32 {
+	if x != nil {
+		return x.MergedPullRequestsCount
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetUsersCountByTeam() []*TeamSize {
+	if x != nil {
+		return x.UsersCountByTeam
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetAssignmentsCountByUser() []*AssignmentsByUser {
+	if x != nil {
+		return x.AssignmentsCountByUser
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetReviewAnalytics() *ReviewAnalytics {
+	if x != nil {
+		return x.ReviewAnalytics
+	}
+	return nil
+}
+
+type GetFairnessRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пустое значение - отчет по всем командам
+	TeamName      string `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFairnessRequest) Reset() {
+	*x = GetFairnessRequest{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFairnessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFairnessRequest) ProtoMessage() {}
+
+func (x *GetFairnessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFairnessRequest.ProtoReflect.Descriptor instead.
+func (*GetFairnessRequest) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetFairnessRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type ReviewerLoad struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username         string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	IsActive         bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	OpenAssignments  int32                  `protobuf:"varint,4,opt,name=open_assignments,json=openAssignments,proto3" json:"open_assignments,omitempty"`
+	TotalAssignments int32                  `protobuf:"varint,5,opt,name=total_assignments,json=totalAssignments,proto3" json:"total_assignments,omitempty"`
+	Share            float64                `protobuf:"fixed64,6,opt,name=share,proto3" json:"share,omitempty"`
+	ExpectedShare    float64                `protobuf:"fixed64,7,opt,name=expected_share,json=expectedShare,proto3" json:"expected_share,omitempty"`
+	// BALANCED, OVERLOADED, UNDERLOADED, INACTIVE
+	LoadStatus    string `protobuf:"bytes,8,opt,name=load_status,json=loadStatus,proto3" json:"load_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewerLoad) Reset() {
+	*x = ReviewerLoad{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewerLoad) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewerLoad) ProtoMessage() {}
+
+func (x *ReviewerLoad) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewerLoad.ProtoReflect.Descriptor instead.
+func (*ReviewerLoad) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ReviewerLoad) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReviewerLoad) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ReviewerLoad) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *ReviewerLoad) GetOpenAssignments() int32 {
+	if x != nil {
+		return x.OpenAssignments
+	}
+	return 0
+}
+
+func (x *ReviewerLoad) GetTotalAssignments() int32 {
+	if x != nil {
+		return x.TotalAssignments
+	}
+	return 0
+}
+
+func (x *ReviewerLoad) GetShare() float64 {
+	if x != nil {
+		return x.Share
+	}
+	return 0
+}
+
+func (x *ReviewerLoad) GetExpectedShare() float64 {
+	if x != nil {
+		return x.ExpectedShare
+	}
+	return 0
+}
+
+func (x *ReviewerLoad) GetLoadStatus() string {
+	if x != nil {
+		return x.LoadStatus
+	}
+	return ""
+}
+
+type TeamFairness struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TeamName           string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	TotalAssignments   int32                  `protobuf:"varint,2,opt,name=total_assignments,json=totalAssignments,proto3" json:"total_assignments,omitempty"`
+	GiniCoefficient    float64                `protobuf:"fixed64,3,opt,name=gini_coefficient,json=giniCoefficient,proto3" json:"gini_coefficient,omitempty"`
+	Members            []*ReviewerLoad        `protobuf:"bytes,4,rep,name=members,proto3" json:"members,omitempty"`
+	OverloadedUserIds  []string               `protobuf:"bytes,5,rep,name=overloaded_user_ids,json=overloadedUserIds,proto3" json:"overloaded_user_ids,omitempty"`
+	UnderloadedUserIds []string               `protobuf:"bytes,6,rep,name=underloaded_user_ids,json=underloadedUserIds,proto3" json:"underloaded_user_ids,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *TeamFairness) Reset() {
+	*x = TeamFairness{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamFairness) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamFairness) ProtoMessage() {}
+
+func (x *TeamFairness) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamFairness.ProtoReflect.Descriptor instead.
+func (*TeamFairness) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *TeamFairness) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamFairness) GetTotalAssignments() int32 {
+	if x != nil {
+		return x.TotalAssignments
+	}
+	return 0
+}
+
+func (x *TeamFairness) GetGiniCoefficient() float64 {
+	if x != nil {
+		return x.GiniCoefficient
+	}
+	return 0
+}
+
+func (x *TeamFairness) GetMembers() []*ReviewerLoad {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *TeamFairness) GetOverloadedUserIds() []string {
+	if x != nil {
+		return x.OverloadedUserIds
+	}
+	return nil
+}
+
+func (x *TeamFairness) GetUnderloadedUserIds() []string {
+	if x != nil {
+		return x.UnderloadedUserIds
+	}
+	return nil
+}
+
+type GetFairnessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Teams         []*TeamFairness        `protobuf:"bytes,1,rep,name=teams,proto3" json:"teams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFairnessResponse) Reset() {
+	*x = GetFairnessResponse{}
+	mi := &file_prservice_v1_prservice_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFairnessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFairnessResponse) ProtoMessage() {}
+
+func (x *GetFairnessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prservice_v1_prservice_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFairnessResponse.ProtoReflect.Descriptor instead.
+func (*GetFairnessResponse) Descriptor() ([]byte, []int) {
+	return file_prservice_v1_prservice_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetFairnessResponse) GetTeams() []*TeamFairness {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+var File_prservice_v1_prservice_proto protoreflect.FileDescriptor
+
+const file_prservice_v1_prservice_proto_rawDesc = "" +
+	"\n" +
+	"\x1cprservice/v1/prservice.proto\x12\fprservice.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x83\x01\n" +
+	"\n" +
+	"TeamMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\x12\x19\n" +
+	"\x05email\x18\x04 \x01(\tH\x00R\x05email\x88\x01\x01B\b\n" +
+	"\x06_email\"W\n" +
+	"\x04Team\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x122\n" +
+	"\amembers\x18\x02 \x03(\v2\x18.prservice.v1.TeamMemberR\amembers\"u\n" +
+	"\x04User\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x12\x1b\n" +
+	"\tis_active\x18\x04 \x01(\bR\bisActive\"\xf9\x04\n" +
+	"\vPullRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x127\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1f.prservice.v1.PullRequestStatusR\x06status\x12-\n" +
+	"\x12assigned_reviewers\x18\x05 \x03(\tR\x11assignedReviewers\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tmerged_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bmergedAt\x12#\n" +
+	"\n" +
+	"repository\x18\b \x01(\tH\x00R\n" +
+	"repository\x88\x01\x01\x12(\n" +
+	"\rsource_branch\x18\t \x01(\tH\x01R\fsourceBranch\x88\x01\x01\x12(\n" +
+	"\rtarget_branch\x18\n" +
+	" \x01(\tH\x02R\ftargetBranch\x88\x01\x01\x12\x15\n" +
+	"\x03url\x18\v \x01(\tH\x03R\x03url\x88\x01\x01\x12\x16\n" +
+	"\x06labels\x18\f \x03(\tR\x06labels\x12(\n" +
+	"\rlines_changed\x18\r \x01(\x05H\x04R\flinesChanged\x88\x01\x01B\r\n" +
+	"\v_repositoryB\x10\n" +
+	"\x0e_source_branchB\x10\n" +
+	"\x0e_target_branchB\x06\n" +
+	"\x04_urlB\x10\n" +
+	"\x0e_lines_changed\"\xdb\x03\n" +
+	"\x10PullRequestShort\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x127\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1f.prservice.v1.PullRequestStatusR\x06status\x12#\n" +
+	"\n" +
+	"repository\x18\x05 \x01(\tH\x00R\n" +
+	"repository\x88\x01\x01\x12(\n" +
+	"\rsource_branch\x18\x06 \x01(\tH\x01R\fsourceBranch\x88\x01\x01\x12(\n" +
+	"\rtarget_branch\x18\a \x01(\tH\x02R\ftargetBranch\x88\x01\x01\x12\x15\n" +
+	"\x03url\x18\b \x01(\tH\x03R\x03url\x88\x01\x01\x12\x16\n" +
+	"\x06labels\x18\t \x03(\tR\x06labels\x12(\n" +
+	"\rlines_changed\x18\n" +
+	" \x01(\x05H\x04R\flinesChanged\x88\x01\x01B\r\n" +
+	"\v_repositoryB\x10\n" +
+	"\x0e_source_branchB\x10\n" +
+	"\x0e_target_branchB\x06\n" +
+	"\x04_urlB\x10\n" +
+	"\x0e_lines_changed\"8\n" +
+	"\x0eAddTeamRequest\x12&\n" +
+	"\x04team\x18\x01 \x01(\v2\x12.prservice.v1.TeamR\x04team\"9\n" +
+	"\x0fAddTeamResponse\x12&\n" +
+	"\x04team\x18\x01 \x01(\v2\x12.prservice.v1.TeamR\x04team\"-\n" +
+	"\x0eGetTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"9\n" +
+	"\x0fGetTeamResponse\x12&\n" +
+	"\x04team\x18\x01 \x01(\v2\x12.prservice.v1.TeamR\x04team\";\n" +
+	"\x1cDeactivateTeamMembersRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"G\n" +
+	"\x1dDeactivateTeamMembersResponse\x12&\n" +
+	"\x04team\x18\x01 \x01(\v2\x12.prservice.v1.TeamR\x04team\"M\n" +
+	"\x15MoveTeamMemberRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tteam_name\x18\x02 \x01(\tR\bteamName\"@\n" +
+	"\x16MoveTeamMemberResponse\x12&\n" +
+	"\x04user\x18\x01 \x01(\v2\x12.prservice.v1.UserR\x04user\"J\n" +
+	"\x12SetIsActiveRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tis_active\x18\x02 \x01(\bR\bisActive\"=\n" +
+	"\x13SetIsActiveResponse\x12&\n" +
+	"\x04user\x18\x01 \x01(\v2\x12.prservice.v1.UserR\x04user\"+\n" +
+	"\x10GetReviewRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"q\n" +
+	"\x11GetReviewResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12C\n" +
+	"\rpull_requests\x18\x02 \x03(\v2\x1e.prservice.v1.PullRequestShortR\fpullRequests\"\xaa\x03\n" +
+	"\x18CreatePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12#\n" +
+	"\n" +
+	"repository\x18\x04 \x01(\tH\x00R\n" +
+	"repository\x88\x01\x01\x12(\n" +
+	"\rsource_branch\x18\x05 \x01(\tH\x01R\fsourceBranch\x88\x01\x01\x12(\n" +
+	"\rtarget_branch\x18\x06 \x01(\tH\x02R\ftargetBranch\x88\x01\x01\x12\x15\n" +
+	"\x03url\x18\a \x01(\tH\x03R\x03url\x88\x01\x01\x12\x16\n" +
+	"\x06labels\x18\b \x03(\tR\x06labels\x12(\n" +
+	"\rlines_changed\x18\t \x01(\x05H\x04R\flinesChanged\x88\x01\x01B\r\n" +
+	"\v_repositoryB\x10\n" +
+	"\x0e_source_branchB\x10\n" +
+	"\x0e_target_branchB\x06\n" +
+	"\x04_urlB\x10\n" +
+	"\x0e_lines_changed\"F\n" +
+	"\x19CreatePullRequestResponse\x12)\n" +
+	"\x02pr\x18\x01 \x01(\v2\x19.prservice.v1.PullRequestR\x02pr\"A\n" +
+	"\x17MergePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"E\n" +
+	"\x18MergePullRequestResponse\x12)\n" +
+	"\x02pr\x18\x01 \x01(\v2\x19.prservice.v1.PullRequestR\x02pr\"i\n" +
+	"\x17ReassignReviewerRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12&\n" +
+	"\x0fold_reviewer_id\x18\x02 \x01(\tR\roldReviewerId\"f\n" +
+	"\x18ReassignReviewerResponse\x12)\n" +
+	"\x02pr\x18\x01 \x01(\v2\x19.prservice.v1.PullRequestR\x02pr\x12\x1f\n" +
+	"\vreplaced_by\x18\x02 \x01(\tR\n" +
+	"replacedBy\"\x8e\x01\n" +
+	"\x14SubmitVerdictRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x125\n" +
+	"\averdict\x18\x03 \x01(\x0e2\x1b.prservice.v1.ReviewVerdictR\averdict\"\xca\x01\n" +
+	"\x15SubmitVerdictResponse\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x125\n" +
+	"\averdict\x18\x03 \x01(\x0e2\x1b.prservice.v1.ReviewVerdictR\averdict\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd3\x01\n" +
+	"\x17ListPullRequestsRequest\x127\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1f.prservice.v1.PullRequestStatusR\x06status\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x05 \x01(\tR\n" +
+	"repository\x12\x14\n" +
+	"\x05label\x18\x06 \x01(\tR\x05label\"_\n" +
+	"\x18ListPullRequestsResponse\x12C\n" +
+	"\rpull_requests\x18\x01 \x03(\v2\x1e.prservice.v1.PullRequestShortR\fpullRequests\"b\n" +
+	"\x17WatchAssignmentsRequest\x12\x1f\n" +
+	"\vreviewer_id\x18\x01 \x01(\tR\n" +
+	"reviewerId\x12&\n" +
+	"\x0fpull_request_id\x18\x02 \x01(\tR\rpullRequestId\"\x9c\x02\n" +
+	"\x0fAssignmentEvent\x125\n" +
+	"\x04type\x18\x01 \x01(\x0e2!.prservice.v1.AssignmentEventTypeR\x04type\x12&\n" +
+	"\x0fpull_request_id\x18\x02 \x01(\tR\rpullRequestId\x12\x1f\n" +
+	"\vreviewer_id\x18\x03 \x01(\tR\n" +
+	"reviewerId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12$\n" +
+	"\vreplaced_by\x18\x05 \x01(\tH\x00R\n" +
+	"replacedBy\x88\x01\x01\x12;\n" +
+	"\voccurred_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAtB\x0e\n" +
+	"\f_replaced_by\"\xcc\x01\n" +
+	"\x0fGetStatsRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x12@\n" +
+	"\vgranularity\x18\x04 \x01(\x0e2\x1e.prservice.v1.StatsGranularityR\vgranularity\"F\n" +
+	"\bTeamSize\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x1d\n" +
+	"\n" +
+	"user_count\x18\x02 \x01(\x05R\tuserCount\"Y\n" +
+	"\x11AssignmentsByUser\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12+\n" +
+	"\x11assignments_count\x18\x02 \x01(\x05R\x10assignmentsCount\"\xa8\x01\n" +
+	"\x0eMergeTimeStats\x12!\n" +
+	"\fmerged_count\x18\x01 \x01(\x05R\vmergedCount\x12*\n" +
+	"\x0emedian_seconds\x18\x02 \x01(\x01H\x00R\rmedianSeconds\x88\x01\x01\x12$\n" +
+	"\vp90_seconds\x18\x03 \x01(\x01H\x01R\n" +
+	"p90Seconds\x88\x01\x01B\x11\n" +
+	"\x0f_median_secondsB\x0e\n" +
+	"\f_p90_seconds\"\xbc\x01\n" +
+	"\x15FirstVerdictTimeStats\x12.\n" +
+	"\x13pull_requests_count\x18\x01 \x01(\x05R\x11pullRequestsCount\x12*\n" +
+	"\x0emedian_seconds\x18\x02 \x01(\x01H\x00R\rmedianSeconds\x88\x01\x01\x12$\n" +
+	"\vp90_seconds\x18\x03 \x01(\x01H\x01R\n" +
+	"p90Seconds\x88\x01\x01B\x11\n" +
+	"\x0f_median_secondsB\x0e\n" +
+	"\f_p90_seconds\"\x81\x01\n" +
+	"\x10ThroughputBucket\x12=\n" +
+	"\fperiod_start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x12\x16\n" +
+	"\x06opened\x18\x02 \x01(\x05R\x06opened\x12\x16\n" +
+	"\x06merged\x18\x03 \x01(\x05R\x06merged\"\xcd\x03\n" +
+	"\x0fReviewAnalytics\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x12@\n" +
+	"\vgranularity\x18\x04 \x01(\x0e2\x1e.prservice.v1.StatsGranularityR\vgranularity\x12;\n" +
+	"\n" +
+	"merge_time\x18\x05 \x01(\v2\x1c.prservice.v1.MergeTimeStatsR\tmergeTime\x12>\n" +
+	"\n" +
+	"throughput\x18\x06 \x03(\v2\x1e.prservice.v1.ThroughputBucketR\n" +
+	"throughput\x12/\n" +
+	"\x13reassignments_count\x18\a \x01(\x05R\x12reassignmentsCount\x12Q\n" +
+	"\x12first_verdict_time\x18\b \x01(\v2#.prservice.v1.FirstVerdictTimeStatsR\x10firstVerdictTime\"\xff\x03\n" +
+	"\x10GetStatsResponse\x12*\n" +
+	"\x11total_users_count\x18\x01 \x01(\x05R\x0ftotalUsersCount\x12,\n" +
+	"\x12active_users_count\x18\x02 \x01(\x05R\x10activeUsersCount\x12*\n" +
+	"\x11total_teams_count\x18\x03 \x01(\x05R\x0ftotalTeamsCount\x12;\n" +
+	"\x1aopened_pull_requests_count\x18\x04 \x01(\x05R\x17openedPullRequestsCount\x12;\n" +
+	"\x1amerged_pull_requests_count\x18\x05 \x01(\x05R\x17mergedPullRequestsCount\x12E\n" +
+	"\x13users_count_by_team\x18\x06 \x03(\v2\x16.prservice.v1.TeamSizeR\x10usersCountByTeam\x12Z\n" +
+	"\x19assignments_count_by_user\x18\a \x03(\v2\x1f.prservice.v1.AssignmentsByUserR\x16assignmentsCountByUser\x12H\n" +
+	"\x10review_analytics\x18\b \x01(\v2\x1d.prservice.v1.ReviewAnalyticsR\x0freviewAnalytics\"1\n" +
+	"\x12GetFairnessRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"\x96\x02\n" +
+	"\fReviewerLoad\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\x12)\n" +
+	"\x10open_assignments\x18\x04 \x01(\x05R\x0fopenAssignments\x12+\n" +
+	"\x11total_assignments\x18\x05 \x01(\x05R\x10totalAssignments\x12\x14\n" +
+	"\x05share\x18\x06 \x01(\x01R\x05share\x12%\n" +
+	"\x0eexpected_share\x18\a \x01(\x01R\rexpectedShare\x12\x1f\n" +
+	"\vload_status\x18\b \x01(\tR\n" +
+	"loadStatus\"\x9b\x02\n" +
+	"\fTeamFairness\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12+\n" +
+	"\x11total_assignments\x18\x02 \x01(\x05R\x10totalAssignments\x12)\n" +
+	"\x10gini_coefficient\x18\x03 \x01(\x01R\x0fginiCoefficient\x124\n" +
+	"\amembers\x18\x04 \x03(\v2\x1a.prservice.v1.ReviewerLoadR\amembers\x12.\n" +
+	"\x13overloaded_user_ids\x18\x05 \x03(\tR\x11overloadedUserIds\x120\n" +
+	"\x14underloaded_user_ids\x18\x06 \x03(\tR\x12underloadedUserIds\"G\n" +
+	"\x13GetFairnessResponse\x120\n" +
+	"\x05teams\x18\x01 \x03(\v2\x1a.prservice.v1.TeamFairnessR\x05teams*v\n" +
+	"\x11PullRequestStatus\x12#\n" +
+	"\x1fPULL_REQUEST_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18PULL_REQUEST_STATUS_OPEN\x10\x01\x12\x1e\n" +
+	"\x1aPULL_REQUEST_STATUS_MERGED\x10\x02*r\n" +
+	"\rReviewVerdict\x12\x1e\n" +
+	"\x1aREVIEW_VERDICT_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17REVIEW_VERDICT_APPROVED\x10\x01\x12$\n" +
+	" REVIEW_VERDICT_CHANGES_REQUESTED\x10\x02*\x86\x01\n" +
+	"\x13AssignmentEventType\x12%\n" +
+	"!ASSIGNMENT_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eASSIGNMENT_EVENT_TYPE_ASSIGNED\x10\x01\x12$\n" +
+	" ASSIGNMENT_EVENT_TYPE_UNASSIGNED\x10\x02*l\n" +
+	"\x10StatsGranularity\x12!\n" +
+	"\x1dSTATS_GRANULARITY_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15STATS_GRANULARITY_DAY\x10\x01\x12\x1a\n" +
+	"\x16STATS_GRANULARITY_WEEK\x10\x022\xec\x02\n" +
+	"\vTeamService\x12F\n" +
+	"\aAddTeam\x12\x1c.prservice.v1.AddTeamRequest\x1a\x1d.prservice.v1.AddTeamResponse\x12F\n" +
+	"\aGetTeam\x12\x1c.prservice.v1.GetTeamRequest\x1a\x1d.prservice.v1.GetTeamResponse\x12p\n" +
+	"\x15DeactivateTeamMembers\x12*.prservice.v1.DeactivateTeamMembersRequest\x1a+.prservice.v1.DeactivateTeamMembersResponse\x12[\n" +
+	"\x0eMoveTeamMember\x12#.prservice.v1.MoveTeamMemberRequest\x1a$.prservice.v1.MoveTeamMemberResponse2\xaf\x01\n" +
+	"\vUserService\x12R\n" +
+	"\vSetIsActive\x12 .prservice.v1.SetIsActiveRequest\x1a!.prservice.v1.SetIsActiveResponse\x12L\n" +
+	"\tGetReview\x12\x1e.prservice.v1.GetReviewRequest\x1a\x1f.prservice.v1.GetReviewResponse2\xd9\x04\n" +
+	"\x12PullRequestService\x12d\n" +
+	"\x11CreatePullRequest\x12&.prservice.v1.CreatePullRequestRequest\x1a'.prservice.v1.CreatePullRequestResponse\x12a\n" +
+	"\x10MergePullRequest\x12%.prservice.v1.MergePullRequestRequest\x1a&.prservice.v1.MergePullRequestResponse\x12a\n" +
+	"\x10ReassignReviewer\x12%.prservice.v1.ReassignReviewerRequest\x1a&.prservice.v1.ReassignReviewerResponse\x12X\n" +
+	"\rSubmitVerdict\x12\".prservice.v1.SubmitVerdictRequest\x1a#.prservice.v1.SubmitVerdictResponse\x12a\n" +
+	"\x10ListPullRequests\x12%.prservice.v1.ListPullRequestsRequest\x1a&.prservice.v1.ListPullRequestsResponse\x12Z\n" +
+	"\x10WatchAssignments\x12%.prservice.v1.WatchAssignmentsRequest\x1a\x1d.prservice.v1.AssignmentEvent0\x012\xad\x01\n" +
+	"\fStatsService\x12I\n" +
+	"\bGetStats\x12\x1d.prservice.v1.GetStatsRequest\x1a\x1e.prservice.v1.GetStatsResponse\x12R\n" +
+	"\vGetFairness\x12 .prservice.v1.GetFairnessRequest\x1a!.prservice.v1.GetFairnessResponseBHZFgithub.com/salex06/pr-service/internal/grpcapi/prservicev1;prservicev1b\x06proto3"
+
+var (
+	file_prservice_v1_prservice_proto_rawDescOnce sync.Once
+	file_prservice_v1_prservice_proto_rawDescData []byte
+)
+
+func file_prservice_v1_prservice_proto_rawDescGZIP() []byte {
+	file_prservice_v1_prservice_proto_rawDescOnce.Do(func() {
+		file_prservice_v1_prservice_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_prservice_v1_prservice_proto_rawDesc), len(file_prservice_v1_prservice_proto_rawDesc)))
+	})
+	return file_prservice_v1_prservice_proto_rawDescData
+}
+
+var file_prservice_v1_prservice_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_prservice_v1_prservice_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
+var file_prservice_v1_prservice_proto_goTypes = []any{
+	(PullRequestStatus)(0),                // 0: prservice.v1.PullRequestStatus
+	(ReviewVerdict)(0),                    // 1: prservice.v1.ReviewVerdict
+	(AssignmentEventType)(0),              // 2: prservice.v1.AssignmentEventType
+	(StatsGranularity)(0),                 // 3: prservice.v1.StatsGranularity
+	(*TeamMember)(nil),                    // 4: prservice.v1.TeamMember
+	(*Team)(nil),                          // 5: prservice.v1.Team
+	(*User)(nil),                          // 6: prservice.v1.User
+	(*PullRequest)(nil),                   // 7: prservice.v1.PullRequest
+	(*PullRequestShort)(nil),              // 8: prservice.v1.PullRequestShort
+	(*AddTeamRequest)(nil),                // 9: prservice.v1.AddTeamRequest
+	(*AddTeamResponse)(nil),               // 10: prservice.v1.AddTeamResponse
+	(*GetTeamRequest)(nil),                // 11: prservice.v1.GetTeamRequest
+	(*GetTeamResponse)(nil),               // 12: prservice.v1.GetTeamResponse
+	(*DeactivateTeamMembersRequest)(nil),  // 13: prservice.v1.DeactivateTeamMembersRequest
+	(*DeactivateTeamMembersResponse)(nil), // 14: prservice.v1.DeactivateTeamMembersResponse
+	(*MoveTeamMemberRequest)(nil),         // 15: prservice.v1.MoveTeamMemberRequest
+	(*MoveTeamMemberResponse)(nil),        // 16: prservice.v1.MoveTeamMemberResponse
+	(*SetIsActiveRequest)(nil),            // 17: prservice.v1.SetIsActiveRequest
+	(*SetIsActiveResponse)(nil),           // 18: prservice.v1.SetIsActiveResponse
+	(*GetReviewRequest)(nil),              // 19: prservice.v1.GetReviewRequest
+	(*GetReviewResponse)(nil),             // 20: prservice.v1.GetReviewResponse
+	(*CreatePullRequestRequest)(nil),      // 21: prservice.v1.CreatePullRequestRequest
+	(*CreatePullRequestResponse)(nil),     // 22: prservice.v1.CreatePullRequestResponse
+	(*MergePullRequestRequest)(nil),       // 23: prservice.v1.MergePullRequestRequest
+	(*MergePullRequestResponse)(nil),      // 24: prservice.v1.MergePullRequestResponse
+	(*ReassignReviewerRequest)(nil),       // 25: prservice.v1.ReassignReviewerRequest
+	(*ReassignReviewerResponse)(nil),      // 26: prservice.v1.ReassignReviewerResponse
+	(*SubmitVerdictRequest)(nil),          // 27: prservice.v1.SubmitVerdictRequest
+	(*SubmitVerdictResponse)(nil),         // 28: prservice.v1.SubmitVerdictResponse
+	(*ListPullRequestsRequest)(nil),       // 29: prservice.v1.ListPullRequestsRequest
+	(*ListPullRequestsResponse)(nil),      // 30: prservice.v1.ListPullRequestsResponse
+	(*WatchAssignmentsRequest)(nil),       // 31: prservice.v1.WatchAssignmentsRequest
+	(*AssignmentEvent)(nil),               // 32: prservice.v1.AssignmentEvent
+	(*GetStatsRequest)(nil),               // 33: prservice.v1.GetStatsRequest
+	(*TeamSize)(nil),                      // 34: prservice.v1.TeamSize
+	(*AssignmentsByUser)(nil),             // 35: prservice.v1.AssignmentsByUser
+	(*MergeTimeStats)(nil),                // 36: prservice.v1.MergeTimeStats
+	(*FirstVerdictTimeStats)(nil),         // 37: prservice.v1.FirstVerdictTimeStats
+	(*ThroughputBucket)(nil),              // 38: prservice.v1.ThroughputBucket
+	(*ReviewAnalytics)(nil),               // 39: prservice.v1.ReviewAnalytics
+	(*GetStatsResponse)(nil),              // 40: prservice.v1.GetStatsResponse
+	(*GetFairnessRequest)(nil),            // 41: prservice.v1.GetFairnessRequest
+	(*ReviewerLoad)(nil),                  // 42: prservice.v1.ReviewerLoad
+	(*TeamFairness)(nil),                  // 43: prservice.v1.TeamFairness
+	(*GetFairnessResponse)(nil),           // 44: prservice.v1.GetFairnessResponse
+	(*timestamppb.Timestamp)(nil),         // 45: google.protobuf.Timestamp
+}
+var file_prservice_v1_prservice_proto_depIdxs = []int32{
+	4,  // 0: prservice.v1.Team.members:type_name -> prservice.v1.TeamMember
+	0,  // 1: prservice.v1.PullRequest.status:type_name -> prservice.v1.PullRequestStatus
+	45, // 2: prservice.v1.PullRequest.created_at:type_name -> google.protobuf.Timestamp
+	45, // 3: prservice.v1.PullRequest.merged_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: prservice.v1.PullRequestShort.status:type_name -> prservice.v1.PullRequestStatus
+	5,  // 5: prservice.v1.AddTeamRequest.team:type_name -> prservice.v1.Team
+	5,  // 6: prservice.v1.AddTeamResponse.team:type_name -> prservice.v1.Team
+	5,  // 7: prservice.v1.GetTeamResponse.team:type_name -> prservice.v1.Team
+	5,  // 8: prservice.v1.DeactivateTeamMembersResponse.team:type_name -> prservice.v1.Team
+	6,  // 9: prservice.v1.MoveTeamMemberResponse.user:type_name -> prservice.v1.User
+	6,  // 10: prservice.v1.SetIsActiveResponse.user:type_name -> prservice.v1.User
+	8,  // 11: prservice.v1.GetReviewResponse.pull_requests:type_name -> prservice.v1.PullRequestShort
+	7,  // 12: prservice.v1.CreatePullRequestResponse.pr:type_name -> prservice.v1.PullRequest
+	7,  // 13: prservice.v1.MergePullRequestResponse.pr:type_name -> prservice.v1.PullRequest
+	7,  // 14: prservice.v1.ReassignReviewerResponse.pr:type_name -> prservice.v1.PullRequest
+	1,  // 15: prservice.v1.SubmitVerdictRequest.verdict:type_name -> prservice.v1.ReviewVerdict
+	1,  // 16: prservice.v1.SubmitVerdictResponse.verdict:type_name -> prservice.v1.ReviewVerdict
+	45, // 17: prservice.v1.SubmitVerdictResponse.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 18: prservice.v1.ListPullRequestsRequest.status:type_name -> prservice.v1.PullRequestStatus
+	8,  // 19: prservice.v1.ListPullRequestsResponse.pull_requests:type_name -> prservice.v1.PullRequestShort
+	2,  // 20: prservice.v1.AssignmentEvent.type:type_name -> prservice.v1.AssignmentEventType
+	45, // 21: prservice.v1.AssignmentEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	45, // 22: prservice.v1.GetStatsRequest.from:type_name -> google.protobuf.Timestamp
+	45, // 23: prservice.v1.GetStatsRequest.to:type_name -> google.protobuf.Timestamp
+	3,  // 24: prservice.v1.GetStatsRequest.granularity:type_name -> prservice.v1.StatsGranularity
+	45, // 25: prservice.v1.ThroughputBucket.period_start:type_name -> google.protobuf.Timestamp
+	45, // 26: prservice.v1.ReviewAnalytics.from:type_name -> google.protobuf.Timestamp
+	45, // 27: prservice.v1.ReviewAnalytics.to:type_name -> google.protobuf.Timestamp
+	3,  // 28: prservice.v1.ReviewAnalytics.granularity:type_name -> prservice.v1.StatsGranularity
+	36, // 29: prservice.v1.ReviewAnalytics.merge_time:type_name -> prservice.v1.MergeTimeStats
+	38, // 30: prservice.v1.ReviewAnalytics.throughput:type_name -> prservice.v1.ThroughputBucket
+	37, // 31: prservice.v1.ReviewAnalytics.first_verdict_time:type_name -> prservice.v1.FirstVerdictTimeStats
+	34, // 32: prservice.v1.GetStatsResponse.users_count_by_team:type_name -> prservice.v1.TeamSize
+	35, // 33: prservice.v1.GetStatsResponse.assignments_count_by_user:type_name -> prservice.v1.AssignmentsByUser
+	39, // 34: prservice.v1.GetStatsResponse.review_analytics:type_name -> prservice.v1.ReviewAnalytics
+	42, // 35: prservice.v1.TeamFairness.members:type_name -> prservice.v1.ReviewerLoad
+	43, // 36: prservice.v1.GetFairnessResponse.teams:type_name -> prservice.v1.TeamFairness
+	9,  // 37: prservice.v1.TeamService.AddTeam:input_type -> prservice.v1.AddTeamRequest
+	11, // 38: prservice.v1.TeamService.GetTeam:input_type -> prservice.v1.GetTeamRequest
+	13, // 39: prservice.v1.TeamService.DeactivateTeamMembers:input_type -> prservice.v1.DeactivateTeamMembersRequest
+	15, // 40: prservice.v1.TeamService.MoveTeamMember:input_type -> prservice.v1.MoveTeamMemberRequest
+	17, // 41: prservice.v1.UserService.SetIsActive:input_type -> prservice.v1.SetIsActiveRequest
+	19, // 42: prservice.v1.UserService.GetReview:input_type -> prservice.v1.GetReviewRequest
+	21, // 43: prservice.v1.PullRequestService.CreatePullRequest:input_type -> prservice.v1.CreatePullRequestRequest
+	23, // 44: prservice.v1.PullRequestService.MergePullRequest:input_type -> prservice.v1.MergePullRequestRequest
+	25, // 45: prservice.v1.PullRequestService.ReassignReviewer:input_type -> prservice.v1.ReassignReviewerRequest
+	27, // 46: prservice.v1.PullRequestService.SubmitVerdict:input_type -> prservice.v1.SubmitVerdictRequest
+	29, // 47: prservice.v1.PullRequestService.ListPullRequests:input_type -> prservice.v1.ListPullRequestsRequest
+	31, // 48: prservice.v1.PullRequestService.WatchAssignments:input_type -> prservice.v1.WatchAssignmentsRequest
+	33, // 49: prservice.v1.StatsService.GetStats:input_type -> prservice.v1.GetStatsRequest
+	41, // 50: prservice.v1.StatsService.GetFairness:input_type -> prservice.v1.GetFairnessRequest
+	10, // 51: prservice.v1.TeamService.AddTeam:output_type -> prservice.v1.AddTeamResponse
+	12, // 52: prservice.v1.TeamService.GetTeam:output_type -> prservice.v1.GetTeamResponse
+	14, // 53: prservice.v1.TeamService.DeactivateTeamMembers:output_type -> prservice.v1.DeactivateTeamMembersResponse
+	16, // 54: prservice.v1.TeamService.MoveTeamMember:output_type -> prservice.v1.MoveTeamMemberResponse
+	18, // 55: prservice.v1.UserService.SetIsActive:output_type -> prservice.v1.SetIsActiveResponse
+	20, // 56: prservice.v1.UserService.GetReview:output_type -> prservice.v1.GetReviewResponse
+	22, // 57: prservice.v1.PullRequestService.CreatePullRequest:output_type -> prservice.v1.CreatePullRequestResponse
+	24, // 58: prservice.v1.PullRequestService.MergePullRequest:output_type -> prservice.v1.MergePullRequestResponse
+	26, // 59: prservice.v1.PullRequestService.ReassignReviewer:output_type -> prservice.v1.ReassignReviewerResponse
+	28, // 60: prservice.v1.PullRequestService.SubmitVerdict:output_type -> prservice.v1.SubmitVerdictResponse
+	30, // 61: prservice.v1.PullRequestService.ListPullRequests:output_type -> prservice.v1.ListPullRequestsResponse
+	32, // 62: prservice.v1.PullRequestService.WatchAssignments:output_type -> prservice.v1.AssignmentEvent
+	40, // 63: prservice.v1.StatsService.GetStats:output_type -> prservice.v1.GetStatsResponse
+	44, // 64: prservice.v1.StatsService.GetFairness:output_type -> prservice.v1.GetFairnessResponse
+	51, // [51:65] is the sub-list for method output_type
+	37, // [37:51] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
+}
+
+func init() { file_prservice_v1_prservice_proto_init() }
+func file_prservice_v1_prservice_proto_init() {
+	if File_prservice_v1_prservice_proto != nil {
+		return
+	}
+	file_prservice_v1_prservice_proto_msgTypes[0].OneofWrappers = []any{}
+	file_prservice_v1_prservice_proto_msgTypes[3].OneofWrappers = []any{}
+	file_prservice_v1_prservice_proto_msgTypes[4].OneofWrappers = []any{}
+	file_prservice_v1_prservice_proto_msgTypes[17].OneofWrappers = []any{}
+	file_prservice_v1_prservice_proto_msgTypes[28].OneofWrappers = []any{}
+	file_prservice_v1_prservice_proto_msgTypes[32].OneofWrappers = []any{}
+	file_prservice_v1_prservice_proto_msgTypes[33].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_prservice_v1_prservice_proto_rawDesc), len(file_prservice_v1_prservice_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   41,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_prservice_v1_prservice_proto_goTypes,
+		DependencyIndexes: file_prservice_v1_prservice_proto_depIdxs,
+		EnumInfos:         file_prservice_v1_prservice_proto_enumTypes,
+		MessageInfos:      file_prservice_v1_prservice_proto_msgTypes,
+	}.Build()
+	File_prservice_v1_prservice_proto = out.File
+	file_prservice_v1_prservice_proto_goTypes = nil
+	file_prservice_v1_prservice_proto_depIdxs = nil
+}
@@ -0,0 +1,582 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/salex06/pr-service/internal/auth"
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+	pb "github.com/salex06/pr-service/internal/grpcapi/prservicev1"
+	apiKeyRepository "github.com/salex06/pr-service/internal/repos/apikey"
+	auditRepository "github.com/salex06/pr-service/internal/repos/audit"
+	batchRepository "github.com/salex06/pr-service/internal/repos/batch"
+	prRepository "github.com/salex06/pr-service/internal/repos/pr"
+	revsRepository "github.com/salex06/pr-service/internal/repos/reviewers"
+	teamRepository "github.com/salex06/pr-service/internal/repos/team"
+	userRepository "github.com/salex06/pr-service/internal/repos/user"
+	"github.com/salex06/pr-service/internal/rest"
+	"github.com/salex06/pr-service/internal/service"
+)
+
+// testTokens - статические токены клиентов тестового сервера
+const testTokens = "admin-token=admin:admin;" +
+	"backend-lead-token=backend-lead:team-lead:backend;" +
+	"frontend-lead-token=frontend-lead:team-lead:frontend;" +
+	"u1-token=u1:member:backend;" +
+	"u2-token=u2:member:backend;" +
+	"service-token=ci:service"
+
+var registerValidators sync.Once
+
+// testClient - клиент gRPC API, запущенного поверх in-memory репозиториев
+type testClient struct {
+	teams        pb.TeamServiceClient
+	users        pb.UserServiceClient
+	pullRequests pb.PullRequestServiceClient
+	stats        pb.StatsServiceClient
+	health       healthpb.HealthClient
+
+	events *service.AssignmentEventBroker
+	// statsKey - ключ доступа с единственным разрешением stats:read
+	statsKey string
+}
+
+func newTestClient(t *testing.T) *testClient {
+	t.Helper()
+
+	registerValidators.Do(func() {
+		if err := rest.RegisterValidators(); err != nil {
+			t.Fatalf("RegisterValidators: %v", err)
+		}
+	})
+
+	var (
+		teamRepo   teamRepository.TeamRepository              = teamRepository.NewInMemoryTeamRepository()
+		userRepo   userRepository.UserRepository              = userRepository.NewInMemoryUserRepository()
+		prRepo     prRepository.PullRequestRepository         = prRepository.NewInMemoryPullRequestRepository()
+		revsRepo   revsRepository.AssignedRevsRepository      = revsRepository.NewInMemoryAssignedRevsRepository(prRepo)
+		batchRepo  batchRepository.PullRequestBatchRepository = batchRepository.NewInMemoryPullRequestBatchRepository(prRepo, revsRepo)
+		auditRepo  auditRepository.AuditRepository            = auditRepository.NewInMemoryAuditRepository()
+		apiKeyRepo apiKeyRepository.APIKeyRepository          = apiKeyRepository.NewInMemoryAPIKeyRepository()
+	)
+
+	keyID, key, err := auth.GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("GenerateAPIKey: %v", err)
+	}
+	if err := apiKeyRepo.SaveAPIKey(context.Background(), &entity.APIKey{
+		KeyID:   keyID,
+		Name:    "dashboard",
+		KeyHash: auth.HashAPIKey(key),
+		Scopes:  []string{string(auth.ScopeStatsRead)},
+	}); err != nil {
+		t.Fatalf("SaveAPIKey: %v", err)
+	}
+
+	staticAuthenticator, err := auth.NewStaticTokenAuthenticator(testTokens)
+	if err != nil {
+		t.Fatalf("NewStaticTokenAuthenticator: %v", err)
+	}
+	authenticator := auth.NewChainAuthenticator(auth.NewAPIKeyAuthenticator(&apiKeyRepo), staticAuthenticator)
+
+	events := service.NewAssignmentEventBroker()
+	auditService := service.NewAuditService(&auditRepo)
+	server := NewServer(&Services{
+		Team:        service.NewTeamService(&teamRepo, &userRepo, &revsRepo, &prRepo, auditService, nil),
+		User:        service.NewUserService(&userRepo, &revsRepo, &prRepo, auditService, nil),
+		PullRequest: service.NewPullRequestService(&prRepo, &revsRepo, &userRepo, &teamRepo, &batchRepo, auditService, events, nil),
+		Stats:       service.NewStatsService(&prRepo, &revsRepo, &userRepo, &teamRepo),
+		Events:      events,
+	}, authenticator, auth.NewPolicy(&userRepo, &prRepo), 5*time.Second)
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() {
+		events.Close()
+		server.Stop()
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return &testClient{
+		teams:        pb.NewTeamServiceClient(conn),
+		users:        pb.NewUserServiceClient(conn),
+		pullRequests: pb.NewPullRequestServiceClient(conn),
+		stats:        pb.NewStatsServiceClient(conn),
+		health:       healthpb.NewHealthClient(conn),
+		events:       events,
+		statsKey:     key,
+	}
+}
+
+// withToken возвращает контекст вызова от имени владельца token
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// assertStatus проверяет gRPC-код ошибки и код ошибки REST API в деталях ErrorInfo
+func assertStatus(t *testing.T, err error, wantCode codes.Code, wantReason dto.ErrorCode) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("error %v is not a grpc status", err)
+	}
+	if st.Code() != wantCode {
+		t.Fatalf("code = %s, want %s (message: %s)", st.Code(), wantCode, st.Message())
+	}
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			if info.GetReason() != string(wantReason) {
+				t.Fatalf("reason = %s, want %s", info.GetReason(), wantReason)
+			}
+			return
+		}
+	}
+	t.Fatalf("status %s has no ErrorInfo details", st.Code())
+}
+
+func mustNoError(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// addBackend создает команду backend из активных сотрудников u1-u4
+func (c *testClient) addBackend(t *testing.T) {
+	t.Helper()
+
+	members := make([]*pb.TeamMember, 0, 4)
+	for _, userID := range []string{"u1", "u2", "u3", "u4"} {
+		members = append(members, &pb.TeamMember{UserId: userID, Username: userID, IsActive: true})
+	}
+	_, err := c.teams.AddTeam(withToken("admin-token"), &pb.AddTeamRequest{
+		Team: &pb.Team{TeamName: "backend", Members: members},
+	})
+	mustNoError(t, err)
+}
+
+func TestAuthentication(t *testing.T) {
+	c := newTestClient(t)
+
+	_, err := c.teams.GetTeam(context.Background(), &pb.GetTeamRequest{TeamName: "backend"})
+	assertStatus(t, err, codes.Unauthenticated, dto.Unauthorized)
+
+	_, err = c.teams.GetTeam(withToken("unknown-token"), &pb.GetTeamRequest{TeamName: "backend"})
+	assertStatus(t, err, codes.Unauthenticated, dto.Unauthorized)
+
+	// Проверка состояния доступна без токена
+	resp, err := c.health.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	mustNoError(t, err)
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("health status = %s, want SERVING", resp.GetStatus())
+	}
+}
+
+func TestMethodAccess(t *testing.T) {
+	c := newTestClient(t)
+	c.addBackend(t)
+	_, err := c.teams.AddTeam(withToken("admin-token"), &pb.AddTeamRequest{Team: &pb.Team{
+		TeamName: "frontend",
+		Members:  []*pb.TeamMember{{UserId: "u5", Username: "u5", IsActive: true}},
+	}})
+	mustNoError(t, err)
+
+	// Ключу доступа - только методы, на которые у него есть разрешение
+	_, err = c.stats.GetFairness(withToken(c.statsKey), &pb.GetFairnessRequest{})
+	mustNoError(t, err)
+	_, err = c.teams.GetTeam(withToken(c.statsKey), &pb.GetTeamRequest{TeamName: "backend"})
+	assertStatus(t, err, codes.PermissionDenied, dto.Forbidden)
+
+	// Остальным клиентам - по тем же правилам, что и в REST API
+	calls := []struct {
+		name    string
+		token   string
+		call    func(ctx context.Context) error
+		allowed bool
+	}{
+		{name: "member reads team", token: "u1-token", allowed: true, call: func(ctx context.Context) error {
+			_, err := c.teams.GetTeam(ctx, &pb.GetTeamRequest{TeamName: "frontend"})
+			return err
+		}},
+		{name: "member creates PR of another author", token: "u1-token", call: func(ctx context.Context) error {
+			_, err := c.pullRequests.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{
+				PullRequestId: "pr-2", PullRequestName: "Fix", AuthorId: "u2",
+			})
+			return err
+		}},
+		{name: "member creates own PR", token: "u1-token", allowed: true, call: func(ctx context.Context) error {
+			_, err := c.pullRequests.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{
+				PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1",
+			})
+			return err
+		}},
+		{name: "service creates PR", token: "service-token", allowed: true, call: func(ctx context.Context) error {
+			_, err := c.pullRequests.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{
+				PullRequestId: "pr-3", PullRequestName: "Bump deps", AuthorId: "u3",
+			})
+			return err
+		}},
+		{name: "service deactivates team", token: "service-token", call: func(ctx context.Context) error {
+			_, err := c.teams.DeactivateTeamMembers(ctx, &pb.DeactivateTeamMembersRequest{TeamName: "frontend"})
+			return err
+		}},
+		{name: "service moves member", token: "service-token", call: func(ctx context.Context) error {
+			_, err := c.teams.MoveTeamMember(ctx, &pb.MoveTeamMemberRequest{UserId: "u4", TeamName: "frontend"})
+			return err
+		}},
+		{name: "lead of another team reassigns", token: "frontend-lead-token", call: func(ctx context.Context) error {
+			_, err := c.pullRequests.ReassignReviewer(ctx, &pb.ReassignReviewerRequest{PullRequestId: "pr-1"})
+			return err
+		}},
+		{name: "not an author merges", token: "u2-token", call: func(ctx context.Context) error {
+			_, err := c.pullRequests.MergePullRequest(ctx, &pb.MergePullRequestRequest{PullRequestId: "pr-1"})
+			return err
+		}},
+		{name: "author merges", token: "u1-token", allowed: true, call: func(ctx context.Context) error {
+			_, err := c.pullRequests.MergePullRequest(ctx, &pb.MergePullRequestRequest{PullRequestId: "pr-1"})
+			return err
+		}},
+		{name: "member submits verdict for another user", token: "u1-token", call: func(ctx context.Context) error {
+			_, err := c.pullRequests.SubmitVerdict(ctx, &pb.SubmitVerdictRequest{
+				PullRequestId: "pr-3", UserId: "u2", Verdict: pb.ReviewVerdict_REVIEW_VERDICT_APPROVED,
+			})
+			return err
+		}},
+		{name: "member changes activity", token: "u1-token", call: func(ctx context.Context) error {
+			_, err := c.users.SetIsActive(ctx, &pb.SetIsActiveRequest{UserId: "u1", IsActive: false})
+			return err
+		}},
+		{name: "lead changes activity of another team member", token: "backend-lead-token", call: func(ctx context.Context) error {
+			_, err := c.users.SetIsActive(ctx, &pb.SetIsActiveRequest{UserId: "u5", IsActive: false})
+			return err
+		}},
+		{name: "lead changes activity of own team member", token: "backend-lead-token", allowed: true,
+			call: func(ctx context.Context) error {
+				_, err := c.users.SetIsActive(ctx, &pb.SetIsActiveRequest{UserId: "u4", IsActive: false})
+				return err
+			}},
+		{name: "lead moves member out of another team", token: "backend-lead-token", call: func(ctx context.Context) error {
+			_, err := c.teams.MoveTeamMember(ctx, &pb.MoveTeamMemberRequest{UserId: "u5", TeamName: "backend"})
+			return err
+		}},
+		{name: "lead adds another team", token: "backend-lead-token", call: func(ctx context.Context) error {
+			_, err := c.teams.AddTeam(ctx, &pb.AddTeamRequest{Team: &pb.Team{TeamName: "mobile"}})
+			return err
+		}},
+		{name: "lead deactivates another team", token: "backend-lead-token", call: func(ctx context.Context) error {
+			_, err := c.teams.DeactivateTeamMembers(ctx, &pb.DeactivateTeamMembersRequest{TeamName: "frontend"})
+			return err
+		}},
+		{name: "lead deactivates own team", token: "backend-lead-token", allowed: true, call: func(ctx context.Context) error {
+			_, err := c.teams.DeactivateTeamMembers(ctx, &pb.DeactivateTeamMembersRequest{TeamName: "backend"})
+			return err
+		}},
+	}
+	for _, tt := range calls {
+		err := tt.call(withToken(tt.token))
+		if tt.allowed {
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+			continue
+		}
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("%s: err = %v, want PermissionDenied", tt.name, err)
+		}
+	}
+}
+
+func TestTeamService(t *testing.T) {
+	c := newTestClient(t)
+	admin := withToken("admin-token")
+	c.addBackend(t)
+
+	_, err := c.teams.AddTeam(admin, &pb.AddTeamRequest{Team: &pb.Team{
+		TeamName: "backend",
+		Members:  []*pb.TeamMember{{UserId: "u5", Username: "u5", IsActive: true}},
+	}})
+	assertStatus(t, err, codes.AlreadyExists, dto.TeamExists)
+
+	_, err = c.teams.AddTeam(admin, &pb.AddTeamRequest{Team: &pb.Team{TeamName: ""}})
+	assertStatus(t, err, codes.InvalidArgument, dto.ValidationError)
+
+	_, err = c.teams.AddTeam(admin, &pb.AddTeamRequest{Team: &pb.Team{
+		TeamName: "frontend",
+		Members:  []*pb.TeamMember{{UserId: "u5", Username: "u5", IsActive: true}},
+	}})
+	mustNoError(t, err)
+
+	team, err := c.teams.GetTeam(admin, &pb.GetTeamRequest{TeamName: "backend"})
+	mustNoError(t, err)
+	if got := len(team.GetTeam().GetMembers()); got != 4 {
+		t.Fatalf("backend members = %d, want 4", got)
+	}
+
+	_, err = c.teams.GetTeam(admin, &pb.GetTeamRequest{TeamName: "missing"})
+	assertStatus(t, err, codes.NotFound, dto.NotFound)
+
+	moved, err := c.teams.MoveTeamMember(admin, &pb.MoveTeamMemberRequest{UserId: "u4", TeamName: "frontend"})
+	mustNoError(t, err)
+	if moved.GetUser().GetTeamName() != "frontend" {
+		t.Fatalf("moved user team = %q, want frontend", moved.GetUser().GetTeamName())
+	}
+
+	_, err = c.teams.MoveTeamMember(admin, &pb.MoveTeamMemberRequest{UserId: "u4", TeamName: "missing"})
+	assertStatus(t, err, codes.NotFound, dto.NotFound)
+
+	deactivated, err := c.teams.DeactivateTeamMembers(admin, &pb.DeactivateTeamMembersRequest{TeamName: "frontend"})
+	mustNoError(t, err)
+	for _, member := range deactivated.GetTeam().GetMembers() {
+		if member.GetIsActive() {
+			t.Fatalf("member %s is still active after deactivation", member.GetUserId())
+		}
+	}
+
+	_, err = c.teams.DeactivateTeamMembers(admin, &pb.DeactivateTeamMembersRequest{TeamName: "missing"})
+	assertStatus(t, err, codes.NotFound, dto.NotFound)
+}
+
+func TestUserService(t *testing.T) {
+	c := newTestClient(t)
+	admin := withToken("admin-token")
+	c.addBackend(t)
+
+	user, err := c.users.SetIsActive(admin, &pb.SetIsActiveRequest{UserId: "u4", IsActive: false})
+	mustNoError(t, err)
+	if user.GetUser().GetIsActive() {
+		t.Fatal("user u4 is still active")
+	}
+
+	_, err = c.users.SetIsActive(admin, &pb.SetIsActiveRequest{UserId: "missing", IsActive: false})
+	assertStatus(t, err, codes.NotFound, dto.NotFound)
+
+	created, err := c.pullRequests.CreatePullRequest(admin, &pb.CreatePullRequestRequest{
+		PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1",
+	})
+	mustNoError(t, err)
+	reviewer := created.GetPr().GetAssignedReviewers()[0]
+
+	review, err := c.users.GetReview(withToken("u1-token"), &pb.GetReviewRequest{UserId: reviewer})
+	mustNoError(t, err)
+	if len(review.GetPullRequests()) != 1 || review.GetPullRequests()[0].GetPullRequestId() != "pr-1" {
+		t.Fatalf("reviews of %s = %v, want [pr-1]", reviewer, review.GetPullRequests())
+	}
+}
+
+func TestPullRequestService(t *testing.T) {
+	c := newTestClient(t)
+	admin := withToken("admin-token")
+	c.addBackend(t)
+
+	repository := "salex06/pr-service"
+	created, err := c.pullRequests.CreatePullRequest(admin, &pb.CreatePullRequestRequest{
+		PullRequestId:   "pr-1",
+		PullRequestName: "Add search",
+		AuthorId:        "u1",
+		Repository:      &repository,
+		Labels:          []string{"backend"},
+	})
+	mustNoError(t, err)
+	pr := created.GetPr()
+	if pr.GetStatus() != pb.PullRequestStatus_PULL_REQUEST_STATUS_OPEN || len(pr.GetAssignedReviewers()) != 2 {
+		t.Fatalf("created PR = %v, want OPEN with 2 reviewers", pr)
+	}
+
+	_, err = c.pullRequests.CreatePullRequest(admin, &pb.CreatePullRequestRequest{
+		PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1",
+	})
+	assertStatus(t, err, codes.AlreadyExists, dto.PrExists)
+
+	_, err = c.pullRequests.CreatePullRequest(admin, &pb.CreatePullRequestRequest{
+		PullRequestId: "pr-2", PullRequestName: "Orphan", AuthorId: "missing",
+	})
+	assertStatus(t, err, codes.NotFound, dto.NotFound)
+
+	_, err = c.pullRequests.CreatePullRequest(admin, &pb.CreatePullRequestRequest{PullRequestId: "pr-2"})
+	assertStatus(t, err, codes.InvalidArgument, dto.ValidationError)
+
+	reviewer := pr.GetAssignedReviewers()[0]
+	verdict, err := c.pullRequests.SubmitVerdict(admin, &pb.SubmitVerdictRequest{
+		PullRequestId: "pr-1",
+		UserId:        reviewer,
+		Verdict:       pb.ReviewVerdict_REVIEW_VERDICT_APPROVED,
+	})
+	mustNoError(t, err)
+	if verdict.GetVerdict() != pb.ReviewVerdict_REVIEW_VERDICT_APPROVED || verdict.GetCreatedAt() == nil {
+		t.Fatalf("verdict = %v, want APPROVED with created_at", verdict)
+	}
+
+	_, err = c.pullRequests.SubmitVerdict(admin, &pb.SubmitVerdictRequest{
+		PullRequestId: "pr-1",
+		UserId:        reviewer,
+	})
+	assertStatus(t, err, codes.InvalidArgument, dto.ValidationError)
+
+	_, err = c.pullRequests.SubmitVerdict(admin, &pb.SubmitVerdictRequest{
+		PullRequestId: "pr-1",
+		UserId:        "u1",
+		Verdict:       pb.ReviewVerdict_REVIEW_VERDICT_CHANGES_REQUESTED,
+	})
+	assertStatus(t, err, codes.FailedPrecondition, dto.NotAssigned)
+
+	reassigned, err := c.pullRequests.ReassignReviewer(admin, &pb.ReassignReviewerRequest{
+		PullRequestId: "pr-1", OldReviewerId: reviewer,
+	})
+	mustNoError(t, err)
+	if reassigned.GetReplacedBy() == "" || reassigned.GetReplacedBy() == reviewer {
+		t.Fatalf("replaced_by = %q, want another reviewer", reassigned.GetReplacedBy())
+	}
+
+	_, err = c.pullRequests.ReassignReviewer(admin, &pb.ReassignReviewerRequest{
+		PullRequestId: "pr-1", OldReviewerId: "u1",
+	})
+	assertStatus(t, err, codes.FailedPrecondition, dto.NotAssigned)
+
+	list, err := c.pullRequests.ListPullRequests(withToken("u1-token"), &pb.ListPullRequestsRequest{
+		Status: pb.PullRequestStatus_PULL_REQUEST_STATUS_OPEN,
+		Label:  "backend",
+	})
+	mustNoError(t, err)
+	if len(list.GetPullRequests()) != 1 {
+		t.Fatalf("listed %d PRs, want 1", len(list.GetPullRequests()))
+	}
+
+	_, err = c.pullRequests.ListPullRequests(admin, &pb.ListPullRequestsRequest{Limit: -1})
+	assertStatus(t, err, codes.InvalidArgument, dto.BadRequest)
+
+	merged, err := c.pullRequests.MergePullRequest(admin, &pb.MergePullRequestRequest{PullRequestId: "pr-1"})
+	mustNoError(t, err)
+	if merged.GetPr().GetStatus() != pb.PullRequestStatus_PULL_REQUEST_STATUS_MERGED || merged.GetPr().GetMergedAt() == nil {
+		t.Fatalf("merged PR = %v, want MERGED with merged_at", merged.GetPr())
+	}
+
+	_, err = c.pullRequests.ReassignReviewer(admin, &pb.ReassignReviewerRequest{
+		PullRequestId: "pr-1", OldReviewerId: reassigned.GetReplacedBy(),
+	})
+	assertStatus(t, err, codes.FailedPrecondition, dto.PrMerged)
+
+	_, err = c.pullRequests.MergePullRequest(admin, &pb.MergePullRequestRequest{PullRequestId: "missing"})
+	assertStatus(t, err, codes.NotFound, dto.NotFound)
+}
+
+func TestWatchAssignments(t *testing.T) {
+	c := newTestClient(t)
+	c.addBackend(t)
+
+	ctx, cancel := context.WithTimeout(withToken("u1-token"), 5*time.Second)
+	defer cancel()
+
+	stream, err := c.pullRequests.WatchAssignments(ctx, &pb.WatchAssignmentsRequest{PullRequestId: "pr-1"})
+	mustNoError(t, err)
+
+	// Подписка регистрируется на сервере асинхронно: пробные события
+	// публикуются, пока первое из них не дойдет до клиента
+	subscribed := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			c.events.Publish(&dto.AssignmentEvent{Type: dto.AssignmentUnassigned, PullRequestID: "pr-1", ReviewerID: "probe"})
+			select {
+			case <-subscribed:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	_, err = stream.Recv()
+	close(subscribed)
+	mustNoError(t, err)
+
+	// Событие другого PR не проходит фильтр подписки
+	c.events.Publish(&dto.AssignmentEvent{Type: dto.AssignmentAssigned, PullRequestID: "pr-2", ReviewerID: "u2"})
+
+	_, err = c.pullRequests.CreatePullRequest(withToken("admin-token"), &pb.CreatePullRequestRequest{
+		PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1",
+	})
+	mustNoError(t, err)
+
+	for assigned := 0; assigned < 2; {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			t.Fatal("stream closed before assignment events")
+		}
+		mustNoError(t, err)
+		if event.GetReviewerId() == "probe" {
+			continue
+		}
+		if event.GetType() != pb.AssignmentEventType_ASSIGNMENT_EVENT_TYPE_ASSIGNED || event.GetPullRequestId() != "pr-1" {
+			t.Fatalf("event = %v, want ASSIGNED for pr-1", event)
+		}
+		assigned++
+	}
+}
+
+func TestStatsService(t *testing.T) {
+	c := newTestClient(t)
+	c.addBackend(t)
+
+	_, err := c.pullRequests.CreatePullRequest(withToken("admin-token"), &pb.CreatePullRequestRequest{
+		PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1",
+	})
+	mustNoError(t, err)
+
+	stats, err := c.stats.GetStats(withToken(c.statsKey), &pb.GetStatsRequest{
+		TeamName:    "backend",
+		Granularity: pb.StatsGranularity_STATS_GRANULARITY_WEEK,
+	})
+	mustNoError(t, err)
+	if stats.GetTotalUsersCount() != 4 || stats.GetOpenedPullRequestsCount() != 1 {
+		t.Fatalf("stats = %v, want 4 users and 1 opened PR", stats)
+	}
+	if stats.GetReviewAnalytics().GetGranularity() != pb.StatsGranularity_STATS_GRANULARITY_WEEK {
+		t.Fatalf("granularity = %s, want WEEK", stats.GetReviewAnalytics().GetGranularity())
+	}
+
+	_, err = c.stats.GetStats(withToken("u1-token"), &pb.GetStatsRequest{TeamName: "missing"})
+	assertStatus(t, err, codes.NotFound, dto.NotFound)
+
+	now := time.Now()
+	_, err = c.stats.GetStats(withToken("u1-token"), &pb.GetStatsRequest{
+		From: timestampToProto(&now),
+		To:   timestampToProto(&now),
+	})
+	assertStatus(t, err, codes.InvalidArgument, dto.BadRequest)
+
+	fairness, err := c.stats.GetFairness(withToken("u1-token"), &pb.GetFairnessRequest{TeamName: "backend"})
+	mustNoError(t, err)
+	if len(fairness.GetTeams()) != 1 || fairness.GetTeams()[0].GetTotalAssignments() != 2 {
+		t.Fatalf("fairness = %v, want backend with 2 assignments", fairness)
+	}
+
+	_, err = c.stats.GetFairness(withToken("u1-token"), &pb.GetFairnessRequest{TeamName: "missing"})
+	assertStatus(t, err, codes.NotFound, dto.NotFound)
+}
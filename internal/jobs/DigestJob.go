@@ -0,0 +1,78 @@
+// Package jobs - пакет с фоновыми задачами, выполняемыми по расписанию
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	digestRepos "github.com/salex06/pr-service/internal/repos/digest"
+	"github.com/salex06/pr-service/internal/service"
+)
+
+// digestCheckInterval - максимальный интервал, с которым задача проверяет,
+// разослан ли дайджест за текущий период
+const digestCheckInterval = time.Hour
+
+// DigestJob представляет фоновую задачу,
+// рассылающую дайджест ожидающих ревью PR's один раз за период.
+// Периоды отсчитываются по UTC (сутки - с полуночи, неделя - с понедельника);
+// разосланные периоды сохраняются в БД, поэтому при нескольких репликах
+// и после перезапуска дайджест за период отправляется один раз
+type DigestJob struct {
+	digestService *service.DigestService
+	runRepo       *digestRepos.DigestRunRepository
+	period        time.Duration
+}
+
+// NewDigestJob конструирует и возвращает объект DigestJob
+func NewDigestJob(ds *service.DigestService, rr *digestRepos.DigestRunRepository, period time.Duration) *DigestJob {
+	return &DigestJob{
+		digestService: ds,
+		runRepo:       rr,
+		period:        period,
+	}
+}
+
+// Run периодически проверяет, разослан ли дайджест за текущий период,
+// и блокируется до отмены контекста. Начатая рассылка при отмене
+// контекста не прерывается: Run возвращается после ее завершения
+func (job *DigestJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(min(job.period, digestCheckInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			job.runOnce(context.WithoutCancel(ctx), now)
+		}
+	}
+}
+
+// runOnce рассылает дайджест за период, в который попадает now,
+// если он еще не разослан этой или другой репликой. При ошибке рассылки
+// отметка о периоде снимается, и следующая проверка повторит рассылку
+func (job *DigestJob) runOnce(ctx context.Context, now time.Time) {
+	periodStart := now.UTC().Truncate(job.period)
+	claimed, err := (*job.runRepo).ClaimRun(ctx, periodStart)
+	if err != nil {
+		slog.ErrorContext(ctx, "error occured when claiming digest run", "period_start", periodStart, "error", err)
+		return
+	}
+	if !claimed {
+		slog.DebugContext(ctx, "review digest already sent for period", "period_start", periodStart)
+		return
+	}
+
+	sent, err := job.digestService.SendDigests(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error occured when sending digests", "period_start", periodStart, "error", err)
+		if err := (*job.runRepo).ReleaseRun(ctx, periodStart); err != nil {
+			slog.ErrorContext(ctx, "error occured when releasing digest run", "period_start", periodStart, "error", err)
+		}
+		return
+	}
+	slog.InfoContext(ctx, "review digest sent", "period_start", periodStart, "recipients", sent)
+}
@@ -0,0 +1,147 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/salex06/pr-service/internal/digest"
+	"github.com/salex06/pr-service/internal/entity"
+	"github.com/salex06/pr-service/internal/mail"
+	auditRepos "github.com/salex06/pr-service/internal/repos/audit"
+	digestRepos "github.com/salex06/pr-service/internal/repos/digest"
+	prRepos "github.com/salex06/pr-service/internal/repos/pr"
+	revsRepos "github.com/salex06/pr-service/internal/repos/reviewers"
+	userRepos "github.com/salex06/pr-service/internal/repos/user"
+	"github.com/salex06/pr-service/internal/service"
+)
+
+type countingSender struct {
+	mu   sync.Mutex
+	sent int
+}
+
+func (s *countingSender) Send(ctx context.Context, msg *mail.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent++
+	return nil
+}
+
+// unavailableRecipients - хранилище сотрудников, не отдающее
+// получателей дайджеста, пока установлен флаг fail
+type unavailableRecipients struct {
+	userRepos.UserRepository
+	fail bool
+}
+
+func (repo *unavailableRecipients) GetDigestRecipients(ctx context.Context) ([]*entity.User, error) {
+	if repo.fail {
+		return nil, errors.New("connection refused")
+	}
+	return repo.UserRepository.GetDigestRecipients(ctx)
+}
+
+func newDigestService(t *testing.T, sender mail.Sender) *service.DigestService {
+	t.Helper()
+	return newDigestServiceWithUsers(t, sender, userRepos.NewInMemoryUserRepository())
+}
+
+func newDigestServiceWithUsers(t *testing.T, sender mail.Sender, userRepo userRepos.UserRepository) *service.DigestService {
+	t.Helper()
+	ctx := context.Background()
+
+	var prRepo prRepos.PullRequestRepository = prRepos.NewInMemoryPullRequestRepository()
+	var revsRepo revsRepos.AssignedRevsRepository = revsRepos.NewInMemoryAssignedRevsRepository(prRepo)
+	var auditRepo auditRepos.AuditRepository = auditRepos.NewInMemoryAuditRepository()
+
+	email := "reviewer@example.com"
+	createdAt := time.Now().Add(-time.Hour)
+	mustNoError(t, userRepo.SaveUser(ctx, &entity.User{UserID: "u1", Username: "reviewer", TeamName: "backend", IsActive: true, Email: &email}))
+	mustNoError(t, userRepo.SaveUser(ctx, &entity.User{UserID: "u2", Username: "author", TeamName: "backend", IsActive: true}))
+	mustNoError(t, prRepo.SavePullRequest(ctx, &entity.PullRequest{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u2", Status: entity.OPEN, CreatedAt: &createdAt}))
+	mustNoError(t, revsRepo.CreateAssignment(ctx, "u1", "pr-1", entity.InitialAssignment))
+
+	renderer, err := digest.NewRenderer()
+	mustNoError(t, err)
+	userService := service.NewUserService(&userRepo, &revsRepo, &prRepo, service.NewAuditService(&auditRepo), nil)
+
+	return service.NewDigestService(userService, &userRepo, renderer, sender)
+}
+
+func mustNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDigestJobSendsOncePerPeriodAcrossReplicas(t *testing.T) {
+	sender := &countingSender{}
+	ds := newDigestService(t, sender)
+	var runRepo digestRepos.DigestRunRepository = digestRepos.NewInMemoryDigestRunRepository()
+	replicas := []*DigestJob{
+		NewDigestJob(ds, &runRepo, 24*time.Hour),
+		NewDigestJob(ds, &runRepo, 24*time.Hour),
+	}
+
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	for _, at := range []time.Time{day.Add(time.Hour), day.Add(2 * time.Hour), day.Add(23 * time.Hour)} {
+		for _, job := range replicas {
+			job.runOnce(context.Background(), at)
+		}
+	}
+	if sender.sent != 1 {
+		t.Fatalf("sent %d digests within one period, want 1", sender.sent)
+	}
+
+	replicas[1].runOnce(context.Background(), day.Add(25*time.Hour))
+	replicas[0].runOnce(context.Background(), day.Add(26*time.Hour))
+	if sender.sent != 2 {
+		t.Fatalf("sent %d digests within two periods, want 2", sender.sent)
+	}
+}
+
+func TestDigestJobSkipsPeriodAfterRestart(t *testing.T) {
+	sender := &countingSender{}
+	ds := newDigestService(t, sender)
+	var runRepo digestRepos.DigestRunRepository = digestRepos.NewInMemoryDigestRunRepository()
+	week := 7 * 24 * time.Hour
+	monday := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	NewDigestJob(ds, &runRepo, week).runOnce(context.Background(), monday)
+	restarted := NewDigestJob(ds, &runRepo, week)
+	restarted.runOnce(context.Background(), monday.Add(3*24*time.Hour))
+	if sender.sent != 1 {
+		t.Fatalf("sent %d digests after restart within one week, want 1", sender.sent)
+	}
+
+	restarted.runOnce(context.Background(), monday.Add(week-time.Hour))
+	if sender.sent != 2 {
+		t.Fatalf("sent %d digests in the following week, want 2", sender.sent)
+	}
+}
+
+func TestDigestJobRetriesPeriodAfterSendFailure(t *testing.T) {
+	sender := &countingSender{}
+	users := &unavailableRecipients{UserRepository: userRepos.NewInMemoryUserRepository()}
+	ds := newDigestServiceWithUsers(t, sender, users)
+	var runRepo digestRepos.DigestRunRepository = digestRepos.NewInMemoryDigestRunRepository()
+	job := NewDigestJob(ds, &runRepo, 24*time.Hour)
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	users.fail = true
+	job.runOnce(context.Background(), day.Add(time.Hour))
+	if sender.sent != 0 {
+		t.Fatalf("sent %d digests while recipients are unavailable, want 0", sender.sent)
+	}
+
+	users.fail = false
+	job.runOnce(context.Background(), day.Add(2*time.Hour))
+	job.runOnce(context.Background(), day.Add(3*time.Hour))
+	if sender.sent != 1 {
+		t.Fatalf("sent %d digests after recovery within one period, want 1", sender.sent)
+	}
+}
@@ -0,0 +1,45 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	idempotencyRepos "github.com/salex06/pr-service/internal/repos/idempotency"
+)
+
+// IdempotencyCleanupJob представляет фоновую задачу, периодически
+// удаляющую ключи идемпотентности с истекшим сроком хранения
+type IdempotencyCleanupJob struct {
+	idempotencyRepo *idempotencyRepos.IdempotencyRepository
+	period          time.Duration
+}
+
+// NewIdempotencyCleanupJob конструирует и возвращает объект IdempotencyCleanupJob
+func NewIdempotencyCleanupJob(ir *idempotencyRepos.IdempotencyRepository, period time.Duration) *IdempotencyCleanupJob {
+	return &IdempotencyCleanupJob{
+		idempotencyRepo: ir,
+		period:          period,
+	}
+}
+
+// Run запускает удаление ключей с заданным периодом
+// и блокируется до отмены контекста
+func (job *IdempotencyCleanupJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(job.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := (*job.idempotencyRepo).DeleteExpiredKeys(ctx, time.Now())
+			if err != nil {
+				slog.ErrorContext(ctx, "error occured when deleting expired idempotency keys", "error", err)
+				continue
+			}
+			slog.DebugContext(ctx, "expired idempotency keys deleted", "count", deleted)
+		}
+	}
+}
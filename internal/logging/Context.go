@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+type attrsKey struct{}
+
+// With возвращает копию контекста, в которой к атрибутам записей лога
+// добавлены attrs (пары ключ-значение или slog.Attr, как в slog.Logger.With)
+func With(ctx context.Context, attrs ...any) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+
+	record := slog.Record{}
+	record.Add(attrs...)
+
+	merged := slices.Clone(attrsFromContext(ctx))
+	record.Attrs(func(attr slog.Attr) bool {
+		merged = append(merged, attr)
+		return true
+	})
+
+	return context.WithValue(ctx, attrsKey{}, merged)
+}
+
+func attrsFromContext(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// ContextHandler представляет обработчик slog, который дополняет
+// каждую запись атрибутами, сохраненными в контексте функцией With
+type ContextHandler struct {
+	slog.Handler
+}
+
+// NewContextHandler конструирует и возвращает объект ContextHandler
+func NewContextHandler(handler slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: handler}
+}
+
+// Handle добавляет к записи атрибуты из контекста и передает ее обработчику
+func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if attrs := attrsFromContext(ctx); len(attrs) > 0 {
+		record = record.Clone()
+		record.AddAttrs(attrs...)
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs возвращает ContextHandler с дополнительными атрибутами
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup возвращает ContextHandler с группой атрибутов name
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name)}
+}
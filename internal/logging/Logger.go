@@ -0,0 +1,41 @@
+// Package logging - пакет, отвечающий за настройку структурированного
+// логгера (slog) и передачу атрибутов записей через контекст
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/salex06/pr-service/internal/config"
+)
+
+// Допустимые форматы вывода логов
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// New конструирует логгер на основе конфигурации: записи выводятся в w
+// в формате JSON или text и дополняются атрибутами из контекста
+func New(cfg *config.LogConfig, w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(cfg.Level)}
+
+	var handler slog.Handler
+	if strings.EqualFold(cfg.Format, FormatText) {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+
+	return slog.New(NewContextHandler(handler))
+}
+
+func parseLevel(level string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo
+	}
+
+	return l
+}
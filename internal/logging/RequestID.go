@@ -0,0 +1,19 @@
+package logging
+
+import "context"
+
+type requestIDKey struct{}
+
+// WithRequestID возвращает копию контекста, содержащую идентификатор
+// запроса, который добавляется к каждой записи лога с этим контекстом
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+	return With(ctx, "request_id", requestID)
+}
+
+// RequestIDFromContext возвращает идентификатор запроса из контекста
+// (пустая строка - если контекст не относится к запросу)
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,22 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// LogSender представляет собой компонент, который вместо отправки
+// писем выводит их в лог (используется, если SMTP-сервер не настроен)
+type LogSender struct{}
+
+// NewLogSender конструирует и возвращает объект LogSender
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+// Send выводит получателей и тему письма в лог
+func (s *LogSender) Send(ctx context.Context, msg *Message) error {
+	slog.InfoContext(ctx, "mail sent to log", "to", strings.Join(msg.To, ", "), "subject", msg.Subject)
+	return nil
+}
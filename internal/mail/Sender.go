@@ -0,0 +1,18 @@
+// Package mail - пакет с компонентами, отвечающими за отправку электронных писем
+package mail
+
+import "context"
+
+// Message представляет электронное письмо с получателями,
+// темой и телом письма в текстовом и HTML-представлении
+type Message struct {
+	To       []string
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
+
+// Sender представляет собой интерфейс отправки электронных писем
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	prRepos "github.com/salex06/pr-service/internal/repos/pr"
+	userRepos "github.com/salex06/pr-service/internal/repos/user"
+)
+
+// collectTimeout - ограничение времени на запросы к хранилищу при сборе метрик
+const collectTimeout = 5 * time.Second
+
+// DomainCollector представляет коллектор, который при каждом
+// сборе метрик запрашивает из хранилища текущее число
+// открытых PR's и активных сотрудников
+type DomainCollector struct {
+	prRepo   *prRepos.PullRequestRepository
+	userRepo *userRepos.UserRepository
+
+	openPullRequests *prometheus.Desc
+	activeUsers      *prometheus.Desc
+}
+
+// NewDomainCollector конструирует и возвращает объект DomainCollector
+func NewDomainCollector(prRepo *prRepos.PullRequestRepository, userRepo *userRepos.UserRepository) *DomainCollector {
+	return &DomainCollector{
+		prRepo:   prRepo,
+		userRepo: userRepo,
+		openPullRequests: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "open_pull_requests"),
+			"Current number of pull requests in OPEN status.", nil, nil,
+		),
+		activeUsers: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "active_users"),
+			"Current number of active users.", nil, nil,
+		),
+	}
+}
+
+// Describe передает описания доменных метрик
+func (dc *DomainCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- dc.openPullRequests
+	ch <- dc.activeUsers
+}
+
+// Collect запрашивает текущие значения доменных метрик
+// (метрика пропускается, если запрос к хранилищу завершился ошибкой)
+func (dc *DomainCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	if count, err := (*dc.prRepo).GetOpenedPullRequestCount(ctx); err == nil {
+		ch <- prometheus.MustNewConstMetric(dc.openPullRequests, prometheus.GaugeValue, float64(count))
+	} else {
+		slog.WarnContext(ctx, "unable to collect open pull requests metric", "error", err)
+	}
+
+	if count, err := (*dc.userRepo).GetActiveUserCount(ctx); err == nil {
+		ch <- prometheus.MustNewConstMetric(dc.activeUsers, prometheus.GaugeValue, float64(count))
+	} else {
+		slog.WarnContext(ctx, "unable to collect active users metric", "error", err)
+	}
+}
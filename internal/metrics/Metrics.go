@@ -0,0 +1,138 @@
+// Package metrics - пакет с метриками приложения
+// в формате Prometheus и их коллекторами
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_service"
+
+// Registry - реестр метрик приложения, отдаваемых на /metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequestsTotal - число обработанных HTTP-запросов
+	// по методу, шаблону маршрута и статусу ответа
+	HTTPRequestsTotal = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Total number of HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration - время обработки HTTP-запросов
+	// по методу, шаблону маршрута и статусу ответа
+	HTTPRequestDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// GRPCRequestsTotal - число обработанных gRPC-вызовов
+	// по полному имени метода и коду статуса
+	GRPCRequestsTotal = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "Total number of gRPC calls by method and status code.",
+	}, []string{"method", "code"})
+
+	// GRPCRequestDuration - время обработки gRPC-вызовов
+	// (для потоковых вызовов - время жизни потока)
+	GRPCRequestDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "gRPC call latency by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	// AssignmentsCreatedTotal - число назначений ревьюеров на PR's по причине назначения
+	AssignmentsCreatedTotal = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "assignments_created_total",
+		Help:      "Total number of reviewer assignments by reason.",
+	}, []string{"reason"})
+
+	// ReassignmentsTotal - число успешных переназначений ревьюеров
+	ReassignmentsTotal = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reassignments_total",
+		Help:      "Total number of successful reviewer reassignments.",
+	})
+
+	// NoCandidateTotal - число переназначений, завершившихся
+	// ошибкой NO_CANDIDATE (нет активного кандидата в команде)
+	NoCandidateTotal = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "no_candidate_total",
+		Help:      "Total number of reassignments rejected with NO_CANDIDATE.",
+	})
+
+	// AssignmentEventsDroppedTotal - число событий назначения, не доставленных
+	// подписчику из-за переполнения его очереди
+	AssignmentEventsDroppedTotal = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "assignment_events_dropped_total",
+		Help:      "Total number of assignment events dropped for slow subscribers.",
+	})
+
+	// AuditRecordFailuresTotal - число изменений, запись о которых не удалось
+	// сохранить в журнале аудита, по действию (ненулевой прирост - повод для оповещения)
+	AuditRecordFailuresTotal = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "audit_record_failures_total",
+		Help:      "Total number of changes whose audit record could not be written by action.",
+	}, []string{"action"})
+
+	// IdempotentReplaysTotal - число повторных запросов с ключом идемпотентности,
+	// на которые возвращен сохраненный ответ
+	IdempotentReplaysTotal = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "idempotent_replays_total",
+		Help:      "Total number of requests answered with a stored idempotent response.",
+	})
+
+	// RateLimitedRequestsTotal - число запросов, отклоненных из-за превышения
+	// бюджета запросов клиента, по классу маршрута (read/write)
+	RateLimitedRequestsTotal = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "Total number of requests rejected by rate limiting by route class.",
+	}, []string{"class"})
+
+	// CacheRequestsTotal - число обращений к кешам чтения
+	// по имени кеша и результату (hit/miss)
+	CacheRequestsTotal = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Total number of read cache lookups by cache and result.",
+	}, []string{"cache", "result"})
+)
+
+// ObserveCacheRequest учитывает обращение к кешу name
+// (hit - значение найдено в кеше)
+func ObserveCacheRequest(name string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	CacheRequestsTotal.WithLabelValues(name, result).Inc()
+}
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler возвращает HTTP-обработчик, отдающий метрики
+// реестра Registry в текстовом формате Prometheus
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
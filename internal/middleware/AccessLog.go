@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessLog возвращает обработчик, который после обработки запроса
+// записывает в лог статус ответа, время обработки и адрес клиента
+// (идентификатор запроса и маршрут берутся из контекста)
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		level := slog.LevelInfo
+		if c.Writer.Status() >= 500 {
+			level = slog.LevelError
+		}
+
+		slog.Log(c.Request.Context(), level, "request completed",
+			"status", c.Writer.Status(),
+			"latency_ms", time.Since(start).Milliseconds(),
+			"client_ip", c.ClientIP(),
+			"path", c.Request.URL.Path,
+		)
+	}
+}
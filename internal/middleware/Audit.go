@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/audit"
+	"github.com/salex06/pr-service/internal/auth"
+	"github.com/salex06/pr-service/internal/logging"
+)
+
+// AuditMeta возвращает обработчик, который сохраняет в контексте запроса
+// сведения об инициаторе изменения для журнала аудита: аутентифицированного
+// клиента и идентификатор запроса (см. RequestID)
+func AuditMeta() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		meta := audit.Meta{
+			Actor:     audit.SystemActor,
+			RequestID: logging.RequestIDFromContext(c.Request.Context()),
+		}
+
+		if principal := auth.GetPrincipal(c); principal != nil {
+			meta.Actor = principal.Subject
+			if principal.IsAPIKey() {
+				meta.Actor = "apikey:" + principal.APIKeyID
+			}
+		}
+
+		if meta.RequestID == "" {
+			meta.RequestID = newRequestID()
+		}
+
+		ctx := audit.WithMeta(c.Request.Context(), meta)
+		c.Request = c.Request.WithContext(logging.With(ctx, "actor", meta.Actor))
+		c.Next()
+	}
+}
@@ -0,0 +1,149 @@
+// Package middleware - пакет с промежуточными обработчиками запросов
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/auth"
+	"github.com/salex06/pr-service/internal/dto"
+)
+
+const scopeGrantedKey = "auth.scopeGranted"
+
+// anonymousPrincipal - клиент, от имени которого выполняются
+// запросы при отключенной аутентификации
+var anonymousPrincipal = &auth.Principal{Subject: "anonymous", Role: auth.RoleAdmin}
+
+// Authenticate возвращает обработчик, который аутентифицирует клиента
+// по заголовку "Authorization: Bearer <token>" (или "X-API-Key: <key>")
+// и сохраняет его в контексте запроса.
+// При невалидном или отсутствующем токене запрос отклоняется с кодом 401
+func Authenticate(authenticator auth.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			token = c.GetHeader("X-API-Key")
+			ok = token != ""
+		}
+		if !ok {
+			abortUnauthorized(c, "missing bearer token")
+			return
+		}
+
+		principal, err := authenticator.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			if errors.Is(err, auth.ErrInvalidCredentials) {
+				abortUnauthorized(c, "invalid token")
+				return
+			}
+
+			c.AbortWithStatusJSON(http.StatusInternalServerError, &dto.ErrorResponse{
+				Status: http.StatusInternalServerError,
+				Error: map[string]string{
+					"code":    string(dto.InternalError),
+					"message": "unable to authenticate request",
+				},
+			})
+			return
+		}
+
+		auth.SetPrincipal(c, principal)
+		c.Next()
+	}
+}
+
+// Anonymous возвращает обработчик, который считает любой запрос
+// запросом администратора (используется при отключенной аутентификации)
+func Anonymous() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth.SetPrincipal(c, anonymousPrincipal)
+		c.Next()
+	}
+}
+
+// RequireScope возвращает обработчик, который пропускает запрос дальше,
+// только если клиенту разрешено действие scope (для ключей доступа), иначе - код 403
+func RequireScope(scope auth.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := auth.GetPrincipal(c)
+		if principal == nil {
+			abortUnauthorized(c, "request is not authenticated")
+			return
+		}
+
+		if !principal.HasScope(scope) {
+			abortForbidden(c, fmt.Sprintf("api key has no %s scope", scope))
+			return
+		}
+
+		c.Set(scopeGrantedKey, true)
+		c.Next()
+	}
+}
+
+// Authorize возвращает обработчик, который пропускает запрос дальше,
+// только если аутентифицированный клиент удовлетворяет правилу доступа.
+// Администраторам доступ разрешен всегда, ключам доступа - если эндпоинт
+// защищен RequireScope и у ключа есть нужное разрешение, в остальных случаях - код 403
+func Authorize(rule auth.Rule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := auth.GetPrincipal(c)
+		if principal == nil {
+			abortUnauthorized(c, "request is not authenticated")
+			return
+		}
+
+		if principal.IsAPIKey() {
+			if !c.GetBool(scopeGrantedKey) {
+				abortForbidden(c, "access denied")
+				return
+			}
+
+			c.Next()
+			return
+		}
+
+		if !principal.HasRole(auth.RoleAdmin) && !rule(c, principal) {
+			abortForbidden(c, "access denied")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func abortUnauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="pr-service"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, &dto.ErrorResponse{
+		Status: http.StatusUnauthorized,
+		Error: map[string]string{
+			"code":    string(dto.Unauthorized),
+			"message": message,
+		},
+	})
+}
+
+func abortForbidden(c *gin.Context, message string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, &dto.ErrorResponse{
+		Status: http.StatusForbidden,
+		Error: map[string]string{
+			"code":    string(dto.Forbidden),
+			"message": message,
+		},
+	})
+}
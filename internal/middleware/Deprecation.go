@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecated возвращает обработчик устаревшего маршрута: в ответ добавляются
+// заголовок Deprecation (RFC 9745) с датой, с которой маршрут считается
+// устаревшим, и ссылка Link на маршрут-преемник successor
+func Deprecated(since time.Time, successor string) gin.HandlerFunc {
+	deprecation := fmt.Sprintf("@%d", since.Unix())
+	link := fmt.Sprintf(`<%s>; rel="successor-version"`, successor)
+
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+		c.Header("Link", link)
+		c.Next()
+	}
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/service"
+)
+
+// conflictStatuses переопределяет HTTP-статус для отдельных кодов
+// конфликта, сохраняя прежний контракт API (по умолчанию - 409)
+var conflictStatuses = map[dto.ErrorCode]int{
+	dto.TeamExists:         http.StatusBadRequest,
+	dto.PreconditionFailed: http.StatusPreconditionFailed,
+}
+
+// Errors возвращает обработчик, который после обработки запроса
+// преобразует последнюю ошибку, добавленную обработчиком через c.Error,
+// в ответ с соответствующим HTTP-статусом и кодом ошибки
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		resp := errorResponse(c.Request.Context(), c.Errors.Last().Err)
+		c.JSON(resp.Status, resp)
+	}
+}
+
+func errorResponse(ctx context.Context, err error) *dto.ErrorResponse {
+	var (
+		notFoundErr        *service.NotFoundError
+		conflictErr        *service.ConflictError
+		invalidArgumentErr *service.InvalidArgumentError
+		internalErr        *service.InternalError
+	)
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		return newErrorResponse(http.StatusNotFound, dto.NotFound, notFoundErr.Message)
+	case errors.As(err, &conflictErr):
+		status, ok := conflictStatuses[conflictErr.Code]
+		if !ok {
+			status = http.StatusConflict
+		}
+		return newErrorResponse(status, conflictErr.Code, conflictErr.Message)
+	case errors.As(err, &invalidArgumentErr):
+		return newErrorResponse(http.StatusBadRequest, dto.BadRequest, invalidArgumentErr.Message)
+	case ctx.Err() != nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled):
+		return newErrorResponse(http.StatusGatewayTimeout, dto.Timeout, "request deadline exceeded")
+	}
+
+	slog.ErrorContext(ctx, "request failed", "error", err)
+
+	// текст исходной ошибки (например, ошибки БД) клиенту не передается
+	message := "internal server error"
+	if errors.As(err, &internalErr) {
+		message = internalErr.Message
+	}
+
+	return newErrorResponse(http.StatusInternalServerError, dto.InternalError, message)
+}
+
+func newErrorResponse(status int, code dto.ErrorCode, message string) *dto.ErrorResponse {
+	return &dto.ErrorResponse{
+		Status: status,
+		Error: map[string]string{
+			"code":    string(code),
+			"message": message,
+		},
+	}
+}
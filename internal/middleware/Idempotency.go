@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/auth"
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+	"github.com/salex06/pr-service/internal/metrics"
+	idempotencyRepos "github.com/salex06/pr-service/internal/repos/idempotency"
+	"github.com/salex06/pr-service/internal/service"
+)
+
+// Заголовки ключа идемпотентности запроса и признака повторного ответа
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// maxIdempotencyKeyLength - максимальная длина ключа идемпотентности
+const maxIdempotencyKeyLength = 255
+
+// replayedHeaders - заголовки ответа, которые сохраняются вместе с ним
+// и воспроизводятся при повторе. Их устанавливают обработчики маршрута
+// (например, Deprecated), которые при повторе не выполняются
+var replayedHeaders = []string{"Deprecation", "Link", "ETag"}
+
+// Idempotency возвращает обработчик, который делает POST-запросы с заголовком
+// Idempotency-Key безопасными для повторов: успешный ответ на запрос сохраняется
+// в repo на время ttl, и повторный запрос того же клиента на тот же маршрут
+// с тем же ключом и тем же телом получает сохраненный ответ без повторного выполнения.
+// Повтор ключа с другим запросом отклоняется с кодом 422, запрос с ключом,
+// который еще обрабатывается, - с кодом 409. Ключ резервируется обрабатывающим
+// запросом на время lease: если ответ за это время не сохранен (экземпляр
+// сервиса остановился), ключ можно использовать снова. Неуспешные ответы
+// не сохраняются, поэтому такой запрос можно повторить с тем же ключом.
+//
+// Обработчик выполняется до проверки прав маршрута (RequireScope, Authorize),
+// поэтому сохраненный ответ воспроизводится без повторной проверки. Чтобы он не
+// достался другому клиенту, ключи разделяются по клиентам (с их ролью и командой)
+// и маршрутам; обработчик должен следовать за Authenticate
+func Idempotency(repo *idempotencyRepos.IdempotencyRepository, ttl, lease time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idempotencyKey := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || idempotencyKey == "" {
+			c.Next()
+			return
+		}
+
+		if len(idempotencyKey) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, newErrorResponse(http.StatusBadRequest, dto.BadRequest,
+				fmt.Sprintf("idempotency key must be at most %d characters long", maxIdempotencyKeyLength)))
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, newErrorResponse(http.StatusBadRequest, dto.BadRequest, "unable to read request body"))
+			return
+		}
+		c.Set(gin.BodyBytesKey, body)
+
+		ctx := c.Request.Context()
+		// время резервирования сравнивается с сохраненным в БД,
+		// где оно хранится с точностью до микросекунд
+		now := time.Now().Truncate(time.Microsecond)
+		record := &entity.IdempotencyRecord{
+			Key:         idempotencyScope(c) + ":" + idempotencyKey,
+			RequestHash: requestHash(c.Request, body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(ttl),
+			LockedUntil: now.Add(lease),
+		}
+
+		existing, err := (*repo).ReserveKey(ctx, record)
+		if err != nil {
+			_ = c.Error(&service.InternalError{Message: "unable to check idempotency key", Err: err})
+			c.Abort()
+			return
+		}
+
+		if existing != nil {
+			replay(c, existing, record.RequestHash)
+			return
+		}
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+
+			// ключ освобождается и при панике обработчика, чтобы запрос можно было повторить
+			if err := (*repo).DeleteKey(context.WithoutCancel(ctx), record); err != nil {
+				slog.ErrorContext(ctx, "unable to release idempotency key", "error", err)
+			}
+		}()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if !c.Writer.Written() || status < http.StatusOK || status >= http.StatusMultipleChoices {
+			return
+		}
+
+		record.StatusCode = status
+		record.ContentType = c.Writer.Header().Get("Content-Type")
+		record.ResponseHeaders = responseHeaders(c.Writer.Header())
+		record.ResponseBody = writer.body.Bytes()
+		if err := (*repo).CompleteKey(context.WithoutCancel(ctx), record); err != nil {
+			slog.ErrorContext(ctx, "unable to save idempotent response", "error", err)
+			return
+		}
+		completed = true
+	}
+}
+
+// replay отвечает на повторный запрос сохраненным ответом
+// или ошибкой, если ключ использован для другого запроса
+// либо исходный запрос еще обрабатывается
+func replay(c *gin.Context, record *entity.IdempotencyRecord, requestHash string) {
+	if record.RequestHash != requestHash {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, newErrorResponse(http.StatusUnprocessableEntity,
+			dto.IdempotencyKeyReused, "idempotency key is already used for another request"))
+		return
+	}
+
+	if !record.IsCompleted() {
+		c.AbortWithStatusJSON(http.StatusConflict, newErrorResponse(http.StatusConflict,
+			dto.IdempotencyKeyInUse, "request with this idempotency key is being processed"))
+		return
+	}
+
+	metrics.IdempotentReplaysTotal.Inc()
+	for name, value := range record.ResponseHeaders {
+		c.Header(name, value)
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(record.StatusCode, record.ContentType, record.ResponseBody)
+	c.Abort()
+}
+
+// responseHeaders возвращает значения заголовков replayedHeaders из header
+func responseHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(replayedHeaders))
+	for _, name := range replayedHeaders {
+		if value := header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	return headers
+}
+
+// idempotencyScope возвращает область действия ключа идемпотентности:
+// клиента (ключ доступа или субъект с ролью и командой) и маршрут запроса
+func idempotencyScope(c *gin.Context) string {
+	client := "anonymous"
+	if principal := auth.GetPrincipal(c); principal != nil {
+		client = principal.Subject + "/" + string(principal.Role) + "/" + principal.TeamName
+		if principal.IsAPIKey() {
+			client = "apikey:" + principal.APIKeyID
+		}
+	}
+
+	return client + " " + c.Request.Method + " " + c.FullPath()
+}
+
+// requestHash вычисляет хеш запроса: метода, пути со строкой запроса и тела
+func requestHash(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, r.Method+"\n"+r.URL.RequestURI()+"\n")
+	_, _ = hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// recordingWriter сохраняет копию тела ответа
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
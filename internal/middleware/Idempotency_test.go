@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/auth"
+	idempotencyRepos "github.com/salex06/pr-service/internal/repos/idempotency"
+)
+
+// idempotencyServer - роутер с маршрутами /a, /b и устаревшим маршрутом /legacy
+// за обработчиком Idempotency;
+// клиент запроса задается заголовком X-Subject, каждое выполнение
+// маршрута возвращает его порядковый номер
+type idempotencyServer struct {
+	router *gin.Engine
+	calls  atomic.Int32
+	// hold, если задан, задерживает ответ первого выполнения маршрута
+	hold func()
+}
+
+func newIdempotencyServer(lease time.Duration) *idempotencyServer {
+	gin.SetMode(gin.TestMode)
+
+	var repo idempotencyRepos.IdempotencyRepository = idempotencyRepos.NewInMemoryIdempotencyRepository()
+	srv := &idempotencyServer{router: gin.New()}
+
+	authenticate := func(c *gin.Context) {
+		auth.SetPrincipal(c, &auth.Principal{Subject: c.GetHeader("X-Subject"), Role: auth.RoleMember})
+	}
+	handler := func(c *gin.Context) {
+		call := srv.calls.Add(1)
+		if call == 1 && srv.hold != nil {
+			srv.hold()
+		}
+		c.JSON(http.StatusCreated, gin.H{"call": call})
+	}
+
+	group := srv.router.Group("/", authenticate, Idempotency(&repo, time.Hour, lease))
+	group.POST("/a", handler)
+	group.POST("/b", handler)
+	group.POST("/legacy", Deprecated(time.Unix(1700000000, 0), "/a"), handler)
+
+	return srv
+}
+
+func (srv *idempotencyServer) post(path, subject, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("X-Subject", subject)
+	req.Header.Set(IdempotencyKeyHeader, key)
+
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// assertCall проверяет, что ответ получен от выполнения маршрута с номером call
+func assertCall(t *testing.T, rec *httptest.ResponseRecorder, call int) {
+	t.Helper()
+
+	var resp struct {
+		Call int `json:"call"`
+	}
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201, body: %s", rec.Code, rec.Body.String())
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response %q: %v", rec.Body.String(), err)
+	}
+	if resp.Call != call {
+		t.Fatalf("response of call %d, want %d", resp.Call, call)
+	}
+}
+
+func assertErrorCode(t *testing.T, rec *httptest.ResponseRecorder, wantStatus int, wantCode string) {
+	t.Helper()
+
+	var resp struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if rec.Code != wantStatus {
+		t.Fatalf("status = %d, want %d, body: %s", rec.Code, wantStatus, rec.Body.String())
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal error response %q: %v", rec.Body.String(), err)
+	}
+	if resp.Error.Code != wantCode {
+		t.Fatalf("error code = %s, want %s", resp.Error.Code, wantCode)
+	}
+}
+
+func TestIdempotencyReplaysResponse(t *testing.T) {
+	srv := newIdempotencyServer(time.Minute)
+
+	assertCall(t, srv.post("/a", "u1", "key-1", `{"n":1}`), 1)
+
+	replayed := srv.post("/a", "u1", "key-1", `{"n":1}`)
+	assertCall(t, replayed, 1)
+	if replayed.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Errorf("replayed response has no %s header", IdempotentReplayedHeader)
+	}
+
+	assertErrorCode(t, srv.post("/a", "u1", "key-1", `{"n":2}`), http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED")
+
+	// Ключ действует в пределах клиента и маршрута
+	assertCall(t, srv.post("/a", "u2", "key-1", `{"n":1}`), 2)
+	assertCall(t, srv.post("/b", "u1", "key-1", `{"n":1}`), 3)
+
+	if calls := srv.calls.Load(); calls != 3 {
+		t.Fatalf("route executed %d times, want 3", calls)
+	}
+}
+
+func TestIdempotencyReplaysRouteHeaders(t *testing.T) {
+	srv := newIdempotencyServer(time.Minute)
+
+	first := srv.post("/legacy", "u1", "key-1", `{"n":1}`)
+	assertCall(t, first, 1)
+
+	replayed := srv.post("/legacy", "u1", "key-1", `{"n":1}`)
+	assertCall(t, replayed, 1)
+	for _, name := range []string{"Deprecation", "Link"} {
+		if got, want := replayed.Header().Get(name), first.Header().Get(name); want == "" || got != want {
+			t.Errorf("replayed %s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestIdempotencyRejectsKeyInUse(t *testing.T) {
+	srv := newIdempotencyServer(time.Minute)
+
+	entered, release := make(chan struct{}), make(chan struct{})
+	srv.hold = func() {
+		close(entered)
+		<-release
+	}
+
+	first := make(chan *httptest.ResponseRecorder)
+	go func() { first <- srv.post("/a", "u1", "key-1", `{}`) }()
+	<-entered
+
+	assertErrorCode(t, srv.post("/a", "u1", "key-1", `{}`), http.StatusConflict, "IDEMPOTENCY_KEY_IN_USE")
+
+	close(release)
+	assertCall(t, <-first, 1)
+	assertCall(t, srv.post("/a", "u1", "key-1", `{}`), 1)
+}
+
+func TestIdempotencyReclaimsStaleReservation(t *testing.T) {
+	const lease = 20 * time.Millisecond
+	srv := newIdempotencyServer(lease)
+
+	entered, release := make(chan struct{}), make(chan struct{})
+	srv.hold = func() {
+		close(entered)
+		<-release
+	}
+
+	// Первый запрос не успевает ответить за время резервирования ключа
+	first := make(chan *httptest.ResponseRecorder)
+	go func() { first <- srv.post("/a", "u1", "key-1", `{}`) }()
+	<-entered
+	time.Sleep(2 * lease)
+
+	assertCall(t, srv.post("/a", "u1", "key-1", `{}`), 2)
+
+	// Запоздавший ответ первого запроса не заменяет сохраненный ответ второго
+	close(release)
+	assertCall(t, <-first, 1)
+	assertCall(t, srv.post("/a", "u1", "key-1", `{}`), 2)
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/metrics"
+)
+
+// unmatchedRoute - значение метки route для запросов,
+// не попавших ни в один зарегистрированный маршрут
+const unmatchedRoute = "unmatched"
+
+// Metrics возвращает обработчик, который учитывает число и время
+// обработки запросов по методу, шаблону маршрута и статусу ответа
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		metrics.HTTPRequestsTotal.WithLabelValues(c.Request.Method, route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, status).
+			Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/auth"
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+	"github.com/salex06/pr-service/internal/metrics"
+	rateLimitRepos "github.com/salex06/pr-service/internal/repos/ratelimit"
+)
+
+// Заголовки ответа с бюджетом запросов клиента
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+)
+
+// Классы маршрутов с отдельными бюджетами запросов
+// (ip - общий бюджет IP-адреса до аутентификации)
+const (
+	rateLimitClassRead  = "read"
+	rateLimitClassWrite = "write"
+	rateLimitClassIP    = "ip"
+)
+
+// RateLimit возвращает обработчик, который ограничивает частоту запросов
+// клиента по алгоритму корзины токенов. Запросы на чтение (GET, HEAD, OPTIONS)
+// расходуют бюджет read, остальные - бюджет write. Клиент определяется
+// по ключу доступа, затем по аутентифицированному пользователю, а при
+// отключенной аутентификации - по IP.
+// При исчерпании бюджета запрос отклоняется с кодом 429 и заголовком
+// Retry-After; при недоступности хранилища запрос пропускается.
+// Обработчик должен следовать за аутентификацией
+func RateLimit(repo *rateLimitRepos.RateLimitRepository, read, write entity.RateLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		class, limit := rateLimitClassWrite, write
+		if isReadMethod(c.Request.Method) {
+			class, limit = rateLimitClassRead, read
+		}
+
+		takeToken(c, repo, class, rateLimitClient(c), limit)
+	}
+}
+
+// IPRateLimit возвращает обработчик, который ограничивает общую частоту
+// запросов с одного IP-адреса. Он выполняется до аутентификации, чтобы
+// запросы с неверными токенами (подбор токенов, проверка ключей доступа в БД)
+// тоже расходовали бюджет. IP-адрес берется из соединения или, для доверенных
+// прокси (gin.Engine.SetTrustedProxies), из заголовка X-Forwarded-For
+func IPRateLimit(repo *rateLimitRepos.RateLimitRepository, limit entity.RateLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		takeToken(c, repo, rateLimitClassIP, "ip:"+c.ClientIP(), limit)
+	}
+}
+
+// takeToken списывает токен из бюджета class клиента client
+// и отклоняет запрос, если бюджет исчерпан
+func takeToken(c *gin.Context, repo *rateLimitRepos.RateLimitRepository, class, client string, limit entity.RateLimit) {
+	if !limit.IsEnabled() {
+		c.Next()
+		return
+	}
+
+	ctx := c.Request.Context()
+	decision, err := (*repo).TakeToken(ctx, class+":"+client, limit, time.Now())
+	if err != nil {
+		slog.WarnContext(ctx, "unable to check rate limit", "error", err)
+		c.Next()
+		return
+	}
+
+	c.Header(RateLimitLimitHeader, strconv.Itoa(limit.Burst))
+	c.Header(RateLimitRemainingHeader, strconv.Itoa(decision.Remaining))
+	if !decision.Allowed {
+		metrics.RateLimitedRequestsTotal.WithLabelValues(class).Inc()
+		c.Header("Retry-After", strconv.Itoa(retryAfterSeconds(decision.RetryAfter)))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests,
+			newErrorResponse(http.StatusTooManyRequests, dto.RateLimited, "rate limit exceeded, retry later"))
+		return
+	}
+
+	c.Next()
+}
+
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// rateLimitClient возвращает ключ клиента, по которому ведется бюджет запросов
+// (при отключенной аутентификации все запросы выполняются от имени
+// anonymousPrincipal, и клиенты различаются только по IP)
+func rateLimitClient(c *gin.Context) string {
+	principal := auth.GetPrincipal(c)
+	switch {
+	case principal != nil && principal.IsAPIKey():
+		return "key:" + principal.APIKeyID
+	case principal != nil && principal != anonymousPrincipal:
+		return "user:" + principal.Subject
+	default:
+		return "ip:" + c.ClientIP()
+	}
+}
+
+// retryAfterSeconds округляет время ожидания вверх до целых секунд
+// (значение заголовка Retry-After не меньше 1)
+func retryAfterSeconds(wait time.Duration) int {
+	return max(1, int(math.Ceil(wait.Seconds())))
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/auth"
+	"github.com/salex06/pr-service/internal/entity"
+	rateLimitRepos "github.com/salex06/pr-service/internal/repos/ratelimit"
+)
+
+// newIPRateLimitRouter собирает роутер, в котором IPRateLimit (бюджет - один
+// запрос) выполняется до аутентификации по статическим токенам
+func newIPRateLimitRouter(t *testing.T, trustedProxies []string) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	authenticator, err := auth.NewStaticTokenAuthenticator("u1-token=u1:member")
+	if err != nil {
+		t.Fatalf("NewStaticTokenAuthenticator: %v", err)
+	}
+
+	var repo rateLimitRepos.RateLimitRepository = rateLimitRepos.NewInMemoryRateLimitRepository()
+	router := gin.New()
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		t.Fatalf("SetTrustedProxies: %v", err)
+	}
+	router.GET("/ping",
+		IPRateLimit(&repo, entity.RateLimit{Rate: 0.001, Burst: 1}),
+		Authenticate(authenticator),
+		func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	return router
+}
+
+func getPing(router *gin.Engine, remoteAddr, token, forwardedFor string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.RemoteAddr = remoteAddr
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIPRateLimitRunsBeforeAuthentication(t *testing.T) {
+	router := newIPRateLimitRouter(t, nil)
+
+	// Запрос с неверным токеном расходует бюджет IP-адреса
+	if rec := getPing(router, "203.0.113.1:1234", "wrong-token", ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", rec.Code)
+	}
+	rec := getPing(router, "203.0.113.1:1234", "u1-token", "")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("429 response has no Retry-After header")
+	}
+
+	if rec := getPing(router, "203.0.113.2:1234", "u1-token", ""); rec.Code != http.StatusOK {
+		t.Fatalf("request from another IP: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestIPRateLimitTrustsForwardedForOnlyFromTrustedProxies(t *testing.T) {
+	// Без доверенных прокси подмена X-Forwarded-For не дает нового бюджета
+	router := newIPRateLimitRouter(t, nil)
+	getPing(router, "203.0.113.1:1234", "u1-token", "198.51.100.1")
+	if rec := getPing(router, "203.0.113.1:1234", "u1-token", "198.51.100.2"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("spoofed X-Forwarded-For: status = %d, want 429", rec.Code)
+	}
+
+	// За доверенным прокси клиенты различаются по X-Forwarded-For
+	router = newIPRateLimitRouter(t, []string{"10.0.0.0/8"})
+	getPing(router, "10.0.0.5:1234", "u1-token", "198.51.100.1")
+	if rec := getPing(router, "10.0.0.5:1234", "u1-token", "198.51.100.2"); rec.Code != http.StatusOK {
+		t.Fatalf("another client behind proxy: status = %d, want 200", rec.Code)
+	}
+	if rec := getPing(router, "10.0.0.5:1234", "u1-token", "198.51.100.1"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("same client behind proxy: status = %d, want 429", rec.Code)
+	}
+}
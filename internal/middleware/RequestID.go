@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/logging"
+	"github.com/salex06/pr-service/internal/tracing"
+)
+
+// RequestIDHeader - заголовок, в котором передается идентификатор запроса
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength - максимальная длина идентификатора запроса,
+// принимаемого от клиента (более длинный заменяется сгенерированным)
+const maxRequestIDLength = 128
+
+// RequestID возвращает обработчик, который принимает идентификатор запроса
+// из заголовка X-Request-ID (или генерирует новый), возвращает его в ответе
+// и сохраняет в контексте запроса вместе с методом, шаблоном маршрута
+// и идентификатором трассировки для записей лога
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = newRequestID()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		ctx := logging.WithRequestID(c.Request.Context(), requestID)
+		ctx = logging.With(ctx, "method", c.Request.Method, "route", c.FullPath())
+		if traceID := tracing.TraceID(ctx); traceID != "" {
+			ctx = logging.With(ctx, "trace_id", traceID)
+		}
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
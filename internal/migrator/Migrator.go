@@ -0,0 +1,256 @@
+// Package migrator - пакет, отвечающий за применение
+// и откат миграций схемы БД, встроенных в бинарный файл
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/salex06/pr-service/internal/database"
+)
+
+// migrationLockID - ключ advisory-блокировки, которая не позволяет
+// нескольким репликам сервиса применять миграции одновременно
+const migrationLockID = 7291002
+
+// Migrator представляет собой компонент, который применяет и откатывает
+// миграции схемы БД и хранит версии примененных миграций в таблице schema_migrations
+type Migrator struct {
+	db         *database.DB
+	migrations []*Migration
+}
+
+// NewMigrator конструирует объект Migrator с миграциями из файловой системы fsys
+func NewMigrator(db *database.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := loadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// LatestVersion возвращает версию последней известной миграции
+// (-1 - если миграций нет)
+func (m *Migrator) LatestVersion() int64 {
+	if len(m.migrations) == 0 {
+		return -1
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up применяет все еще не примененные миграции в порядке
+// возрастания версий и возвращает примененные миграции
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	applied := make([]*Migration, 0)
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.Up); err != nil {
+					return err
+				}
+
+				_, err := tx.Exec(ctx,
+					`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`,
+					migration.Version, migration.Name,
+				)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("unable to apply migration %d-%s: %w", migration.Version, migration.Name, err)
+			}
+
+			slog.InfoContext(ctx, "migration applied", "version", migration.Version, "name", migration.Name)
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down откатывает steps последних примененных миграций
+// и возвращает откаченные миграции
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	reverted := make([]*Migration, 0, steps)
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d-%s has no down script", migration.Version, migration.Name)
+			}
+
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.Down); err != nil {
+					return err
+				}
+
+				_, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("unable to revert migration %d-%s: %w", migration.Version, migration.Name, err)
+			}
+
+			slog.InfoContext(ctx, "migration reverted", "version", migration.Version, "name", migration.Name)
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status возвращает состояние всех известных миграций
+func (m *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	conn, err := m.db.Pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if err := createSchemaTable(ctx, conn); err != nil {
+		return nil, err
+	}
+
+	versions, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := &MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+		}
+		if appliedAt, ok := versions[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// CurrentVersion выполняет запрос к БД и возвращает версию
+// последней примененной миграции (-1 - если миграции не применялись)
+func (m *Migrator) CurrentVersion(ctx context.Context) (int64, error) {
+	var version int64
+	err := m.db.Pool.QueryRow(ctx, `SELECT COALESCE(MAX(version), -1) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	return version, nil
+}
+
+// CheckSchema проверяет, что к БД применены все известные миграции
+func (m *Migrator) CheckSchema(ctx context.Context) error {
+	version, err := m.CurrentVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if version < m.LatestVersion() {
+		return fmt.Errorf("schema version %d is behind %d", version, m.LatestVersion())
+	}
+
+	return nil
+}
+
+// withLock выполняет fn на выделенном соединении, удерживая advisory-блокировку
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) (err error) {
+	conn, err := m.db.Pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("unable to take migration lock: %w", err)
+	}
+	defer func() {
+		unlockCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if _, unlockErr := conn.Exec(unlockCtx, `SELECT pg_advisory_unlock($1)`, migrationLockID); unlockErr != nil {
+			err = errors.Join(err, fmt.Errorf("unable to release migration lock: %w", unlockErr))
+		}
+	}()
+
+	if err := createSchemaTable(ctx, conn); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func createSchemaTable(ctx context.Context, conn *pgxpool.Conn) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS schema_migrations(
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`
+
+	if _, err := conn.Exec(ctx, query); err != nil {
+		return fmt.Errorf("unable to create schema table: %w", err)
+	}
+
+	return nil
+}
+
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.Query(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read schema table: %w", err)
+	}
+	defer rows.Close()
+
+	versions := make(map[int64]time.Time)
+	for rows.Next() {
+		var (
+			version   int64
+			appliedAt time.Time
+		)
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("unable to read schema table: %w", err)
+		}
+		versions[version] = appliedAt
+	}
+
+	return versions, rows.Err()
+}
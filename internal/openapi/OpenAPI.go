@@ -0,0 +1,17 @@
+// Package openapi - пакет со встроенной в бинарный файл
+// спецификацией API сервиса (OpenAPI 3) и страницей Swagger UI
+package openapi
+
+import (
+	_ "embed"
+)
+
+// Spec - спецификация API сервиса в формате OpenAPI 3 (JSON)
+//
+//go:embed openapi.json
+var Spec []byte
+
+// SwaggerUI - HTML-страница Swagger UI, отображающая спецификацию Spec
+//
+//go:embed swagger.html
+var SwaggerUI []byte
@@ -0,0 +1,21 @@
+// Package apikey - пакет с репозиториями, отвечающими за взаимодействие с БД,
+// где хранится информация о ключах доступа
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// APIKeyRepository представляет интерфейс взаимодействия
+// с базой данных, где хранится информация о ключах доступа
+type APIKeyRepository interface {
+	GetAPIKey(ctx context.Context, keyID string) (*entity.APIKey, error)
+	GetAPIKeys(ctx context.Context) ([]*entity.APIKey, error)
+
+	SaveAPIKey(ctx context.Context, key *entity.APIKey) error
+	UpdateAPIKey(ctx context.Context, key *entity.APIKey) error
+	TouchAPIKey(ctx context.Context, keyID string, usedAt time.Time) error
+}
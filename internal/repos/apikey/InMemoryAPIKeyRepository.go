@@ -0,0 +1,74 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// InMemoryAPIKeyRepository представляет собой компонент,
+// отвечающий за взаимодействие с in-memory хранилищем (map),
+// где содержится информация о ключах доступа
+type InMemoryAPIKeyRepository struct {
+	mu      sync.RWMutex
+	storage map[string]*entity.APIKey
+}
+
+// NewInMemoryAPIKeyRepository конструирует и возвращает объект InMemoryAPIKeyRepository
+func NewInMemoryAPIKeyRepository() *InMemoryAPIKeyRepository {
+	return &InMemoryAPIKeyRepository{
+		storage: make(map[string]*entity.APIKey),
+	}
+}
+
+// GetAPIKey возвращает ключ с заданным идентификатором (nil - если не найден)
+func (repo *InMemoryAPIKeyRepository) GetAPIKey(ctx context.Context, keyID string) (*entity.APIKey, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return repo.storage[keyID], nil
+}
+
+// GetAPIKeys возвращает все ключи
+func (repo *InMemoryAPIKeyRepository) GetAPIKeys(ctx context.Context) ([]*entity.APIKey, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	keys := make([]*entity.APIKey, 0, len(repo.storage))
+	for _, v := range repo.storage {
+		keys = append(keys, v)
+	}
+	return keys, nil
+}
+
+// SaveAPIKey сохраняет ключ в хранилище
+func (repo *InMemoryAPIKeyRepository) SaveAPIKey(ctx context.Context, key *entity.APIKey) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.storage[key.KeyID] = key
+	return nil
+}
+
+// UpdateAPIKey обновляет изменяемую информацию о ключе
+// (для данной реализации идентично SaveAPIKey)
+func (repo *InMemoryAPIKeyRepository) UpdateAPIKey(ctx context.Context, key *entity.APIKey) error {
+	return repo.SaveAPIKey(ctx, key)
+}
+
+// TouchAPIKey обновляет время последнего использования ключа
+func (repo *InMemoryAPIKeyRepository) TouchAPIKey(ctx context.Context, keyID string, usedAt time.Time) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	key, ok := repo.storage[keyID]
+	if !ok {
+		return errors.New("api key not found")
+	}
+
+	key.LastUsedAt = &usedAt
+	return nil
+}
@@ -0,0 +1,161 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/salex06/pr-service/internal/database"
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// PostgresAPIKeyRepository представляет собой компонент,
+// отвечающий за взаимодействие с БД PostgreSQL, где
+// хранится информация о ключах доступа
+type PostgresAPIKeyRepository struct {
+	db *database.DB
+}
+
+// NewPostgresAPIKeyRepository конструирует и возвращает объект PostgresAPIKeyRepository
+func NewPostgresAPIKeyRepository(db *database.DB) APIKeyRepository {
+	return &PostgresAPIKeyRepository{db: db}
+}
+
+// GetAPIKey выполняет запрос к БД и возвращает
+// ключ с заданным идентификатором (nil - если не найден)
+func (repo *PostgresAPIKeyRepository) GetAPIKey(ctx context.Context, keyID string) (*entity.APIKey, error) {
+	query := `
+		SELECT key_id, name, key_hash, scopes, created_at, expires_at, last_used_at, revoked_at, rotated_from
+		FROM api_keys
+		WHERE key_id = $1
+	`
+
+	key, err := scanAPIKey(repo.db.Pool.QueryRow(ctx, query, keyID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// GetAPIKeys выполняет запрос к БД и возвращает все ключи
+func (repo *PostgresAPIKeyRepository) GetAPIKeys(ctx context.Context) ([]*entity.APIKey, error) {
+	query := `
+		SELECT key_id, name, key_hash, scopes, created_at, expires_at, last_used_at, revoked_at, rotated_from
+		FROM api_keys
+		ORDER BY created_at
+	`
+
+	rows, err := repo.db.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]*entity.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get api keys: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// SaveAPIKey сохраняет ключ в БД
+func (repo *PostgresAPIKeyRepository) SaveAPIKey(ctx context.Context, key *entity.APIKey) error {
+	query := `
+		INSERT INTO api_keys (key_id, name, key_hash, scopes, created_at, expires_at, rotated_from)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := repo.db.Pool.Exec(ctx, query,
+		key.KeyID,
+		key.Name,
+		key.KeyHash,
+		key.Scopes,
+		key.CreatedAt,
+		key.ExpiresAt,
+		key.RotatedFrom,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save api key: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateAPIKey выполняет запрос к БД для обновления
+// изменяемой информации о ключе (срок действия и время отзыва)
+func (repo *PostgresAPIKeyRepository) UpdateAPIKey(ctx context.Context, key *entity.APIKey) error {
+	query := `
+		UPDATE api_keys
+		SET name = $1, scopes = $2, expires_at = $3, revoked_at = $4
+		WHERE key_id = $5
+	`
+
+	result, err := repo.db.Pool.Exec(ctx, query,
+		key.Name,
+		key.Scopes,
+		key.ExpiresAt,
+		key.RevokedAt,
+		key.KeyID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update api key: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("api key not found")
+	}
+
+	return nil
+}
+
+// TouchAPIKey выполняет запрос к БД для обновления
+// времени последнего использования ключа
+func (repo *PostgresAPIKeyRepository) TouchAPIKey(ctx context.Context, keyID string, usedAt time.Time) error {
+	query := `
+		UPDATE api_keys
+		SET last_used_at = $1
+		WHERE key_id = $2
+	`
+
+	_, err := repo.db.Pool.Exec(ctx, query, usedAt, keyID)
+	if err != nil {
+		return fmt.Errorf("failed to touch api key: %w", err)
+	}
+
+	return nil
+}
+
+func scanAPIKey(row pgx.Row) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := row.Scan(
+		&key.KeyID,
+		&key.Name,
+		&key.KeyHash,
+		&key.Scopes,
+		&key.CreatedAt,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.RotatedFrom,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
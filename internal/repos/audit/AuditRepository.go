@@ -0,0 +1,19 @@
+// Package audit - пакет с репозиториями, отвечающими за взаимодействие с БД,
+// где хранится журнал аудита изменений
+package audit
+
+import (
+	"context"
+
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// AuditRepository представляет интерфейс взаимодействия с базой данных,
+// где хранится журнал аудита (только добавление записей)
+type AuditRepository interface {
+	AppendRecord(ctx context.Context, record *entity.AuditRecord) error
+
+	GetRecords(ctx context.Context, filter *dto.AuditFilter) ([]*entity.AuditRecord, error)
+	GetRecordsAfter(ctx context.Context, afterID int64, limit int) ([]*entity.AuditRecord, error)
+}
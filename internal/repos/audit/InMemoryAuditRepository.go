@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// InMemoryAuditRepository представляет собой компонент,
+// отвечающий за взаимодействие с in-memory хранилищем (slice),
+// где содержится журнал аудита
+type InMemoryAuditRepository struct {
+	mu      sync.RWMutex
+	storage []*entity.AuditRecord
+}
+
+// NewInMemoryAuditRepository конструирует и возвращает объект InMemoryAuditRepository
+func NewInMemoryAuditRepository() *InMemoryAuditRepository {
+	return &InMemoryAuditRepository{
+		storage: make([]*entity.AuditRecord, 0),
+	}
+}
+
+// AppendRecord добавляет запись в конец журнала,
+// связывая её с хешем предыдущей записи
+func (repo *InMemoryAuditRepository) AppendRecord(ctx context.Context, record *entity.AuditRecord) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	record.PrevHash = ""
+	if len(repo.storage) > 0 {
+		record.PrevHash = repo.storage[len(repo.storage)-1].Hash
+	}
+	record.ID = int64(len(repo.storage) + 1)
+	record.Hash = record.ComputeHash()
+
+	repo.storage = append(repo.storage, record)
+	return nil
+}
+
+// GetRecords возвращает записи журнала, удовлетворяющие фильтру
+// (сначала самые новые)
+func (repo *InMemoryAuditRepository) GetRecords(ctx context.Context, filter *dto.AuditFilter) ([]*entity.AuditRecord, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	records := make([]*entity.AuditRecord, 0)
+	skipped := 0
+	for _, v := range slices.Backward(repo.storage) {
+		if !matches(v, filter) {
+			continue
+		}
+
+		if skipped < filter.Offset {
+			skipped++
+			continue
+		}
+
+		records = append(records, v)
+		if filter.Limit > 0 && len(records) == filter.Limit {
+			break
+		}
+	}
+
+	return records, nil
+}
+
+// GetRecordsAfter возвращает до limit записей журнала
+// с идентификатором больше afterID (в порядке добавления)
+func (repo *InMemoryAuditRepository) GetRecordsAfter(ctx context.Context, afterID int64, limit int) ([]*entity.AuditRecord, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	start := min(int(max(afterID, 0)), len(repo.storage))
+	end := min(start+limit, len(repo.storage))
+
+	return slices.Clone(repo.storage[start:end]), nil
+}
+
+func matches(record *entity.AuditRecord, filter *dto.AuditFilter) bool {
+	switch {
+	case filter.Actor != "" && record.Actor != filter.Actor:
+		return false
+	case filter.Action != "" && record.Action != filter.Action:
+		return false
+	case filter.TargetType != "" && record.TargetType != filter.TargetType:
+		return false
+	case filter.TargetID != "" && !slices.Contains(record.TargetIDs, filter.TargetID):
+		return false
+	case filter.From != nil && record.OccurredAt.Before(*filter.From):
+		return false
+	case filter.To != nil && !record.OccurredAt.Before(*filter.To):
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,189 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/salex06/pr-service/internal/database"
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// auditLockID - идентификатор advisory-блокировки,
+// сериализующей добавление записей в цепочку хешей
+const auditLockID = 7_291_001
+
+// PostgresAuditRepository представляет собой компонент,
+// отвечающий за взаимодействие с БД PostgreSQL, где
+// хранится журнал аудита
+type PostgresAuditRepository struct {
+	db *database.DB
+}
+
+// NewPostgresAuditRepository конструирует и возвращает объект PostgresAuditRepository
+func NewPostgresAuditRepository(db *database.DB) AuditRepository {
+	return &PostgresAuditRepository{db: db}
+}
+
+// AppendRecord выполняет в транзакции добавление записи в конец журнала,
+// связывая её с хешем предыдущей записи. Параллельные добавления
+// сериализуются advisory-блокировкой
+func (repo *PostgresAuditRepository) AppendRecord(ctx context.Context, record *entity.AuditRecord) (err error) {
+	tx, err := repo.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, auditLockID); err != nil {
+		return fmt.Errorf("failed to lock audit log: %w", err)
+	}
+
+	record.PrevHash = ""
+	err = tx.QueryRow(ctx, `SELECT hash FROM audit_log ORDER BY id DESC LIMIT 1`).Scan(&record.PrevHash)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to get last audit record: %w", err)
+	}
+	record.Hash = record.ComputeHash()
+
+	query := `
+		INSERT INTO audit_log (occurred_at, actor, action, target_type, target_ids,
+			before_state, after_state, request_id, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	err = tx.QueryRow(ctx, query,
+		record.OccurredAt,
+		record.Actor,
+		record.Action,
+		record.TargetType,
+		record.TargetIDs,
+		nullableJSON(record.Before),
+		nullableJSON(record.After),
+		record.RequestID,
+		record.PrevHash,
+		record.Hash,
+	).Scan(&record.ID)
+	if err != nil {
+		return fmt.Errorf("failed to append audit record: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit audit record: %w", err)
+	}
+
+	return nil
+}
+
+// GetRecords выполняет запрос к БД и возвращает записи журнала,
+// удовлетворяющие фильтру (сначала самые новые)
+func (repo *PostgresAuditRepository) GetRecords(ctx context.Context, filter *dto.AuditFilter) ([]*entity.AuditRecord, error) {
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Actor != "" {
+		addCondition("actor = $%d", filter.Actor)
+	}
+	if filter.Action != "" {
+		addCondition("action = $%d", filter.Action)
+	}
+	if filter.TargetType != "" {
+		addCondition("target_type = $%d", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		addCondition("$%d = ANY(target_ids)", filter.TargetID)
+	}
+	if filter.From != nil {
+		addCondition("occurred_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("occurred_at < $%d", *filter.To)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+		SELECT id, occurred_at, actor, action, target_type, target_ids,
+			before_state, after_state, COALESCE(request_id, ''), prev_hash, hash
+		FROM audit_log
+		%s
+		ORDER BY id DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args))
+
+	return repo.queryRecords(ctx, query, args...)
+}
+
+// GetRecordsAfter выполняет запрос к БД и возвращает до limit записей
+// журнала с идентификатором больше afterID (в порядке добавления)
+func (repo *PostgresAuditRepository) GetRecordsAfter(ctx context.Context, afterID int64, limit int) ([]*entity.AuditRecord, error) {
+	query := `
+		SELECT id, occurred_at, actor, action, target_type, target_ids,
+			before_state, after_state, COALESCE(request_id, ''), prev_hash, hash
+		FROM audit_log
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+
+	return repo.queryRecords(ctx, query, afterID, limit)
+}
+
+func (repo *PostgresAuditRepository) queryRecords(ctx context.Context, query string, args ...any) ([]*entity.AuditRecord, error) {
+	rows, err := repo.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit records: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]*entity.AuditRecord, 0)
+	for rows.Next() {
+		var record entity.AuditRecord
+		var before, after []byte
+		if err := rows.Scan(
+			&record.ID,
+			&record.OccurredAt,
+			&record.Actor,
+			&record.Action,
+			&record.TargetType,
+			&record.TargetIDs,
+			&before,
+			&after,
+			&record.RequestID,
+			&record.PrevHash,
+			&record.Hash,
+		); err != nil {
+			return nil, fmt.Errorf("failed to get audit records: %w", err)
+		}
+		record.Before = before
+		record.After = after
+		records = append(records, &record)
+	}
+
+	return records, rows.Err()
+}
+
+func nullableJSON(value []byte) any {
+	if len(value) == 0 {
+		return nil
+	}
+
+	return string(value)
+}
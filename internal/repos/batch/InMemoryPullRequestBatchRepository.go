@@ -0,0 +1,59 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/salex06/pr-service/internal/entity"
+	prRepos "github.com/salex06/pr-service/internal/repos/pr"
+	revsRepos "github.com/salex06/pr-service/internal/repos/reviewers"
+)
+
+// InMemoryPullRequestBatchRepository представляет собой компонент,
+// сохраняющий пакет PR's и назначений в in-memory хранилища prRepo и revsRepo
+type InMemoryPullRequestBatchRepository struct {
+	prRepo   prRepos.PullRequestRepository
+	revsRepo revsRepos.AssignedRevsRepository
+}
+
+// NewInMemoryPullRequestBatchRepository конструирует и возвращает объект InMemoryPullRequestBatchRepository
+func NewInMemoryPullRequestBatchRepository(
+	prRepo prRepos.PullRequestRepository,
+	revsRepo revsRepos.AssignedRevsRepository,
+) *InMemoryPullRequestBatchRepository {
+	return &InMemoryPullRequestBatchRepository{
+		prRepo:   prRepo,
+		revsRepo: revsRepo,
+	}
+}
+
+// SavePullRequestsWithAssignments сохраняет PR's вместе с назначениями.
+// Если хотя бы один PR уже существует, не сохраняется ничего
+func (repo *InMemoryPullRequestBatchRepository) SavePullRequestsWithAssignments(
+	ctx context.Context,
+	prs []*entity.PullRequest,
+	assignments []*entity.AssignedReviewers,
+) error {
+	for _, pr := range prs {
+		exists, err := repo.prRepo.PullRequestExists(ctx, pr.PullRequestID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("failed to save pull requests: %s already exists", pr.PullRequestID)
+		}
+	}
+
+	for _, pr := range prs {
+		if err := repo.prRepo.SavePullRequest(ctx, pr); err != nil {
+			return err
+		}
+	}
+	for _, assignment := range assignments {
+		if err := repo.revsRepo.CreateAssignment(ctx, assignment.UserID, assignment.PullRequestID, assignment.Reason); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
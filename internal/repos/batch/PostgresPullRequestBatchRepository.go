@@ -0,0 +1,53 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/salex06/pr-service/internal/database"
+	"github.com/salex06/pr-service/internal/entity"
+	prRepos "github.com/salex06/pr-service/internal/repos/pr"
+	revsRepos "github.com/salex06/pr-service/internal/repos/reviewers"
+)
+
+// PostgresPullRequestBatchRepository представляет собой компонент,
+// сохраняющий пакет PR's и назначений в БД PostgreSQL в одной транзакции
+type PostgresPullRequestBatchRepository struct {
+	db *database.DB
+}
+
+// NewPostgresPullRequestBatchRepository конструирует и возвращает объект PostgresPullRequestBatchRepository
+func NewPostgresPullRequestBatchRepository(db *database.DB) PullRequestBatchRepository {
+	return &PostgresPullRequestBatchRepository{db: db}
+}
+
+// SavePullRequestsWithAssignments открывает транзакцию, в которой
+// репозитории PR's и назначений сохраняют записи своих таблиц
+func (repo *PostgresPullRequestBatchRepository) SavePullRequestsWithAssignments(
+	ctx context.Context,
+	prs []*entity.PullRequest,
+	assignments []*entity.AssignedReviewers,
+) (err error) {
+	tx, err := repo.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err = prRepos.SavePullRequestsTx(ctx, tx, prs); err != nil {
+		return err
+	}
+	if err = revsRepos.CreateAssignmentsTx(ctx, tx, assignments); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit pull requests with assignments: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+// Package batch - пакет с репозиториями, сохраняющими пакет PR's вместе
+// с назначениями ревьюеров на них в одной транзакции. Записи каждой таблицы
+// формирует репозиторий, отвечающий за эту таблицу
+package batch
+
+import (
+	"context"
+
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// PullRequestBatchRepository представляет интерфейс сохранения
+// пакета PR's вместе с назначениями ревьюеров на них
+type PullRequestBatchRepository interface {
+	// SavePullRequestsWithAssignments сохраняет PR's prs и назначения
+	// assignments: либо все записи, либо ни одной
+	SavePullRequestsWithAssignments(ctx context.Context, prs []*entity.PullRequest, assignments []*entity.AssignedReviewers) error
+}
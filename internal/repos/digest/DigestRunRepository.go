@@ -0,0 +1,20 @@
+// Package digest - пакет с репозиториями, отвечающими за взаимодействие с БД,
+// где хранятся сведения о выполненных рассылках дайджеста
+package digest
+
+import (
+	"context"
+	"time"
+)
+
+// DigestRunRepository представляет интерфейс взаимодействия
+// с базой данных, где хранятся периоды, за которые разослан дайджест
+type DigestRunRepository interface {
+	// ClaimRun отмечает период с началом periodStart как разосланный и возвращает
+	// true, если период еще не был отмечен (рассылку выполняет вызвавший);
+	// false - если дайджест за период уже разослан (например, другой репликой)
+	ClaimRun(ctx context.Context, periodStart time.Time) (bool, error)
+	// ReleaseRun снимает отметку с периода с началом periodStart,
+	// чтобы рассылка за него была выполнена повторно
+	ReleaseRun(ctx context.Context, periodStart time.Time) error
+}
@@ -0,0 +1,45 @@
+package digest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// InMemoryDigestRunRepository представляет собой компонент,
+// отвечающий за взаимодействие с in-memory хранилищем (map),
+// где содержатся периоды разосланных дайджестов
+type InMemoryDigestRunRepository struct {
+	mu      sync.Mutex
+	storage map[time.Time]struct{}
+}
+
+// NewInMemoryDigestRunRepository конструирует и возвращает объект InMemoryDigestRunRepository
+func NewInMemoryDigestRunRepository() *InMemoryDigestRunRepository {
+	return &InMemoryDigestRunRepository{
+		storage: make(map[time.Time]struct{}),
+	}
+}
+
+// ClaimRun отмечает период как разосланный, если он еще не был отмечен
+func (repo *InMemoryDigestRunRepository) ClaimRun(ctx context.Context, periodStart time.Time) (bool, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	periodStart = periodStart.UTC()
+	if _, ok := repo.storage[periodStart]; ok {
+		return false, nil
+	}
+
+	repo.storage[periodStart] = struct{}{}
+	return true, nil
+}
+
+// ReleaseRun снимает отметку с периода
+func (repo *InMemoryDigestRunRepository) ReleaseRun(ctx context.Context, periodStart time.Time) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.storage, periodStart.UTC())
+	return nil
+}
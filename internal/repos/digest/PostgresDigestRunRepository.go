@@ -0,0 +1,52 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/salex06/pr-service/internal/database"
+)
+
+// PostgresDigestRunRepository представляет собой компонент,
+// отвечающий за взаимодействие с БД PostgreSQL, где
+// хранятся периоды разосланных дайджестов
+type PostgresDigestRunRepository struct {
+	db *database.DB
+}
+
+// NewPostgresDigestRunRepository конструирует и возвращает объект PostgresDigestRunRepository
+func NewPostgresDigestRunRepository(db *database.DB) DigestRunRepository {
+	return &PostgresDigestRunRepository{db: db}
+}
+
+// ClaimRun выполняет запрос к БД, сохраняющий период рассылки. Уникальность
+// начала периода гарантирует, что период получит только одна реплика
+func (repo *PostgresDigestRunRepository) ClaimRun(ctx context.Context, periodStart time.Time) (bool, error) {
+	query := `
+		INSERT INTO digest_runs (period_start)
+		VALUES ($1)
+		ON CONFLICT (period_start) DO NOTHING
+	`
+
+	result, err := repo.db.Pool.Exec(ctx, query, periodStart.UTC())
+	if err != nil {
+		return false, fmt.Errorf("failed to claim digest run: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// ReleaseRun выполняет запрос к БД, удаляющий сохраненный период рассылки
+func (repo *PostgresDigestRunRepository) ReleaseRun(ctx context.Context, periodStart time.Time) error {
+	query := `
+		DELETE FROM digest_runs
+		WHERE period_start = $1
+	`
+
+	if _, err := repo.db.Pool.Exec(ctx, query, periodStart.UTC()); err != nil {
+		return fmt.Errorf("failed to release digest run: %w", err)
+	}
+
+	return nil
+}
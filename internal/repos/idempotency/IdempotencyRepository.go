@@ -0,0 +1,33 @@
+// Package idempotency - пакет с репозиториями, отвечающими за взаимодействие с БД,
+// где хранятся ключи идемпотентности и сохраненные ответы на запросы
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// ErrKeyNotReserved - ошибка сохранения ответа по ключу, резервирование
+// которого истекло и было перехвачено другим запросом (или ключ удален)
+var ErrKeyNotReserved = errors.New("idempotency key is not reserved by this request")
+
+// IdempotencyRepository представляет интерфейс взаимодействия
+// с базой данных, где хранятся ключи идемпотентности
+type IdempotencyRepository interface {
+	// ReserveKey сохраняет новый ключ (без ответа), если для него нет действующей
+	// записи, и возвращает nil; иначе возвращает действующую запись. Запись, срок
+	// хранения или резервирования которой истек, заменяется новой
+	ReserveKey(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, error)
+	// CompleteKey сохраняет ответ на запрос, если ключ все еще зарезервирован
+	// этим запросом (не был перехвачен другим после истечения резервирования);
+	// иначе возвращает ErrKeyNotReserved
+	CompleteKey(ctx context.Context, record *entity.IdempotencyRecord) error
+	// DeleteKey удаляет резервирование ключа запросом (например,
+	// если запрос завершился неудачно)
+	DeleteKey(ctx context.Context, record *entity.IdempotencyRecord) error
+	// DeleteExpiredKeys удаляет ключи, срок хранения которых истек к моменту now
+	DeleteExpiredKeys(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,92 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// InMemoryIdempotencyRepository представляет собой компонент,
+// отвечающий за взаимодействие с in-memory хранилищем (map),
+// где содержатся ключи идемпотентности
+type InMemoryIdempotencyRepository struct {
+	mu      sync.Mutex
+	storage map[string]*entity.IdempotencyRecord
+}
+
+// NewInMemoryIdempotencyRepository конструирует и возвращает объект InMemoryIdempotencyRepository
+func NewInMemoryIdempotencyRepository() *InMemoryIdempotencyRepository {
+	return &InMemoryIdempotencyRepository{
+		storage: make(map[string]*entity.IdempotencyRecord),
+	}
+}
+
+// ReserveKey сохраняет новый ключ, если для него нет действующей записи
+// (запись с истекшим сроком хранения или резервирования заменяется),
+// иначе возвращает копию действующей записи
+func (repo *InMemoryIdempotencyRepository) ReserveKey(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if existing, ok := repo.storage[record.Key]; ok &&
+		!existing.IsExpired(record.CreatedAt) && !existing.IsStale(record.CreatedAt) {
+		found := *existing
+		return &found, nil
+	}
+
+	reserved := *record
+	reserved.StatusCode = 0
+	reserved.ContentType = ""
+	reserved.ResponseHeaders = nil
+	reserved.ResponseBody = nil
+	repo.storage[record.Key] = &reserved
+
+	return nil, nil
+}
+
+// CompleteKey сохраняет ответ на запрос, если ключ все еще зарезервирован
+// этим запросом (иначе - ErrKeyNotReserved)
+func (repo *InMemoryIdempotencyRepository) CompleteKey(ctx context.Context, record *entity.IdempotencyRecord) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	existing, ok := repo.storage[record.Key]
+	if !ok || !existing.CreatedAt.Equal(record.CreatedAt) {
+		return ErrKeyNotReserved
+	}
+
+	existing.StatusCode = record.StatusCode
+	existing.ContentType = record.ContentType
+	existing.ResponseHeaders = record.ResponseHeaders
+	existing.ResponseBody = record.ResponseBody
+	return nil
+}
+
+// DeleteKey удаляет ключ из хранилища, если он все еще зарезервирован этим запросом
+func (repo *InMemoryIdempotencyRepository) DeleteKey(ctx context.Context, record *entity.IdempotencyRecord) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if existing, ok := repo.storage[record.Key]; ok && existing.CreatedAt.Equal(record.CreatedAt) {
+		delete(repo.storage, record.Key)
+	}
+	return nil
+}
+
+// DeleteExpiredKeys удаляет ключи, срок хранения которых истек к моменту now
+func (repo *InMemoryIdempotencyRepository) DeleteExpiredKeys(ctx context.Context, now time.Time) (int64, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var deleted int64
+	for key, record := range repo.storage {
+		if record.IsExpired(now) {
+			delete(repo.storage, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,151 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/salex06/pr-service/internal/database"
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// reserveAttempts - число попыток резервирования ключа, если действующая
+// запись была удалена между вставкой и ее чтением
+const reserveAttempts = 3
+
+// PostgresIdempotencyRepository представляет собой компонент,
+// отвечающий за взаимодействие с БД PostgreSQL, где
+// хранятся ключи идемпотентности
+type PostgresIdempotencyRepository struct {
+	db *database.DB
+}
+
+// NewPostgresIdempotencyRepository конструирует и возвращает объект PostgresIdempotencyRepository
+func NewPostgresIdempotencyRepository(db *database.DB) IdempotencyRepository {
+	return &PostgresIdempotencyRepository{db: db}
+}
+
+// ReserveKey выполняет запрос к БД, сохраняющий новый ключ (запись с истекшим
+// сроком хранения или резервирования заменяется). Если для ключа есть
+// действующая запись, она возвращается
+func (repo *PostgresIdempotencyRepository) ReserveKey(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, error) {
+	reserveQuery := `
+		INSERT INTO idempotency_keys (idempotency_key, request_hash, created_at, expires_at, locked_until)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (idempotency_key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+			status_code = NULL,
+			content_type = NULL,
+			response_headers = NULL,
+			response_body = NULL,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at,
+			locked_until = EXCLUDED.locked_until
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+			OR (idempotency_keys.status_code IS NULL AND idempotency_keys.locked_until <= EXCLUDED.created_at)
+	`
+	selectQuery := `
+		SELECT idempotency_key, request_hash, COALESCE(status_code, 0), COALESCE(content_type, ''),
+			COALESCE(response_headers, '{}'::jsonb), response_body, created_at, expires_at, locked_until
+		FROM idempotency_keys
+		WHERE idempotency_key = $1
+	`
+
+	for range reserveAttempts {
+		result, err := repo.db.Pool.Exec(ctx, reserveQuery,
+			record.Key,
+			record.RequestHash,
+			record.CreatedAt,
+			record.ExpiresAt,
+			record.LockedUntil,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+
+		if result.RowsAffected() > 0 {
+			return nil, nil
+		}
+
+		var existing entity.IdempotencyRecord
+		err = repo.db.Pool.QueryRow(ctx, selectQuery, record.Key).Scan(
+			&existing.Key,
+			&existing.RequestHash,
+			&existing.StatusCode,
+			&existing.ContentType,
+			&existing.ResponseHeaders,
+			&existing.ResponseBody,
+			&existing.CreatedAt,
+			&existing.ExpiresAt,
+			&existing.LockedUntil,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+		}
+
+		return &existing, nil
+	}
+
+	return nil, errors.New("failed to reserve idempotency key: key is concurrently modified")
+}
+
+// CompleteKey выполняет запрос к БД, сохраняющий ответ на запрос,
+// если ключ все еще зарезервирован этим запросом (иначе - ErrKeyNotReserved)
+func (repo *PostgresIdempotencyRepository) CompleteKey(ctx context.Context, record *entity.IdempotencyRecord) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $1, content_type = $2, response_headers = $3, response_body = $4
+		WHERE idempotency_key = $5 AND created_at = $6
+	`
+
+	result, err := repo.db.Pool.Exec(ctx, query,
+		record.StatusCode,
+		record.ContentType,
+		record.ResponseHeaders,
+		record.ResponseBody,
+		record.Key,
+		record.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("failed to complete idempotency key: %w", ErrKeyNotReserved)
+	}
+
+	return nil
+}
+
+// DeleteKey выполняет запрос к БД, удаляющий ключ,
+// если он все еще зарезервирован этим запросом
+func (repo *PostgresIdempotencyRepository) DeleteKey(ctx context.Context, record *entity.IdempotencyRecord) error {
+	query := `DELETE FROM idempotency_keys WHERE idempotency_key = $1 AND created_at = $2`
+
+	if _, err := repo.db.Pool.Exec(ctx, query, record.Key, record.CreatedAt); err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredKeys выполняет запрос к БД, удаляющий ключи
+// с истекшим к моменту now сроком хранения
+func (repo *PostgresIdempotencyRepository) DeleteExpiredKeys(ctx context.Context, now time.Time) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= $1`
+
+	result, err := repo.db.Pool.Exec(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
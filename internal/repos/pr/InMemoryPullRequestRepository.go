@@ -2,27 +2,41 @@ package pr
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/salex06/pr-service/internal/dto"
 	"github.com/salex06/pr-service/internal/entity"
 )
 
 // InMemoryPullRequestRepository представляет собой компонент,
 // отвечающий за взаимодействие с in-memory хранилищем (map),
-// где содержится информация о PR's
+// где содержится информация о PR's и решениях ревьюеров по ним
 type InMemoryPullRequestRepository struct {
-	storage map[string]*entity.PullRequest
+	storage  map[string]*entity.PullRequest
+	verdicts map[string][]*entity.ReviewVerdict
 }
 
 // NewInMemoryPullRequestRepository конструирует и возвращает объект InMemoryPullRequestRepository
 func NewInMemoryPullRequestRepository() *InMemoryPullRequestRepository {
 	return &InMemoryPullRequestRepository{
-		storage: make(map[string]*entity.PullRequest),
+		storage:  make(map[string]*entity.PullRequest),
+		verdicts: make(map[string][]*entity.ReviewVerdict),
 	}
 }
 
-// GetPullRequest возвращает PR с заданным идентификатором (nil - если не найден)
+// GetPullRequest возвращает копию PR с заданным идентификатором (nil - если не найден)
 func (repo *InMemoryPullRequestRepository) GetPullRequest(ctx context.Context, prID string) (*entity.PullRequest, error) {
-	return repo.storage[prID], nil
+	pr, ok := repo.storage[prID]
+	if !ok {
+		return nil, nil
+	}
+
+	found := *pr
+	return &found, nil
 }
 
 // GetPullRequests возвращает набор PR's по заданному набору идентификаторов
@@ -36,6 +50,47 @@ func (repo *InMemoryPullRequestRepository) GetPullRequests(ctx context.Context,
 	return prs, nil
 }
 
+// ListPullRequests возвращает PR's, удовлетворяющие фильтру (сначала самые новые)
+func (repo *InMemoryPullRequestRepository) ListPullRequests(ctx context.Context, filter *dto.PullRequestFilter) ([]*entity.PullRequest, error) {
+	prs := make([]*entity.PullRequest, 0)
+	for _, pr := range repo.storage {
+		if filter.Status != "" && pr.Status != filter.Status {
+			continue
+		}
+		if filter.AuthorID != "" && pr.AuthorID != filter.AuthorID {
+			continue
+		}
+		if filter.Repository != "" && (pr.Repository == nil || *pr.Repository != filter.Repository) {
+			continue
+		}
+		if filter.Label != "" && !slices.Contains(pr.Labels, filter.Label) {
+			continue
+		}
+		prs = append(prs, pr)
+	}
+
+	slices.SortFunc(prs, func(a, b *entity.PullRequest) int {
+		if a.CreatedAt != nil && b.CreatedAt != nil && !a.CreatedAt.Equal(*b.CreatedAt) {
+			return b.CreatedAt.Compare(*a.CreatedAt)
+		}
+		if (a.CreatedAt == nil) != (b.CreatedAt == nil) {
+			if a.CreatedAt == nil {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.PullRequestID, b.PullRequestID)
+	})
+
+	start := min(filter.Offset, len(prs))
+	end := len(prs)
+	if filter.Limit > 0 {
+		end = min(start+filter.Limit, len(prs))
+	}
+
+	return prs[start:end], nil
+}
+
 // PullRequestExists выполняет проверку наличия PR
 // с заданным идентификатором в хранилище и возвращает результат
 func (repo *InMemoryPullRequestRepository) PullRequestExists(ctx context.Context, prID string) (bool, error) {
@@ -43,16 +98,24 @@ func (repo *InMemoryPullRequestRepository) PullRequestExists(ctx context.Context
 	return ok, nil
 }
 
-// SavePullRequest выполняет сохранение PR в хранилище
+// SavePullRequest выполняет сохранение PR (первой версии) в хранилище
 func (repo *InMemoryPullRequestRepository) SavePullRequest(ctx context.Context, pr *entity.PullRequest) error {
-	repo.storage[pr.PullRequestID] = pr
+	pr.Version = 1
+	saved := *pr
+	repo.storage[pr.PullRequestID] = &saved
 	return nil
 }
 
-// UpdatePullRequest выполняет обновление PR
-// (для данной реализации идентично SavePullRequest)
+// UpdatePullRequest выполняет обновление PR, если его версия в хранилище
+// совпадает с pr.Version (иначе - entity.ErrStaleVersion), и увеличивает версию PR
 func (repo *InMemoryPullRequestRepository) UpdatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
-	repo.storage[pr.PullRequestID] = pr
+	if stored, ok := repo.storage[pr.PullRequestID]; !ok || stored.Version != pr.Version {
+		return fmt.Errorf("failed to update pull request %s: %w", pr.PullRequestID, entity.ErrStaleVersion)
+	}
+
+	pr.Version++
+	updated := *pr
+	repo.storage[pr.PullRequestID] = &updated
 	return nil
 }
 
@@ -67,13 +130,150 @@ func (repo *InMemoryPullRequestRepository) GetOpenedPullRequestCount(ctx context
 	return count, nil
 }
 
-// GetMergedPullRequestCount возвращает число PR в статусе MERGED
-func (repo *InMemoryPullRequestRepository) GetMergedPullRequestCount(ctx context.Context) (int, error) {
-	count := 0
+// SaveVerdict сохраняет копию решения ревьюера по PR
+func (repo *InMemoryPullRequestRepository) SaveVerdict(ctx context.Context, verdict *entity.ReviewVerdict) error {
+	if _, ok := repo.storage[verdict.PullRequestID]; !ok {
+		return fmt.Errorf("pull request %s not found", verdict.PullRequestID)
+	}
+
+	saved := *verdict
+	repo.verdicts[verdict.PullRequestID] = append(repo.verdicts[verdict.PullRequestID], &saved)
+	return nil
+}
+
+// GetPullRequestCountByStatus возвращает число PR's в каждом статусе
+// среди PR's, созданных в заданном временном окне
+func (repo *InMemoryPullRequestRepository) GetPullRequestCountByStatus(
+	ctx context.Context,
+	filter *dto.StatsFilter,
+) (map[entity.PullRequestStatus]int, error) {
+	counts := make(map[entity.PullRequestStatus]int)
 	for _, v := range repo.storage {
-		if v.Status == entity.MERGED {
-			count++
+		if v.CreatedAt == nil || !inWindow(*v.CreatedAt, filter) || !matchesAuthor(v, filter) {
+			continue
 		}
+		counts[v.Status]++
 	}
-	return count, nil
+
+	return counts, nil
+}
+
+// GetMergeTimeStats возвращает медиану и 90-й перцентиль времени
+// от создания до слияния PR's, слитых в заданном временном окне
+// (перцентили рассчитываются линейной интерполяцией, как percentile_cont в PostgreSQL)
+func (repo *InMemoryPullRequestRepository) GetMergeTimeStats(ctx context.Context, filter *dto.StatsFilter) (*dto.MergeTimeStats, error) {
+	durations := make([]float64, 0)
+	for _, v := range repo.storage {
+		if v.Status != entity.MERGED || v.MergedAt == nil || v.CreatedAt == nil {
+			continue
+		}
+		if !inWindow(*v.MergedAt, filter) || !matchesAuthor(v, filter) {
+			continue
+		}
+		durations = append(durations, v.MergedAt.Sub(*v.CreatedAt).Seconds())
+	}
+	slices.Sort(durations)
+
+	return &dto.MergeTimeStats{
+		MergedCount:   len(durations),
+		MedianSeconds: percentileCont(durations, 0.5),
+		P90Seconds:    percentileCont(durations, 0.9),
+	}, nil
+}
+
+// GetFirstVerdictTimeStats возвращает медиану и 90-й перцентиль времени
+// от создания PR до первого решения ревьюера по нему (для PR's,
+// первое решение по которым вынесено в заданном временном окне)
+func (repo *InMemoryPullRequestRepository) GetFirstVerdictTimeStats(
+	ctx context.Context,
+	filter *dto.StatsFilter,
+) (*dto.FirstVerdictTimeStats, error) {
+	durations := make([]float64, 0)
+	for prID, verdicts := range repo.verdicts {
+		v, ok := repo.storage[prID]
+		if !ok || v.CreatedAt == nil || len(verdicts) == 0 || !matchesAuthor(v, filter) {
+			continue
+		}
+		firstVerdictAt := *verdicts[0].CreatedAt
+		if !inWindow(firstVerdictAt, filter) {
+			continue
+		}
+		durations = append(durations, firstVerdictAt.Sub(*v.CreatedAt).Seconds())
+	}
+	slices.Sort(durations)
+
+	return &dto.FirstVerdictTimeStats{
+		PullRequestsCount: len(durations),
+		MedianSeconds:     percentileCont(durations, 0.5),
+		P90Seconds:        percentileCont(durations, 0.9),
+	}, nil
+}
+
+// GetThroughput возвращает число открытых и слитых PR's в заданном
+// временном окне, сгруппированных по дням или неделям (UTC)
+func (repo *InMemoryPullRequestRepository) GetThroughput(ctx context.Context, filter *dto.StatsFilter) ([]*dto.ThroughputBucket, error) {
+	buckets := make(map[time.Time]*dto.ThroughputBucket)
+	bucketFor := func(t time.Time) *dto.ThroughputBucket {
+		period := truncatePeriod(t, filter.Granularity)
+		if _, ok := buckets[period]; !ok {
+			buckets[period] = &dto.ThroughputBucket{PeriodStart: period}
+		}
+		return buckets[period]
+	}
+
+	for _, v := range repo.storage {
+		if !matchesAuthor(v, filter) {
+			continue
+		}
+		if v.CreatedAt != nil && inWindow(*v.CreatedAt, filter) {
+			bucketFor(*v.CreatedAt).Opened++
+		}
+		if v.Status == entity.MERGED && v.MergedAt != nil && inWindow(*v.MergedAt, filter) {
+			bucketFor(*v.MergedAt).Merged++
+		}
+	}
+
+	result := make([]*dto.ThroughputBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		result = append(result, bucket)
+	}
+	slices.SortFunc(result, func(a, b *dto.ThroughputBucket) int {
+		return a.PeriodStart.Compare(b.PeriodStart)
+	})
+
+	return result, nil
+}
+
+func inWindow(t time.Time, filter *dto.StatsFilter) bool {
+	return !t.Before(filter.From) && t.Before(filter.To)
+}
+
+func matchesAuthor(pr *entity.PullRequest, filter *dto.StatsFilter) bool {
+	return filter.UserIDs == nil || slices.Contains(filter.UserIDs, pr.AuthorID)
+}
+
+// truncatePeriod возвращает начало дня или недели (с понедельника), в которые попадает t
+func truncatePeriod(t time.Time, granularity dto.StatsGranularity) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if granularity == dto.GranularityWeek {
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+
+	return day
+}
+
+// percentileCont возвращает перцентиль p отсортированного набора
+// значений с линейной интерполяцией (nil - если набор пуст)
+func percentileCont(sorted []float64, p float64) *float64 {
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	pos := p * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	value := sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+
+	return &value
 }
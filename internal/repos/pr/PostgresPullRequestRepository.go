@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 
 	"github.com/salex06/pr-service/internal/database"
+	"github.com/salex06/pr-service/internal/dto"
 	"github.com/salex06/pr-service/internal/entity"
 )
 
@@ -40,21 +42,13 @@ func (repo *PostgresPullRequestRepository) PullRequestExists(ctx context.Context
 // PR с заданным идентификатором (nil - если не найден)
 func (repo *PostgresPullRequestRepository) GetPullRequest(ctx context.Context, prID string) (*entity.PullRequest, error) {
 	query := `
-		SELECT pull_request_id, pull_request_name, author_id, pr_status, created_at, merged_at 
+		SELECT pull_request_id, pull_request_name, author_id, pr_status, created_at, merged_at, version,
+			repository, source_branch, target_branch, url, labels, lines_changed
 		FROM pull_requests
 		WHERE pull_request_id = $1
 	`
 
-	var pr entity.PullRequest
-	err := repo.db.Pool.QueryRow(ctx, query, prID).Scan(
-		&pr.PullRequestID,
-		&pr.PullRequestName,
-		&pr.AuthorID,
-		&pr.Status,
-		&pr.CreatedAt,
-		&pr.MergedAt,
-	)
-
+	pr, err := scanPullRequest(repo.db.Pool.QueryRow(ctx, query, prID))
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
@@ -63,35 +57,97 @@ func (repo *PostgresPullRequestRepository) GetPullRequest(ctx context.Context, p
 		return nil, fmt.Errorf("failed to get pull request: %w", err)
 	}
 
-	return &pr, nil
+	return pr, nil
 }
 
-// GetPullRequests возвращает набор объектов PR's по заданному набору идентификаторов
+// GetPullRequests выполняет запрос к БД и возвращает набор объектов PR's
+// по заданному набору идентификаторов (в порядке следования идентификаторов)
 func (repo *PostgresPullRequestRepository) GetPullRequests(ctx context.Context, prIds []string) ([]*entity.PullRequest, error) {
+	query := `
+		SELECT pull_request_id, pull_request_name, author_id, pr_status, created_at, merged_at, version,
+			repository, source_branch, target_branch, url, labels, lines_changed
+		FROM pull_requests
+		WHERE pull_request_id = ANY($1)
+		ORDER BY array_position($1::varchar[], pull_request_id)
+	`
+
+	rows, err := repo.db.Pool.Query(ctx, query, prIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull requests: %w", err)
+	}
+	defer rows.Close()
+
 	prs := make([]*entity.PullRequest, 0, len(prIds))
-	for _, id := range prIds {
-		if pr, _ := repo.GetPullRequest(context.Background(), id); pr != nil {
-			prs = append(prs, pr)
+	for rows.Next() {
+		pr, err := scanPullRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pull request: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+
+	return prs, rows.Err()
+}
+
+// ListPullRequests выполняет запрос к БД и возвращает PR's,
+// удовлетворяющие фильтру (сначала самые новые)
+func (repo *PostgresPullRequestRepository) ListPullRequests(ctx context.Context, filter *dto.PullRequestFilter) ([]*entity.PullRequest, error) {
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Status != "" {
+		addCondition("pr_status = $%d", string(filter.Status))
+	}
+	if filter.AuthorID != "" {
+		addCondition("author_id = $%d", filter.AuthorID)
+	}
+	if filter.Repository != "" {
+		addCondition("repository = $%d", filter.Repository)
+	}
+	if filter.Label != "" {
+		addCondition("labels @> ARRAY[$%d::text]", filter.Label)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+		SELECT pull_request_id, pull_request_name, author_id, pr_status, created_at, merged_at, version,
+			repository, source_branch, target_branch, url, labels, lines_changed
+		FROM pull_requests
+		%s
+		ORDER BY created_at DESC NULLS LAST, pull_request_id
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args))
+
+	rows, err := repo.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	prs := make([]*entity.PullRequest, 0)
+	for rows.Next() {
+		pr, err := scanPullRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pull request: %w", err)
 		}
+		prs = append(prs, pr)
 	}
 
-	return prs, nil
+	return prs, rows.Err()
 }
 
 // SavePullRequest сохраняет PR в БД
 func (repo *PostgresPullRequestRepository) SavePullRequest(ctx context.Context, pr *entity.PullRequest) error {
-	query := `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, pr_status, created_at, merged_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`
-
-	_, err := repo.db.Pool.Exec(ctx, query,
-		pr.PullRequestID,
-		pr.PullRequestName,
-		pr.AuthorID, pr.Status,
-		pr.CreatedAt,
-		pr.MergedAt,
-	)
+	err := repo.db.Pool.QueryRow(ctx, insertPullRequestQuery, pullRequestInsertArgs(pr)...).Scan(&pr.Version)
 
 	if err != nil {
 		return fmt.Errorf("failed to save pull request: %w", err)
@@ -100,30 +156,51 @@ func (repo *PostgresPullRequestRepository) SavePullRequest(ctx context.Context,
 	return nil
 }
 
-// UpdatePullRequest выполняет запрос к БД для обновления
-// изменяемой информации о PR
+// SavePullRequestsTx сохраняет PR's prs в транзакции tx одним пакетом запросов,
+// чтобы вызывающий мог сохранить их вместе с записями других таблиц
+func SavePullRequestsTx(ctx context.Context, tx pgx.Tx, prs []*entity.PullRequest) error {
+	batch := &pgx.Batch{}
+	for _, pr := range prs {
+		batch.Queue(insertPullRequestQuery, pullRequestInsertArgs(pr)...).
+			QueryRow(func(row pgx.Row) error {
+				return row.Scan(&pr.Version)
+			})
+	}
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to save pull requests: %w", err)
+	}
+
+	return nil
+}
+
+// UpdatePullRequest выполняет запрос к БД для обновления изменяемой
+// информации о PR, если его версия в БД совпадает с pr.Version
+// (иначе - entity.ErrStaleVersion), и увеличивает версию PR
 func (repo *PostgresPullRequestRepository) UpdatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
 	query := `
 		UPDATE pull_requests 
-		SET pull_request_name = $1, author_id = $2, pr_status = $3, created_at = $4, merged_at = $5
-		WHERE pull_request_id = $6;
+		SET pull_request_name = $1, author_id = $2, pr_status = $3, created_at = $4, merged_at = $5, version = version + 1
+		WHERE pull_request_id = $6 AND version = $7
+		RETURNING version;
 	`
 
-	result, err := repo.db.Pool.Exec(ctx, query,
+	err := repo.db.Pool.QueryRow(ctx, query,
 		pr.PullRequestName,
 		pr.AuthorID,
 		string(pr.Status),
 		pr.CreatedAt,
 		pr.MergedAt,
 		pr.PullRequestID,
-	)
+		pr.Version,
+	).Scan(&pr.Version)
 
-	if err != nil {
-		return fmt.Errorf("failed to update pull request: %w", err)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to update pull request %s: %w", pr.PullRequestID, entity.ErrStaleVersion)
 	}
 
-	if result.RowsAffected() == 0 {
-		return errors.New("pr not found")
+	if err != nil {
+		return fmt.Errorf("failed to update pull request: %w", err)
 	}
 
 	return nil
@@ -147,20 +224,220 @@ func (repo *PostgresPullRequestRepository) GetOpenedPullRequestCount(ctx context
 	return count, nil
 }
 
-// GetMergedPullRequestCount выполняет запрос к БД для
-// получения числа PR в статусе MERGED
-func (repo *PostgresPullRequestRepository) GetMergedPullRequestCount(ctx context.Context) (int, error) {
+// SaveVerdict выполняет запрос к БД для сохранения решения ревьюера по PR
+func (repo *PostgresPullRequestRepository) SaveVerdict(ctx context.Context, verdict *entity.ReviewVerdict) error {
 	query := `
-		SELECT COUNT(*)
+		INSERT INTO review_verdicts (pull_request_id, user_id, verdict, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := repo.db.Pool.Exec(ctx, query,
+		verdict.PullRequestID, verdict.UserID, verdict.Verdict, verdict.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save review verdict: %w", err)
+	}
+
+	return nil
+}
+
+// GetPullRequestCountByStatus выполняет запрос к БД для получения
+// числа PR's в каждом статусе среди PR's, созданных в заданном временном окне
+func (repo *PostgresPullRequestRepository) GetPullRequestCountByStatus(
+	ctx context.Context,
+	filter *dto.StatsFilter,
+) (map[entity.PullRequestStatus]int, error) {
+	query := `
+		SELECT pr_status, COUNT(*)
+		FROM pull_requests
+		WHERE created_at >= $1 AND created_at < $2
+			AND ($3::varchar[] IS NULL OR author_id = ANY($3))
+		GROUP BY pr_status
+	`
+
+	rows, err := repo.db.Pool.Query(ctx, query, filter.From, filter.To, filter.UserIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR count by status: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[entity.PullRequestStatus]int)
+	for rows.Next() {
+		var (
+			status entity.PullRequestStatus
+			count  int
+		)
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan PR count by status: %w", err)
+		}
+		counts[status] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// GetMergeTimeStats выполняет запрос к БД для получения медианы и
+// 90-го перцентиля времени от создания до слияния PR's,
+// слитых в заданном временном окне
+func (repo *PostgresPullRequestRepository) GetMergeTimeStats(ctx context.Context, filter *dto.StatsFilter) (*dto.MergeTimeStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM merged_at - created_at)),
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM merged_at - created_at))
 		FROM pull_requests
 		WHERE pr_status = 'MERGED'
+			AND merged_at >= $1 AND merged_at < $2
+			AND ($3::varchar[] IS NULL OR author_id = ANY($3))
 	`
 
-	var count int
-	err := repo.db.Pool.QueryRow(ctx, query).Scan(&count)
+	var stats dto.MergeTimeStats
+	err := repo.db.Pool.QueryRow(ctx, query, filter.From, filter.To, filter.UserIDs).Scan(
+		&stats.MergedCount,
+		&stats.MedianSeconds,
+		&stats.P90Seconds,
+	)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get merged PR count: %w", err)
+		return nil, fmt.Errorf("failed to get merge time stats: %w", err)
 	}
 
-	return count, nil
+	return &stats, nil
+}
+
+// GetFirstVerdictTimeStats выполняет запрос к БД для получения медианы
+// и 90-го перцентиля времени от создания PR до первого решения ревьюера
+// по нему (для PR's, первое решение по которым вынесено в заданном временном окне)
+func (repo *PostgresPullRequestRepository) GetFirstVerdictTimeStats(
+	ctx context.Context,
+	filter *dto.StatsFilter,
+) (*dto.FirstVerdictTimeStats, error) {
+	query := `
+		WITH first_verdicts AS (
+			SELECT pull_request_id, MIN(created_at) AS first_verdict_at
+			FROM review_verdicts
+			GROUP BY pull_request_id
+		)
+		SELECT
+			COUNT(*),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM fv.first_verdict_at - pr.created_at)),
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM fv.first_verdict_at - pr.created_at))
+		FROM first_verdicts fv
+		JOIN pull_requests pr ON pr.pull_request_id = fv.pull_request_id
+		WHERE fv.first_verdict_at >= $1 AND fv.first_verdict_at < $2
+			AND ($3::varchar[] IS NULL OR pr.author_id = ANY($3))
+	`
+
+	var stats dto.FirstVerdictTimeStats
+	err := repo.db.Pool.QueryRow(ctx, query, filter.From, filter.To, filter.UserIDs).Scan(
+		&stats.PullRequestsCount,
+		&stats.MedianSeconds,
+		&stats.P90Seconds,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get first verdict time stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// GetThroughput выполняет запрос к БД для получения числа открытых
+// и слитых PR's в заданном временном окне, сгруппированных по дням или неделям (UTC)
+func (repo *PostgresPullRequestRepository) GetThroughput(ctx context.Context, filter *dto.StatsFilter) ([]*dto.ThroughputBucket, error) {
+	query := `
+		WITH events AS (
+			SELECT date_trunc($1, created_at AT TIME ZONE 'UTC') AS period, 1 AS opened, 0 AS merged
+			FROM pull_requests
+			WHERE created_at >= $2 AND created_at < $3
+				AND ($4::varchar[] IS NULL OR author_id = ANY($4))
+			UNION ALL
+			SELECT date_trunc($1, merged_at AT TIME ZONE 'UTC'), 0, 1
+			FROM pull_requests
+			WHERE pr_status = 'MERGED'
+				AND merged_at >= $2 AND merged_at < $3
+				AND ($4::varchar[] IS NULL OR author_id = ANY($4))
+		)
+		SELECT period AT TIME ZONE 'UTC', SUM(opened), SUM(merged)
+		FROM events
+		GROUP BY period
+		ORDER BY period
+	`
+
+	rows, err := repo.db.Pool.Query(ctx, query,
+		string(filter.Granularity),
+		filter.From,
+		filter.To,
+		filter.UserIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR throughput: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := make([]*dto.ThroughputBucket, 0)
+	for rows.Next() {
+		var bucket dto.ThroughputBucket
+		if err := rows.Scan(&bucket.PeriodStart, &bucket.Opened, &bucket.Merged); err != nil {
+			return nil, fmt.Errorf("failed to scan PR throughput: %w", err)
+		}
+		bucket.PeriodStart = bucket.PeriodStart.UTC()
+		buckets = append(buckets, &bucket)
+	}
+
+	return buckets, rows.Err()
+}
+
+func scanPullRequest(row pgx.Row) (*entity.PullRequest, error) {
+	var pr entity.PullRequest
+	err := row.Scan(
+		&pr.PullRequestID,
+		&pr.PullRequestName,
+		&pr.AuthorID,
+		&pr.Status,
+		&pr.CreatedAt,
+		&pr.MergedAt,
+		&pr.Version,
+		&pr.Repository,
+		&pr.SourceBranch,
+		&pr.TargetBranch,
+		&pr.URL,
+		&pr.Labels,
+		&pr.LinesChanged,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pr, nil
+}
+
+// insertPullRequestQuery - запрос вставки PR (первой версии),
+// возвращающий версию сохраненного PR
+const insertPullRequestQuery = `
+	INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, pr_status, created_at, merged_at,
+		repository, source_branch, target_branch, url, labels, lines_changed)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	RETURNING version
+`
+
+// pullRequestInsertArgs возвращает аргументы запроса вставки PR
+// (отсутствующие метки сохраняются пустым массивом)
+func pullRequestInsertArgs(pr *entity.PullRequest) []any {
+	labels := pr.Labels
+	if labels == nil {
+		labels = make([]string, 0)
+	}
+
+	return []any{
+		pr.PullRequestID,
+		pr.PullRequestName,
+		pr.AuthorID,
+		pr.Status,
+		pr.CreatedAt,
+		pr.MergedAt,
+		pr.Repository,
+		pr.SourceBranch,
+		pr.TargetBranch,
+		pr.URL,
+		labels,
+		pr.LinesChanged,
+	}
 }
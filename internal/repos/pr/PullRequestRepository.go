@@ -5,6 +5,7 @@ package pr
 import (
 	"context"
 
+	"github.com/salex06/pr-service/internal/dto"
 	"github.com/salex06/pr-service/internal/entity"
 )
 
@@ -15,10 +16,17 @@ type PullRequestRepository interface {
 
 	GetPullRequest(ctx context.Context, prID string) (*entity.PullRequest, error)
 	GetPullRequests(ctx context.Context, prIds []string) ([]*entity.PullRequest, error)
+	ListPullRequests(ctx context.Context, filter *dto.PullRequestFilter) ([]*entity.PullRequest, error)
 
 	SavePullRequest(ctx context.Context, pr *entity.PullRequest) error
 	UpdatePullRequest(ctx context.Context, pr *entity.PullRequest) error
 
+	SaveVerdict(ctx context.Context, verdict *entity.ReviewVerdict) error
+
 	GetOpenedPullRequestCount(ctx context.Context) (int, error)
-	GetMergedPullRequestCount(ctx context.Context) (int, error)
+	GetPullRequestCountByStatus(ctx context.Context, filter *dto.StatsFilter) (map[entity.PullRequestStatus]int, error)
+
+	GetMergeTimeStats(ctx context.Context, filter *dto.StatsFilter) (*dto.MergeTimeStats, error)
+	GetThroughput(ctx context.Context, filter *dto.StatsFilter) ([]*dto.ThroughputBucket, error)
+	GetFirstVerdictTimeStats(ctx context.Context, filter *dto.StatsFilter) (*dto.FirstVerdictTimeStats, error)
 }
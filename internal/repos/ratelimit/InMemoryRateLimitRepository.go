@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// sweepPeriod - период удаления корзин, которые пополнились полностью
+// (такая корзина ничем не отличается от отсутствующей)
+const sweepPeriod = time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	limit     entity.RateLimit
+}
+
+// refill пополняет корзину к моменту now
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.updatedAt); elapsed > 0 {
+		b.tokens = min(float64(b.limit.Burst), b.tokens+elapsed.Seconds()*b.limit.Rate)
+		b.updatedAt = now
+	}
+}
+
+// InMemoryRateLimitRepository представляет собой компонент,
+// отвечающий за хранение корзин токенов в памяти процесса (map)
+type InMemoryRateLimitRepository struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewInMemoryRateLimitRepository конструирует и возвращает объект InMemoryRateLimitRepository
+func NewInMemoryRateLimitRepository() *InMemoryRateLimitRepository {
+	return &InMemoryRateLimitRepository{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// TakeToken списывает токен из корзины key (новая корзина создается полной)
+func (repo *InMemoryRateLimitRepository) TakeToken(ctx context.Context, key string, limit entity.RateLimit, now time.Time) (*entity.RateLimitDecision, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.sweep(now)
+
+	b, ok := repo.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updatedAt: now}
+		repo.buckets[key] = b
+	}
+	b.limit = limit
+	b.refill(now)
+
+	if b.tokens < 1 {
+		return &entity.RateLimitDecision{RetryAfter: limit.RefillTime(1 - b.tokens)}, nil
+	}
+
+	b.tokens--
+	return &entity.RateLimitDecision{Allowed: true, Remaining: int(b.tokens)}, nil
+}
+
+func (repo *InMemoryRateLimitRepository) sweep(now time.Time) {
+	if now.Sub(repo.lastSweep) < sweepPeriod {
+		return
+	}
+	repo.lastSweep = now
+
+	for key, b := range repo.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Burst) {
+			delete(repo.buckets, key)
+		}
+	}
+}
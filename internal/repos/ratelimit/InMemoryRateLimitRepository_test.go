@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+func TestTakeTokenRefillsBucket(t *testing.T) {
+	repo := NewInMemoryRateLimitRepository()
+	limit := entity.RateLimit{Rate: 2, Burst: 3}
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	take := func(at time.Duration) *entity.RateLimitDecision {
+		t.Helper()
+
+		decision, err := repo.TakeToken(context.Background(), "client", limit, start.Add(at))
+		if err != nil {
+			t.Fatalf("TakeToken: %v", err)
+		}
+		return decision
+	}
+
+	// Новая корзина полная: Burst запросов подряд разрешены
+	for i := range limit.Burst {
+		decision := take(0)
+		if !decision.Allowed || decision.Remaining != limit.Burst-1-i {
+			t.Fatalf("request %d: %+v, want allowed with %d remaining", i+1, decision, limit.Burst-1-i)
+		}
+	}
+
+	decision := take(0)
+	if decision.Allowed || decision.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("request over burst: %+v, want rejected with retry after 500ms", decision)
+	}
+
+	// За 250ms корзина пополняется на полтокена - запрос еще отклоняется
+	decision = take(250 * time.Millisecond)
+	if decision.Allowed || decision.RetryAfter != 250*time.Millisecond {
+		t.Fatalf("request after 250ms: %+v, want rejected with retry after 250ms", decision)
+	}
+
+	if decision = take(500 * time.Millisecond); !decision.Allowed || decision.Remaining != 0 {
+		t.Fatalf("request after 500ms: %+v, want allowed with 0 remaining", decision)
+	}
+
+	// После долгого простоя корзина пополняется не больше чем до Burst
+	if decision = take(time.Hour); !decision.Allowed || decision.Remaining != limit.Burst-1 {
+		t.Fatalf("request after an hour: %+v, want allowed with %d remaining", decision, limit.Burst-1)
+	}
+}
+
+func TestTakeTokenKeepsBucketsPerKey(t *testing.T) {
+	repo := NewInMemoryRateLimitRepository()
+	limit := entity.RateLimit{Rate: 1, Burst: 1}
+	now := time.Now()
+
+	for _, key := range []string{"a", "b"} {
+		decision, err := repo.TakeToken(context.Background(), key, limit, now)
+		if err != nil {
+			t.Fatalf("TakeToken: %v", err)
+		}
+		if !decision.Allowed {
+			t.Fatalf("first request of %s rejected", key)
+		}
+	}
+
+	decision, err := repo.TakeToken(context.Background(), "a", limit, now)
+	if err != nil {
+		t.Fatalf("TakeToken: %v", err)
+	}
+	if decision.Allowed {
+		t.Fatal("second request of a allowed over burst")
+	}
+}
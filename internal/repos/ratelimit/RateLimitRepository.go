@@ -0,0 +1,20 @@
+// Package ratelimit - пакет с хранилищами корзин токенов,
+// по которым ограничивается частота запросов клиентов
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// RateLimitRepository представляет интерфейс хранилища корзин токенов.
+// In-memory хранилище ограничивает запросы к одному экземпляру сервиса;
+// для общего бюджета нескольких реплик достаточно реализовать интерфейс
+// поверх разделяемого хранилища
+type RateLimitRepository interface {
+	// TakeToken пополняет корзину key к моменту now по правилам limit
+	// и списывает из нее один токен, если он есть
+	TakeToken(ctx context.Context, key string, limit entity.RateLimit, now time.Time) (*entity.RateLimitDecision, error)
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
 )
 
 // AssignedRevsRepository представляет собой интерфейс взаимодействия
@@ -15,8 +16,12 @@ type AssignedRevsRepository interface {
 	GetAssignedPullRequestIds(ctx context.Context, userID string) ([]string, error)
 	GetAssignedReviewersIds(ctx context.Context, pullRequestID string) ([]string, error)
 
-	GetAssignmentsCountByReviewerID(context.Context) ([]*dto.AssignmentsByUser, error)
+	GetAssignmentsCountByReviewerID(ctx context.Context, filter *dto.StatsFilter) ([]*dto.AssignmentsByUser, error)
+	GetReviewerAssignmentsCount(ctx context.Context, userIDs []string) ([]*dto.ReviewerAssignmentsCount, error)
+	GetReassignmentCount(ctx context.Context, filter *dto.StatsFilter) (int, error)
 
-	CreateAssignment(ctx context.Context, userID string, prID string) error
-	DeleteAssignment(ctx context.Context, userID string, prID string) error
+	GetAssignmentHistory(ctx context.Context, pullRequestID string) ([]*entity.AssignedReviewers, error)
+
+	CreateAssignment(ctx context.Context, userID string, prID string, reason entity.AssignmentReason) error
+	CloseAssignment(ctx context.Context, userID string, prID string, reason entity.AssignmentReason, replacedBy *string) error
 }
@@ -2,24 +2,34 @@ package reviewers
 
 import (
 	"context"
+	"errors"
+	"maps"
 	"slices"
+	"time"
 
 	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+	prRepos "github.com/salex06/pr-service/internal/repos/pr"
 )
 
 // InMemoryAssignedRevsRepository представляет собой компонент,
 // отвечающий за взаимодействие с in-memory хранилищем (map),
-// где находится информация о назначениях сотрудников на PR's
+// где находится информация о назначениях сотрудников на PR's.
+// Статусы PR's (для подсчета назначений на открытые PR's) берутся из prRepo
 type InMemoryAssignedRevsRepository struct {
-	storage    map[string][]string // userId - []pullRequestIds
-	storageRev map[string][]string // pullRequestId - []userIds
+	prRepo     prRepos.PullRequestRepository
+	storage    map[string][]string                    // userId - []pullRequestIds
+	storageRev map[string][]string                    // pullRequestId - []userIds
+	history    map[string][]*entity.AssignedReviewers // pullRequestId - все назначения
 }
 
 // NewInMemoryAssignedRevsRepository конструирует и возвращает объект InMemoryAssignedRevsRepository
-func NewInMemoryAssignedRevsRepository() *InMemoryAssignedRevsRepository {
+func NewInMemoryAssignedRevsRepository(prRepo prRepos.PullRequestRepository) *InMemoryAssignedRevsRepository {
 	return &InMemoryAssignedRevsRepository{
+		prRepo:     prRepo,
 		storage:    make(map[string][]string),
 		storageRev: make(map[string][]string),
+		history:    make(map[string][]*entity.AssignedReviewers),
 	}
 }
 
@@ -30,10 +40,18 @@ func (repo *InMemoryAssignedRevsRepository) GetAssignedPullRequestIds(ctx contex
 }
 
 // CreateAssignment сохраняет назначение сотрудника с
-// идентификатором userID на PR с идентификатором prID
-func (repo *InMemoryAssignedRevsRepository) CreateAssignment(ctx context.Context, userID, prID string) error {
+// идентификатором userID на PR с идентификатором prID по причине reason
+func (repo *InMemoryAssignedRevsRepository) CreateAssignment(ctx context.Context, userID, prID string, reason entity.AssignmentReason) error {
+	assignedAt := time.Now()
+
 	repo.storage[userID] = append(repo.storage[userID], prID)
 	repo.storageRev[prID] = append(repo.storageRev[prID], userID)
+	repo.history[prID] = append(repo.history[prID], &entity.AssignedReviewers{
+		UserID:        userID,
+		PullRequestID: prID,
+		AssignedAt:    &assignedAt,
+		Reason:        reason,
+	})
 	return nil
 }
 
@@ -43,23 +61,119 @@ func (repo *InMemoryAssignedRevsRepository) GetAssignedReviewersIds(ctx context.
 	return repo.storageRev[prID], nil
 }
 
-// DeleteAssignment удаляет назначение сотрудника
-// с идентификатором userID на PR с идентификатором prID
-func (repo *InMemoryAssignedRevsRepository) DeleteAssignment(ctx context.Context, userID, prID string) error {
+// GetAssignmentHistory возвращает все назначения сотрудников
+// на PR с идентификатором prID, включая снятые (в порядке назначения)
+func (repo *InMemoryAssignedRevsRepository) GetAssignmentHistory(ctx context.Context, prID string) ([]*entity.AssignedReviewers, error) {
+	return repo.history[prID], nil
+}
+
+// CloseAssignment снимает сотрудника с идентификатором userID
+// с PR с идентификатором prID, сохраняя запись о назначении
+// с временем и причиной снятия
+func (repo *InMemoryAssignedRevsRepository) CloseAssignment(
+	ctx context.Context,
+	userID, prID string,
+	reason entity.AssignmentReason,
+	replacedBy *string,
+) error {
+	idx := slices.IndexFunc(repo.history[prID], func(a *entity.AssignedReviewers) bool {
+		return a.UserID == userID && a.IsActive()
+	})
+	if idx < 0 {
+		return errors.New("assignment not found")
+	}
+
+	unassignedAt := time.Now()
+	assignment := repo.history[prID][idx]
+	assignment.UnassignedAt = &unassignedAt
+	assignment.UnassignReason = &reason
+	assignment.ReplacedBy = replacedBy
+
 	repo.storage[userID] = slices.DeleteFunc(repo.storage[userID], func(currPrId string) bool { return prID == currPrId })
 	repo.storageRev[prID] = slices.DeleteFunc(repo.storageRev[prID], func(currUserId string) bool { return currUserId == userID })
 
 	return nil
 }
 
-// GetAssignmentsCountByReviewerID возвращает набор пар
-// "идентификатор ревьюера - количество назначений на PR данного пользователя"
-func (repo *InMemoryAssignedRevsRepository) GetAssignmentsCountByReviewerID(ctx context.Context) ([]*dto.AssignmentsByUser, error) {
-	assignmentsByUsers := make([]*dto.AssignmentsByUser, 0, len(repo.storage))
-	for k, v := range repo.storage {
+// GetReviewerAssignmentsCount возвращает число действующих назначений
+// на открытые PR's и общее число назначений (включая снятые)
+// каждого из сотрудников userIDs
+func (repo *InMemoryAssignedRevsRepository) GetReviewerAssignmentsCount(
+	ctx context.Context,
+	userIDs []string,
+) ([]*dto.ReviewerAssignmentsCount, error) {
+	counts := make(map[string]*dto.ReviewerAssignmentsCount)
+	for prID, assignments := range repo.history {
+		pr, err := repo.prRepo.GetPullRequest(ctx, prID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range assignments {
+			if !slices.Contains(userIDs, a.UserID) {
+				continue
+			}
+			if _, ok := counts[a.UserID]; !ok {
+				counts[a.UserID] = &dto.ReviewerAssignmentsCount{UserID: a.UserID}
+			}
+			counts[a.UserID].TotalAssignments++
+			if a.IsActive() && pr != nil && pr.Status == entity.OPEN {
+				counts[a.UserID].OpenAssignments++
+			}
+		}
+	}
+
+	return slices.Collect(maps.Values(counts)), nil
+}
+
+// GetReassignmentCount возвращает число переназначений ревьюеров
+// в заданном временном окне (учитываются сотрудники из filter.UserIDs, которых сняли с PR)
+func (repo *InMemoryAssignedRevsRepository) GetReassignmentCount(ctx context.Context, filter *dto.StatsFilter) (int, error) {
+	count := 0
+	for _, assignments := range repo.history {
+		for _, a := range assignments {
+			if a.UnassignReason == nil || *a.UnassignReason != entity.ReassignAssignment {
+				continue
+			}
+			if a.UnassignedAt.Before(filter.From) || !a.UnassignedAt.Before(filter.To) {
+				continue
+			}
+			if filter.UserIDs != nil && !slices.Contains(filter.UserIDs, a.UserID) {
+				continue
+			}
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// GetAssignmentsCountByReviewerID возвращает набор пар "идентификатор ревьюера -
+// количество действующих назначений на PR данного пользователя", созданных
+// в заданном временном окне (учитываются сотрудники из filter.UserIDs)
+func (repo *InMemoryAssignedRevsRepository) GetAssignmentsCountByReviewerID(
+	ctx context.Context,
+	filter *dto.StatsFilter,
+) ([]*dto.AssignmentsByUser, error) {
+	counts := make(map[string]int)
+	for _, assignments := range repo.history {
+		for _, a := range assignments {
+			if !a.IsActive() || a.AssignedAt == nil ||
+				a.AssignedAt.Before(filter.From) || !a.AssignedAt.Before(filter.To) {
+				continue
+			}
+			if filter.UserIDs != nil && !slices.Contains(filter.UserIDs, a.UserID) {
+				continue
+			}
+			counts[a.UserID]++
+		}
+	}
+
+	assignmentsByUsers := make([]*dto.AssignmentsByUser, 0, len(counts))
+	for k, v := range counts {
 		assignmentsByUsers = append(assignmentsByUsers, &dto.AssignmentsByUser{
 			UserID:           k,
-			AssignmentsCount: len(v),
+			AssignmentsCount: v,
 		})
 	}
 
@@ -2,10 +2,14 @@ package reviewers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
+
 	"github.com/salex06/pr-service/internal/database"
 	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
 )
 
 // PostgresAssignedRevsRepository представляет собой компонент,
@@ -26,7 +30,7 @@ func (repo *PostgresAssignedRevsRepository) GetAssignedPullRequestIds(ctx contex
 	query := `
 		SELECT pull_request_id 
 		FROM assigned_reviewers
-		WHERE user_id = $1;
+		WHERE user_id = $1 AND unassigned_at IS NULL;
 	`
 
 	rows, err := repo.db.Pool.Query(ctx, query, userID)
@@ -53,7 +57,8 @@ func (repo *PostgresAssignedRevsRepository) GetAssignedReviewersIds(ctx context.
 	query := `
 		SELECT user_id 
 		FROM assigned_reviewers
-		WHERE pull_request_id = $1;
+		WHERE pull_request_id = $1 AND unassigned_at IS NULL
+		ORDER BY assigned_at, id;
 	`
 
 	rows, err := repo.db.Pool.Query(ctx, query, pullRequestID)
@@ -75,15 +80,23 @@ func (repo *PostgresAssignedRevsRepository) GetAssignedReviewersIds(ctx context.
 }
 
 // GetAssignmentsCountByReviewerID выполняет запрос к БД для
-// получения набора пар "идентификатор ревьюера - количество назначений на PR данного пользователя"
-func (repo *PostgresAssignedRevsRepository) GetAssignmentsCountByReviewerID(ctx context.Context) ([]*dto.AssignmentsByUser, error) {
+// получения набора пар "идентификатор ревьюера - количество действующих назначений
+// на PR данного пользователя", созданных в заданном временном окне
+// (учитываются сотрудники из filter.UserIDs)
+func (repo *PostgresAssignedRevsRepository) GetAssignmentsCountByReviewerID(
+	ctx context.Context,
+	filter *dto.StatsFilter,
+) ([]*dto.AssignmentsByUser, error) {
 	query := `
 		SELECT user_id, COUNT (*)
 		FROM assigned_reviewers
+		WHERE unassigned_at IS NULL
+			AND assigned_at >= $1 AND assigned_at < $2
+			AND ($3::varchar[] IS NULL OR user_id = ANY($3))
 		GROUP BY user_id
 	`
 
-	rows, err := repo.db.Pool.Query(ctx, query)
+	rows, err := repo.db.Pool.Query(ctx, query, filter.From, filter.To, filter.UserIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to group assignments count by user: %w", err)
 	}
@@ -101,18 +114,120 @@ func (repo *PostgresAssignedRevsRepository) GetAssignmentsCountByReviewerID(ctx
 	return assignmentsByUsers, nil
 }
 
+// GetReviewerAssignmentsCount выполняет запрос к БД для получения числа
+// действующих назначений на открытые PR's и общего числа назначений
+// (включая снятые) каждого из сотрудников userIDs
+func (repo *PostgresAssignedRevsRepository) GetReviewerAssignmentsCount(
+	ctx context.Context,
+	userIDs []string,
+) ([]*dto.ReviewerAssignmentsCount, error) {
+	query := `
+		SELECT
+			ar.user_id,
+			COUNT(*) FILTER (WHERE ar.unassigned_at IS NULL AND pr.pr_status = 'OPEN'),
+			COUNT(*)
+		FROM assigned_reviewers ar
+		JOIN pull_requests pr ON pr.pull_request_id = ar.pull_request_id
+		WHERE ar.user_id = ANY($1)
+		GROUP BY ar.user_id
+	`
+
+	rows, err := repo.db.Pool.Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer assignments count: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make([]*dto.ReviewerAssignmentsCount, 0, len(userIDs))
+	for rows.Next() {
+		var currRow dto.ReviewerAssignmentsCount
+		if err := rows.Scan(&currRow.UserID, &currRow.OpenAssignments, &currRow.TotalAssignments); err != nil {
+			return nil, fmt.Errorf("failed to get reviewer assignments count: %w", err)
+		}
+		counts = append(counts, &currRow)
+	}
+
+	return counts, rows.Err()
+}
+
+// GetReassignmentCount выполняет запрос к БД для получения числа
+// переназначений ревьюеров в заданном временном окне
+// (учитываются сотрудники из filter.UserIDs, которых сняли с PR)
+func (repo *PostgresAssignedRevsRepository) GetReassignmentCount(ctx context.Context, filter *dto.StatsFilter) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM assigned_reviewers
+		WHERE unassign_reason = $1
+			AND unassigned_at >= $2 AND unassigned_at < $3
+			AND ($4::varchar[] IS NULL OR user_id = ANY($4))
+	`
+
+	var count int
+	err := repo.db.Pool.QueryRow(ctx, query,
+		string(entity.ReassignAssignment),
+		filter.From,
+		filter.To,
+		filter.UserIDs,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get reassignment count: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetAssignmentHistory выполняет запрос к БД и возвращает все назначения
+// сотрудников на данный PR, включая снятые (в порядке назначения)
+func (repo *PostgresAssignedRevsRepository) GetAssignmentHistory(
+	ctx context.Context,
+	pullRequestID string,
+) ([]*entity.AssignedReviewers, error) {
+	query := `
+		SELECT user_id, pull_request_id, assigned_at, unassigned_at, reason, unassign_reason, replaced_by
+		FROM assigned_reviewers
+		WHERE pull_request_id = $1
+		ORDER BY assigned_at, id;
+	`
+
+	rows, err := repo.db.Pool.Query(ctx, query, pullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment history: %w", err)
+	}
+	defer rows.Close()
+
+	history := make([]*entity.AssignedReviewers, 0)
+	for rows.Next() {
+		var assignment entity.AssignedReviewers
+		if err := rows.Scan(
+			&assignment.UserID,
+			&assignment.PullRequestID,
+			&assignment.AssignedAt,
+			&assignment.UnassignedAt,
+			&assignment.Reason,
+			&assignment.UnassignReason,
+			&assignment.ReplacedBy,
+		); err != nil {
+			return nil, fmt.Errorf("failed to get assignment history: %w", err)
+		}
+		history = append(history, &assignment)
+	}
+
+	return history, nil
+}
+
 // CreateAssignment выполняет запрос к БД для
 // назначения сотрудника с идентификатором userID
-// на PR с идентификатором prID
-func (repo *PostgresAssignedRevsRepository) CreateAssignment(ctx context.Context, userID, prID string) error {
+// на PR с идентификатором prID по причине reason
+func (repo *PostgresAssignedRevsRepository) CreateAssignment(ctx context.Context, userID, prID string, reason entity.AssignmentReason) error {
 	query := `
-		INSERT INTO assigned_reviewers (user_id, pull_request_id)
-		VALUES ($1, $2);
+		INSERT INTO assigned_reviewers (user_id, pull_request_id, reason)
+		VALUES ($1, $2, $3);
 	`
 
 	_, err := repo.db.Pool.Exec(ctx, query,
 		userID,
 		prID,
+		string(reason),
 	)
 
 	if err != nil {
@@ -122,22 +237,55 @@ func (repo *PostgresAssignedRevsRepository) CreateAssignment(ctx context.Context
 	return nil
 }
 
-// DeleteAssignment выполняет запрос к БД для удаления
-// назначения сотрудника с идентификатором userID на PR
-// с идентификатором prID
-func (repo *PostgresAssignedRevsRepository) DeleteAssignment(ctx context.Context, userID, prID string) error {
+// CreateAssignmentsTx сохраняет назначения assignments в транзакции tx одним
+// пакетом запросов, чтобы вызывающий мог сохранить их вместе с записями других таблиц
+func CreateAssignmentsTx(ctx context.Context, tx pgx.Tx, assignments []*entity.AssignedReviewers) error {
 	query := `
-		DELETE FROM assigned_reviewers
-		WHERE user_id = $1 AND pull_request_id = $2
+		INSERT INTO assigned_reviewers (user_id, pull_request_id, reason)
+		VALUES ($1, $2, $3);
 	`
 
-	_, err := repo.db.Pool.Exec(ctx, query,
+	batch := &pgx.Batch{}
+	for _, assignment := range assignments {
+		batch.Queue(query, assignment.UserID, assignment.PullRequestID, string(assignment.Reason))
+	}
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to create assignments: %w", err)
+	}
+
+	return nil
+}
+
+// CloseAssignment выполняет запрос к БД для снятия сотрудника
+// с идентификатором userID с PR с идентификатором prID.
+// Запись о назначении сохраняется с временем и причиной снятия
+// и идентификатором сотрудника, назначенного на замену
+func (repo *PostgresAssignedRevsRepository) CloseAssignment(
+	ctx context.Context,
+	userID, prID string,
+	reason entity.AssignmentReason,
+	replacedBy *string,
+) error {
+	query := `
+		UPDATE assigned_reviewers
+		SET unassigned_at = CURRENT_TIMESTAMP, unassign_reason = $1, replaced_by = $2
+		WHERE user_id = $3 AND pull_request_id = $4 AND unassigned_at IS NULL
+	`
+
+	result, err := repo.db.Pool.Exec(ctx, query,
+		string(reason),
+		replacedBy,
 		userID,
 		prID,
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to delete assignment: %w", err)
+		return fmt.Errorf("failed to close assignment: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("assignment not found")
 	}
 
 	return nil
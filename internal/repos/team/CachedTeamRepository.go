@@ -0,0 +1,69 @@
+package team
+
+import (
+	"context"
+	"time"
+
+	"github.com/salex06/pr-service/internal/cache"
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// CachedTeamRepository представляет собой декоратор TeamRepository, который
+// кеширует команды, найденные по имени, на время ttl. Изменение команды
+// через репозиторий удаляет её из кеша (даже если изменение не удалось)
+type CachedTeamRepository struct {
+	next  *TeamRepository
+	teams *cache.Cache[entity.Team]
+}
+
+// NewCachedTeamRepository конструирует и возвращает объект CachedTeamRepository
+func NewCachedTeamRepository(next *TeamRepository, ttl time.Duration, observer cache.Observer) TeamRepository {
+	return &CachedTeamRepository{
+		next:  next,
+		teams: cache.New[entity.Team]("team", ttl, observer),
+	}
+}
+
+// TeamExists проверяет существование команды (найденная в кеше команда существует)
+func (repo *CachedTeamRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	if _, ok := repo.teams.Get(teamName); ok {
+		return true, nil
+	}
+
+	return (*repo.next).TeamExists(ctx, teamName)
+}
+
+// SaveTeam сохраняет команду и удаляет её из кеша
+func (repo *CachedTeamRepository) SaveTeam(ctx context.Context, team *entity.Team) error {
+	defer repo.teams.Delete(team.TeamName)
+
+	return (*repo.next).SaveTeam(ctx, team)
+}
+
+// GetTeam возвращает копию команды из кеша, а при её отсутствии
+// загружает команду из хранилища и сохраняет в кеш
+func (repo *CachedTeamRepository) GetTeam(ctx context.Context, teamName string) (*entity.Team, error) {
+	if team, ok := repo.teams.Get(teamName); ok {
+		return &team, nil
+	}
+
+	team, err := (*repo.next).GetTeam(ctx, teamName)
+	if err != nil || team == nil {
+		return team, err
+	}
+	repo.teams.Set(teamName, *team)
+
+	return team, nil
+}
+
+// UpdateTeam обновляет команду и удаляет её из кеша
+func (repo *CachedTeamRepository) UpdateTeam(ctx context.Context, team *entity.Team) error {
+	defer repo.teams.Delete(team.TeamName)
+
+	return (*repo.next).UpdateTeam(ctx, team)
+}
+
+// GetTeamCount возвращает число команд (без кеширования)
+func (repo *CachedTeamRepository) GetTeamCount(ctx context.Context) (int, error) {
+	return (*repo.next).GetTeamCount(ctx)
+}
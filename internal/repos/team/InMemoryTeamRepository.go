@@ -2,6 +2,7 @@ package team
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/salex06/pr-service/internal/entity"
 )
@@ -26,16 +27,38 @@ func (db *InMemoryTeamRepository) TeamExists(ctx context.Context, teamName strin
 	return ok, nil
 }
 
-// SaveTeam сохраняет команду в map по заданному имени
+// SaveTeam сохраняет команду (первой версии) в map по заданному имени
 func (db *InMemoryTeamRepository) SaveTeam(ctx context.Context, team *entity.Team) error {
-	db.storage[team.TeamName] = team
+	team.Version = 1
+	saved := *team
+	db.storage[team.TeamName] = &saved
 
 	return nil
 }
 
-// GetTeam возвращает команду с заданным именем (nil - если не найдена)
+// GetTeam возвращает копию команды с заданным именем (nil - если не найдена)
 func (db *InMemoryTeamRepository) GetTeam(ctx context.Context, teamName string) (*entity.Team, error) {
-	return db.storage[teamName], nil
+	team, ok := db.storage[teamName]
+	if !ok {
+		return nil, nil
+	}
+
+	found := *team
+	return &found, nil
+}
+
+// UpdateTeam увеличивает версию команды, если её версия в хранилище
+// совпадает с team.Version (иначе - entity.ErrStaleVersion)
+func (db *InMemoryTeamRepository) UpdateTeam(ctx context.Context, team *entity.Team) error {
+	if stored, ok := db.storage[team.TeamName]; !ok || stored.Version != team.Version {
+		return fmt.Errorf("failed to update team %s: %w", team.TeamName, entity.ErrStaleVersion)
+	}
+
+	team.Version++
+	updated := *team
+	db.storage[team.TeamName] = &updated
+
+	return nil
 }
 
 // GetTeamCount возвращает общее количество команд
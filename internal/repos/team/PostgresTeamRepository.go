@@ -41,9 +41,10 @@ func (repo *PostgresTeamRepository) SaveTeam(ctx context.Context, team *entity.T
 	query := `
 		INSERT INTO teams (team_name)
 		VALUES ($1)
+		RETURNING version
 	`
 
-	_, err := repo.db.Pool.Exec(ctx, query, team.TeamName)
+	err := repo.db.Pool.QueryRow(ctx, query, team.TeamName).Scan(&team.Version)
 
 	if err != nil {
 		return fmt.Errorf("failed to save team: %w", err)
@@ -56,13 +57,14 @@ func (repo *PostgresTeamRepository) SaveTeam(ctx context.Context, team *entity.T
 // команду с заданным именем (nil - если не найдена)
 func (repo *PostgresTeamRepository) GetTeam(ctx context.Context, teamName string) (*entity.Team, error) {
 	query := `
-		SELECT team_name FROM teams
+		SELECT team_name, version FROM teams
 		WHERE team_name = $1
 	`
 
 	var team entity.Team
 	err := repo.db.Pool.QueryRow(ctx, query, teamName).Scan(
 		&team.TeamName,
+		&team.Version,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -76,6 +78,29 @@ func (repo *PostgresTeamRepository) GetTeam(ctx context.Context, teamName string
 	return &team, nil
 }
 
+// UpdateTeam выполняет запрос к БД, увеличивающий версию команды
+// (фиксирует изменение состава или участников команды), если её версия
+// в БД совпадает с team.Version (иначе - entity.ErrStaleVersion)
+func (repo *PostgresTeamRepository) UpdateTeam(ctx context.Context, team *entity.Team) error {
+	query := `
+		UPDATE teams
+		SET version = version + 1
+		WHERE team_name = $1 AND version = $2
+		RETURNING version
+	`
+
+	err := repo.db.Pool.QueryRow(ctx, query, team.TeamName, team.Version).Scan(&team.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to update team %s: %w", team.TeamName, entity.ErrStaleVersion)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to update team: %w", err)
+	}
+
+	return nil
+}
+
 // GetTeamCount выполняет запрос к БД для
 // получения общего количества команд
 func (repo *PostgresTeamRepository) GetTeamCount(ctx context.Context) (int, error) {
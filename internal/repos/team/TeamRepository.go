@@ -14,6 +14,7 @@ type TeamRepository interface {
 	TeamExists(ctx context.Context, teamName string) (bool, error)
 	SaveTeam(ctx context.Context, team *entity.Team) error
 	GetTeam(ctx context.Context, teamName string) (*entity.Team, error)
+	UpdateTeam(ctx context.Context, team *entity.Team) error
 
 	GetTeamCount(ctx context.Context) (int, error)
 }
@@ -0,0 +1,134 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/salex06/pr-service/internal/cache"
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// CachedUserRepository представляет собой декоратор UserRepository, который
+// кеширует пользователей (по идентификатору) и составы команд на время ttl.
+// Изменение пользователя через репозиторий удаляет его из кеша вместе со
+// всеми составами команд, так как пользователь мог перейти в другую команду.
+// Подбор ревьюеров и агрегирующие запросы не кешируются
+type CachedUserRepository struct {
+	next    *UserRepository
+	users   *cache.Cache[entity.User]
+	members *cache.Cache[[]entity.User]
+}
+
+// NewCachedUserRepository конструирует и возвращает объект CachedUserRepository
+func NewCachedUserRepository(next *UserRepository, ttl time.Duration, observer cache.Observer) UserRepository {
+	return &CachedUserRepository{
+		next:    next,
+		users:   cache.New[entity.User]("user", ttl, observer),
+		members: cache.New[[]entity.User]("team_members", ttl, observer),
+	}
+}
+
+// GetUser возвращает копию пользователя из кеша, а при его отсутствии
+// загружает пользователя из хранилища и сохраняет в кеш
+func (repo *CachedUserRepository) GetUser(ctx context.Context, userID string) (*entity.User, error) {
+	if user, ok := repo.users.Get(userID); ok {
+		return &user, nil
+	}
+
+	user, err := (*repo.next).GetUser(ctx, userID)
+	if err != nil || user == nil {
+		return user, err
+	}
+	repo.users.Set(userID, *user)
+
+	return user, nil
+}
+
+// UpdateUser обновляет пользователя и сбрасывает связанные с ним записи кеша
+func (repo *CachedUserRepository) UpdateUser(ctx context.Context, user *entity.User) error {
+	defer repo.invalidate(user.UserID)
+
+	return (*repo.next).UpdateUser(ctx, user)
+}
+
+// SaveUser сохраняет пользователя и сбрасывает связанные с ним записи кеша
+func (repo *CachedUserRepository) SaveUser(ctx context.Context, user *entity.User) error {
+	defer repo.invalidate(user.UserID)
+
+	return (*repo.next).SaveUser(ctx, user)
+}
+
+// UserExists проверяет существование пользователя (найденный в кеше пользователь существует)
+func (repo *CachedUserRepository) UserExists(ctx context.Context, userID string) (bool, error) {
+	if _, ok := repo.users.Get(userID); ok {
+		return true, nil
+	}
+
+	return (*repo.next).UserExists(ctx, userID)
+}
+
+// GetTotalUserCount возвращает общее число пользователей (без кеширования)
+func (repo *CachedUserRepository) GetTotalUserCount(ctx context.Context) (int, error) {
+	return (*repo.next).GetTotalUserCount(ctx)
+}
+
+// GetActiveUserCount возвращает число активных пользователей (без кеширования)
+func (repo *CachedUserRepository) GetActiveUserCount(ctx context.Context) (int, error) {
+	return (*repo.next).GetActiveUserCount(ctx)
+}
+
+// GetTeamMembers возвращает копии участников команды из кеша, а при их
+// отсутствии загружает состав команды из хранилища и сохраняет в кеш
+func (repo *CachedUserRepository) GetTeamMembers(ctx context.Context, teamName string) ([]*entity.User, error) {
+	if members, ok := repo.members.Get(teamName); ok {
+		return copyUsers(members), nil
+	}
+
+	members, err := (*repo.next).GetTeamMembers(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := make([]entity.User, 0, len(members))
+	for _, member := range members {
+		cached = append(cached, *member)
+	}
+	repo.members.Set(teamName, cached)
+
+	return members, nil
+}
+
+// GetDigestRecipients возвращает получателей дайджеста (без кеширования)
+func (repo *CachedUserRepository) GetDigestRecipients(ctx context.Context) ([]*entity.User, error) {
+	return (*repo.next).GetDigestRecipients(ctx)
+}
+
+// GetUserCountByTeam возвращает размеры команд (без кеширования)
+func (repo *CachedUserRepository) GetUserCountByTeam(ctx context.Context) ([]*dto.TeamSize, error) {
+	return (*repo.next).GetUserCountByTeam(ctx)
+}
+
+// ChooseReviewers подбирает ревьюеров по актуальным данным хранилища
+func (repo *CachedUserRepository) ChooseReviewers(ctx context.Context, prAuthor *entity.User) ([]string, error) {
+	return (*repo.next).ChooseReviewers(ctx, prAuthor)
+}
+
+// ReassignReviewer подбирает замену ревьюеру по актуальным данным хранилища
+func (repo *CachedUserRepository) ReassignReviewer(ctx context.Context, teamName string, idsExclusionList []string) (*string, error) {
+	return (*repo.next).ReassignReviewer(ctx, teamName, idsExclusionList)
+}
+
+func (repo *CachedUserRepository) invalidate(userID string) {
+	repo.users.Delete(userID)
+	repo.members.Clear()
+}
+
+func copyUsers(users []entity.User) []*entity.User {
+	copied := make([]*entity.User, 0, len(users))
+	for _, user := range users {
+		copied = append(copied, &user)
+	}
+
+	return copied
+}
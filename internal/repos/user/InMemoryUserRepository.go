@@ -23,25 +23,36 @@ func NewInMemoryUserRepository() *InMemoryUserRepository {
 	}
 }
 
-// GetUser возвращает пользователя с заданным userID (если не найден - nil)
+// GetUser возвращает копию пользователя с заданным userID (если не найден - nil)
 func (db *InMemoryUserRepository) GetUser(ctx context.Context, userID string) (*entity.User, error) {
 	if user, ok := db.storage[userID]; ok {
-		return user, nil
+		found := *user
+		return &found, nil
 	}
 
-	return nil, fmt.Errorf("user not found")
+	return nil, nil
 }
 
-// UpdateUser обновляет изменяемую информацию о пользователе
+// UpdateUser обновляет изменяемую информацию о пользователе, если его
+// версия в хранилище совпадает с user.Version (иначе - entity.ErrStaleVersion),
+// и увеличивает версию пользователя
 func (db *InMemoryUserRepository) UpdateUser(ctx context.Context, user *entity.User) error {
-	db.storage[user.UserID] = user
+	if stored, ok := db.storage[user.UserID]; !ok || stored.Version != user.Version {
+		return fmt.Errorf("failed to update user %s: %w", user.UserID, entity.ErrStaleVersion)
+	}
+
+	user.Version++
+	updated := *user
+	db.storage[user.UserID] = &updated
 
 	return nil
 }
 
-// SaveUser сохраняет пользователя в in-memory хранилище
+// SaveUser сохраняет пользователя (первой версии) в in-memory хранилище
 func (db *InMemoryUserRepository) SaveUser(ctx context.Context, user *entity.User) error {
-	db.storage[user.UserID] = user
+	user.Version = 1
+	saved := *user
+	db.storage[user.UserID] = &saved
 
 	return nil
 }
@@ -97,12 +108,25 @@ func (db *InMemoryUserRepository) GetTeamMembers(ctx context.Context, teamName s
 	members := make([]*entity.User, 0)
 	for _, v := range db.storage {
 		if v.TeamName == teamName {
-			members = append(members, v)
+			member := *v
+			members = append(members, &member)
 		}
 	}
 	return members, nil
 }
 
+// GetDigestRecipients возвращает активных сотрудников с заданным
+// адресом электронной почты, не отказавшихся от рассылки дайджеста
+func (db *InMemoryUserRepository) GetDigestRecipients(ctx context.Context) ([]*entity.User, error) {
+	recipients := make([]*entity.User, 0)
+	for _, v := range db.storage {
+		if v.IsActive && !v.DigestOptOut && v.Email != nil {
+			recipients = append(recipients, v)
+		}
+	}
+	return recipients, nil
+}
+
 // GetTotalUserCount возвращает общее количество пользователей
 func (db *InMemoryUserRepository) GetTotalUserCount(ctx context.Context) (int, error) {
 	return len(db.storage), nil
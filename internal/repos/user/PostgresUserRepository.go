@@ -27,7 +27,7 @@ func NewPostgresUserRepository(db *database.DB) UserRepository {
 // GetUser возвращает пользователя с заданным userID (если не найден - nil)
 func (repo *PostgresUserRepository) GetUser(ctx context.Context, userID string) (*entity.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active FROM users
+		SELECT user_id, username, team_name, is_active, email, digest_opt_out, version FROM users
 		WHERE user_id = $1;
 	`
 
@@ -37,6 +37,9 @@ func (repo *PostgresUserRepository) GetUser(ctx context.Context, userID string)
 		&user.Username,
 		&user.TeamName,
 		&user.IsActive,
+		&user.Email,
+		&user.DigestOptOut,
+		&user.Version,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -50,28 +53,33 @@ func (repo *PostgresUserRepository) GetUser(ctx context.Context, userID string)
 	return &user, nil
 }
 
-// UpdateUser выполняет запрос к БД для обновления
-// изменяемой информации о пользователе
+// UpdateUser выполняет запрос к БД для обновления изменяемой информации
+// о пользователе, если его версия в БД совпадает с user.Version (иначе -
+// entity.ErrStaleVersion), и увеличивает версию пользователя
 func (repo *PostgresUserRepository) UpdateUser(ctx context.Context, user *entity.User) error {
 	query := `
 		UPDATE users 
-		SET username = $1, team_name = $2, is_active = $3
-		WHERE user_id = $4;
+		SET username = $1, team_name = $2, is_active = $3, email = $4, digest_opt_out = $5, version = version + 1
+		WHERE user_id = $6 AND version = $7
+		RETURNING version;
 	`
 
-	result, err := repo.db.Pool.Exec(ctx, query,
+	err := repo.db.Pool.QueryRow(ctx, query,
 		user.Username,
 		user.TeamName,
 		user.IsActive,
+		user.Email,
+		user.DigestOptOut,
 		user.UserID,
-	)
+		user.Version,
+	).Scan(&user.Version)
 
-	if err != nil {
-		return fmt.Errorf("failed to update user: %w", err)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to update user %s: %w", user.UserID, entity.ErrStaleVersion)
 	}
 
-	if result.RowsAffected() == 0 {
-		return errors.New("user not found")
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
 	}
 
 	return nil
@@ -80,16 +88,19 @@ func (repo *PostgresUserRepository) UpdateUser(ctx context.Context, user *entity
 // SaveUser сохраняет пользователя в БД
 func (repo *PostgresUserRepository) SaveUser(ctx context.Context, user *entity.User) error {
 	query := `
-		INSERT INTO users (user_id, username, team_name, is_active)
-		VALUES ($1, $2, $3, $4);
+		INSERT INTO users (user_id, username, team_name, is_active, email, digest_opt_out)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING version;
 	`
 
-	_, err := repo.db.Pool.Exec(ctx, query,
+	err := repo.db.Pool.QueryRow(ctx, query,
 		user.UserID,
 		user.Username,
 		user.TeamName,
 		user.IsActive,
-	)
+		user.Email,
+		user.DigestOptOut,
+	).Scan(&user.Version)
 
 	if err != nil {
 		return fmt.Errorf("failed to save user: %w", err)
@@ -150,7 +161,7 @@ func (repo *PostgresUserRepository) GetActiveUserCount(ctx context.Context) (int
 // сотрудников, которые являются членами заданной команды
 func (repo *PostgresUserRepository) GetTeamMembers(ctx context.Context, teamName string) ([]*entity.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active FROM users
+		SELECT user_id, username, team_name, is_active, email, digest_opt_out, version FROM users
 		WHERE team_name=$1; 
 	`
 
@@ -168,6 +179,9 @@ func (repo *PostgresUserRepository) GetTeamMembers(ctx context.Context, teamName
 			&member.Username,
 			&member.TeamName,
 			&member.IsActive,
+			&member.Email,
+			&member.DigestOptOut,
+			&member.Version,
 		); err != nil {
 			return nil, fmt.Errorf("failed to get team members: %w", err)
 		}
@@ -177,6 +191,41 @@ func (repo *PostgresUserRepository) GetTeamMembers(ctx context.Context, teamName
 	return members, nil
 }
 
+// GetDigestRecipients выполняет запрос к БД и возвращает
+// активных сотрудников с заданным адресом электронной почты,
+// не отказавшихся от рассылки дайджеста
+func (repo *PostgresUserRepository) GetDigestRecipients(ctx context.Context) ([]*entity.User, error) {
+	query := `
+		SELECT user_id, username, team_name, is_active, email, digest_opt_out, version FROM users
+		WHERE is_active AND NOT digest_opt_out AND email IS NOT NULL;
+	`
+
+	rows, err := repo.db.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []*entity.User
+	for rows.Next() {
+		var recipient entity.User
+		if err := rows.Scan(
+			&recipient.UserID,
+			&recipient.Username,
+			&recipient.TeamName,
+			&recipient.IsActive,
+			&recipient.Email,
+			&recipient.DigestOptOut,
+			&recipient.Version,
+		); err != nil {
+			return nil, fmt.Errorf("failed to get digest recipients: %w", err)
+		}
+		recipients = append(recipients, &recipient)
+	}
+
+	return recipients, nil
+}
+
 // GetUserCountByTeam выполняет запрос к БД и возвращает
 // количество пользователей в каждой команде
 func (repo *PostgresUserRepository) GetUserCountByTeam(ctx context.Context) ([]*dto.TeamSize, error) {
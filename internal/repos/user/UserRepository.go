@@ -21,6 +21,7 @@ type UserRepository interface {
 	GetActiveUserCount(ctx context.Context) (int, error)
 
 	GetTeamMembers(ctx context.Context, teamName string) ([]*entity.User, error)
+	GetDigestRecipients(ctx context.Context) ([]*entity.User, error)
 	GetUserCountByTeam(ctx context.Context) ([]*dto.TeamSize, error)
 
 	ChooseReviewers(ctx context.Context, prAuthor *entity.User) ([]string, error)
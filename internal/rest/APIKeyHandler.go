@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/service"
+)
+
+// APIKeyHandler представляет контроллер, который отвечает
+// за получение запросов на управление ключами доступа,
+// передачу на обработку в сервисы и формирование ответа
+type APIKeyHandler struct {
+	apiKeyService *service.APIKeyService
+}
+
+// NewAPIKeyHandler конструирует и возвращает объект APIKeyHandler
+func NewAPIKeyHandler(svc *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: svc,
+	}
+}
+
+// HandleCreateRequest отвечает за получение и формирование ответа
+// на запрос выпуска нового ключа доступа
+func (akh *APIKeyHandler) HandleCreateRequest(c *gin.Context) {
+	var req dto.CreateAPIKey
+	if parseErr := bindJSON(c, &req); parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
+		return
+	}
+
+	resp, err := akh.apiKeyService.CreateAPIKey(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"api_key": resp,
+	})
+}
+
+// HandleListRequest отвечает за получение и формирование ответа
+// на запрос получения информации обо всех ключах доступа
+func (akh *APIKeyHandler) HandleListRequest(c *gin.Context) {
+	resp, err := akh.apiKeyService.GetAPIKeys(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"api_keys": resp,
+	})
+}
+
+// HandleRevokeRequest отвечает за получение и формирование ответа
+// на запрос отзыва ключа доступа
+func (akh *APIKeyHandler) HandleRevokeRequest(c *gin.Context) {
+	var req dto.RevokeAPIKey
+	if parseErr := bindJSON(c, &req); parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
+		return
+	}
+
+	resp, err := akh.apiKeyService.RevokeAPIKey(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"api_key": resp,
+	})
+}
+
+// HandleRotateRequest отвечает за получение и формирование ответа
+// на запрос ротации ключа доступа
+func (akh *APIKeyHandler) HandleRotateRequest(c *gin.Context) {
+	var req dto.RotateAPIKey
+	if parseErr := bindJSON(c, &req); parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
+		return
+	}
+
+	resp, err := akh.apiKeyService.RotateAPIKey(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"api_key": resp,
+	})
+}
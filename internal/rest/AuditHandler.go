@@ -0,0 +1,105 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/service"
+)
+
+// AuditHandler представляет контроллер, который отвечает
+// за получение запросов к журналу аудита и формирование ответа
+type AuditHandler struct {
+	auditService *service.AuditService
+}
+
+// NewAuditHandler конструирует и возвращает объект AuditHandler
+func NewAuditHandler(as *service.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: as,
+	}
+}
+
+// HandleGetRecordsRequest обрабатывает запрос получения записей журнала аудита
+// с фильтрами actor, action, target_type, target_id, from, to (RFC 3339), limit и offset
+func (ah *AuditHandler) HandleGetRecordsRequest(c *gin.Context) {
+	filter, parseErr := parseAuditFilter(c)
+	if parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
+		return
+	}
+
+	records, err := ah.auditService.GetRecords(c.Request.Context(), filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"records": records,
+	})
+}
+
+// HandleVerifyRequest обрабатывает запрос проверки
+// целостности цепочки хешей журнала аудита
+func (ah *AuditHandler) HandleVerifyRequest(c *gin.Context) {
+	result, err := ah.auditService.VerifyChain(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func parseAuditFilter(c *gin.Context) (*dto.AuditFilter, *dto.ErrorResponse) {
+	filter := &dto.AuditFilter{
+		Actor:      c.Query("actor"),
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+	}
+
+	var err error
+	if filter.From, err = parseTimeQuery(c, "from"); err != nil {
+		return nil, badQueryParam("from")
+	}
+	if filter.To, err = parseTimeQuery(c, "to"); err != nil {
+		return nil, badQueryParam("to")
+	}
+	if filter.Limit, err = parseIntQuery(c, "limit"); err != nil {
+		return nil, badQueryParam("limit")
+	}
+	if filter.Offset, err = parseIntQuery(c, "offset"); err != nil {
+		return nil, badQueryParam("offset")
+	}
+
+	return filter, nil
+}
+
+func parseTimeQuery(c *gin.Context, name string) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func parseIntQuery(c *gin.Context, name string) (int, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/dto"
+)
+
+// bindJSON разбирает JSON-тело запроса в obj и проверяет его по правилам
+// валидации. Параметры пути маршрута (например, :team_name в /api/v1/teams/:team_name)
+// подставляются в одноименные поля тела и имеют приоритет над ними;
+// при наличии параметров пути тело может отсутствовать
+func bindJSON(c *gin.Context, obj any) *dto.ErrorResponse {
+	body, err := requestBody(c)
+	if err != nil {
+		return badRequestBody()
+	}
+
+	if len(c.Params) > 0 {
+		if body, err = withPathParams(body, c.Params); err != nil {
+			return badRequestBody()
+		}
+	}
+
+	if err := json.Unmarshal(body, obj); err != nil {
+		return badRequestBody()
+	}
+
+	if err := ValidateStruct(obj); err != nil {
+		return bindError(err)
+	}
+
+	return nil
+}
+
+// requestBody возвращает тело запроса, сохраняя его в контексте
+// (как c.ShouldBindBodyWith), чтобы тело можно было прочитать повторно
+func requestBody(c *gin.Context) ([]byte, error) {
+	if cached, ok := c.Get(gin.BodyBytesKey); ok {
+		if body, ok := cached.([]byte); ok {
+			return body, nil
+		}
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.Set(gin.BodyBytesKey, body)
+
+	return body, nil
+}
+
+func withPathParams(body []byte, params gin.Params) ([]byte, error) {
+	fields := make(map[string]any, len(params))
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, param := range params {
+		fields[param.Key] = param.Value
+	}
+
+	return json.Marshal(fields)
+}
+
+// requestParam возвращает значение параметра пути name
+// (для маршрутов /api/v1), а при его отсутствии - параметра строки запроса
+func requestParam(c *gin.Context, name string) string {
+	if value, ok := c.Params.Get(name); ok {
+		return value
+	}
+
+	return c.Query(name)
+}
@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/service"
+)
+
+// DigestHandler представляет контроллер, который отвечает
+// за получение запросов предпросмотра дайджеста ожидающих ревью PR's
+type DigestHandler struct {
+	digestService *service.DigestService
+}
+
+// NewDigestHandler конструирует и возвращает объект DigestHandler
+func NewDigestHandler(ds *service.DigestService) *DigestHandler {
+	return &DigestHandler{
+		digestService: ds,
+	}
+}
+
+// HandlePreviewRequest формирует дайджест для сотрудника с идентификатором user_id
+// без его отправки и возвращает его в формате format (html - по умолчанию, text или json)
+func (dh *DigestHandler) HandlePreviewRequest(c *gin.Context) {
+	userID := requestParam(c, "user_id")
+	format := c.DefaultQuery("format", service.DigestFormatHTML)
+
+	reviewDigest, err := dh.digestService.BuildDigest(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, reviewDigest)
+		return
+	}
+
+	body, err := dh.digestService.RenderDigest(reviewDigest, format)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	contentType := "text/html; charset=utf-8"
+	if format == service.DigestFormatText {
+		contentType = "text/plain; charset=utf-8"
+	}
+	c.Data(http.StatusOK, contentType, []byte(body))
+}
@@ -0,0 +1,13 @@
+package rest
+
+import "github.com/gin-gonic/gin"
+
+// ifMatch возвращает условие If-Match запроса на изменение ресурса
+func ifMatch(c *gin.Context) string {
+	return c.GetHeader("If-Match")
+}
+
+// setETag выставляет заголовок ETag ответа с текущей версией ресурса
+func setETag(c *gin.Context, etag string) {
+	c.Header("ETag", etag)
+}
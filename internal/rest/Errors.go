@@ -0,0 +1,31 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/salex06/pr-service/internal/dto"
+)
+
+// badRequestBody возвращает ответ на запрос,
+// тело которого не удалось разобрать как JSON
+func badRequestBody() *dto.ErrorResponse {
+	return &dto.ErrorResponse{
+		Status: http.StatusBadRequest,
+		Error: map[string]string{
+			"code":    string(dto.BadRequest),
+			"message": "json parsing error",
+		},
+	}
+}
+
+// badQueryParam возвращает ответ на запрос
+// с некорректным параметром строки запроса name
+func badQueryParam(name string) *dto.ErrorResponse {
+	return &dto.ErrorResponse{
+		Status: http.StatusBadRequest,
+		Error: map[string]string{
+			"code":    string(dto.BadRequest),
+			"message": "invalid query parameter: " + name,
+		},
+	}
+}
@@ -0,0 +1,81 @@
+package rest
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/database"
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/migrator"
+)
+
+// HealthHandler представляет контроллер, который отвечает
+// на запросы проверки работоспособности и готовности сервиса
+type HealthHandler struct {
+	db           *database.DB
+	migrator     *migrator.Migrator
+	shuttingDown atomic.Bool
+}
+
+// NewHealthHandler конструирует и возвращает объект HealthHandler
+func NewHealthHandler(db *database.DB, migrator *migrator.Migrator) *HealthHandler {
+	return &HealthHandler{
+		db:       db,
+		migrator: migrator,
+	}
+}
+
+// SetShuttingDown помечает сервис как останавливающийся:
+// с этого момента проверка готовности завершается неудачно,
+// чтобы балансировщик перестал направлять на него запросы
+func (hh *HealthHandler) SetShuttingDown() {
+	hh.shuttingDown.Store(true)
+}
+
+// HandleLivenessRequest отвечает на проверку работоспособности
+// (процесс запущен и обрабатывает запросы)
+func (hh *HealthHandler) HandleLivenessRequest(c *gin.Context) {
+	c.JSON(http.StatusOK, &dto.HealthStatus{Status: dto.HealthOK})
+}
+
+// HandleReadinessRequest отвечает на проверку готовности: сервис
+// не останавливается, БД доступна и к ней применены все миграции
+func (hh *HealthHandler) HandleReadinessRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	status := &dto.HealthStatus{
+		Status: dto.HealthOK,
+		Checks: map[string]string{
+			"shutdown": dto.HealthOK,
+			"database": dto.HealthOK,
+			"schema":   dto.HealthOK,
+		},
+	}
+
+	if hh.shuttingDown.Load() {
+		status.Checks["shutdown"] = "shutting down"
+		status.Status = dto.HealthUnavailable
+	}
+
+	if err := hh.db.Pool.Ping(ctx); err != nil {
+		status.Checks["database"] = err.Error()
+		status.Checks["schema"] = "skipped"
+		status.Status = dto.HealthUnavailable
+	} else {
+		if version, err := hh.migrator.CurrentVersion(ctx); err == nil {
+			status.SchemaVersion = &version
+		}
+		if err := hh.migrator.CheckSchema(ctx); err != nil {
+			status.Checks["schema"] = err.Error()
+			status.Status = dto.HealthUnavailable
+		}
+	}
+
+	if status.Status != dto.HealthOK {
+		c.JSON(http.StatusServiceUnavailable, status)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
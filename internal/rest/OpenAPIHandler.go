@@ -0,0 +1,28 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/salex06/pr-service/internal/openapi"
+)
+
+// OpenAPIHandler представляет контроллер, который отдает
+// спецификацию API сервиса и страницу Swagger UI
+type OpenAPIHandler struct{}
+
+// NewOpenAPIHandler конструирует и возвращает объект OpenAPIHandler
+func NewOpenAPIHandler() *OpenAPIHandler {
+	return &OpenAPIHandler{}
+}
+
+// HandleSpecRequest возвращает спецификацию API в формате OpenAPI 3
+func (oh *OpenAPIHandler) HandleSpecRequest(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", openapi.Spec)
+}
+
+// HandleSwaggerUIRequest возвращает страницу Swagger UI
+func (oh *OpenAPIHandler) HandleSwaggerUIRequest(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", openapi.SwaggerUI)
+}
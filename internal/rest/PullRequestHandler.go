@@ -1,11 +1,13 @@
 package rest
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
 	"github.com/salex06/pr-service/internal/service"
 )
 
@@ -27,43 +29,62 @@ func NewPullRequestHandler(prService *service.PullRequestService) *PullRequestHa
 // открытия нового pull-request`а и назначения на него сотрудников
 func (prh *PullRequestHandler) HandleCreateRequest(c *gin.Context) {
 	var req dto.CreatePullRequest
-	parseErr := c.ShouldBindBodyWithJSON(&req)
-	if parseErr != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "json parsing error",
-		})
+	if parseErr := bindJSON(c, &req); parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
 		return
 	}
 
-	resp, err := prh.prService.CreatePullRequest(&req)
+	resp, err := prh.prService.CreatePullRequest(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(err.Status, err.Error)
+		_ = c.Error(err)
 		return
 	}
 
+	setETag(c, dto.ETag(resp.Version))
+
 	c.JSON(http.StatusCreated, gin.H{
 		"pr": resp,
 	})
 }
 
+// HandleBatchCreateRequest отвечает за получение и формирование ответа на запрос
+// открытия нескольких pull-request`ов (результат - по каждому PR из запроса)
+func (prh *PullRequestHandler) HandleBatchCreateRequest(c *gin.Context) {
+	var req dto.BatchCreatePullRequests
+	if parseErr := bindJSON(c, &req); parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
+		return
+	}
+
+	resp, err := prh.prService.CreatePullRequests(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": resp,
+	})
+}
+
 // HandleMergeRequest отвечает за получение и формирование ответа на запрос
 // закрытия pull-request`а и его перевода в статус MERGED
 func (prh *PullRequestHandler) HandleMergeRequest(c *gin.Context) {
 	var req dto.MergePullRequest
-	parseErr := c.ShouldBindBodyWithJSON(&req)
-	if parseErr != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "json parsing error",
-		})
+	if parseErr := bindJSON(c, &req); parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
 		return
 	}
+	req.IfMatch = ifMatch(c)
 
-	resp, err := prh.prService.MergePullRequest(&req)
+	resp, err := prh.prService.MergePullRequest(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(err.Status, err.Error)
+		_ = c.Error(err)
 		return
 	}
 
+	setETag(c, dto.ETag(resp.Version))
+
 	c.JSON(http.StatusOK, gin.H{
 		"pr": resp,
 	})
@@ -73,22 +94,151 @@ func (prh *PullRequestHandler) HandleMergeRequest(c *gin.Context) {
 // переназначения одного сотрудника на pull-request
 func (prh *PullRequestHandler) HandleReassignRequest(c *gin.Context) {
 	var req dto.ReassignPullRequest
-	parseErr := c.ShouldBindBodyWithJSON(&req)
-	if parseErr != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "json parsing error",
-		})
+	if parseErr := bindJSON(c, &req); parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
 		return
 	}
+	req.IfMatch = ifMatch(c)
 
-	resp, err := prh.prService.ReassignPullRequest(&req)
+	resp, err := prh.prService.ReassignPullRequest(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(err.Status, err.Error)
+		_ = c.Error(err)
 		return
 	}
 
+	setETag(c, dto.ETag(resp.Pr.Version))
+
 	c.JSON(http.StatusOK, gin.H{
 		"pr":          resp.Pr,
 		"replaced_by": resp.ReplacedBy,
 	})
 }
+
+// HandleAssignReviewerRequest отвечает за получение и формирование ответа на запрос
+// ручного назначения сотрудника ревьюером pull-request`а
+func (prh *PullRequestHandler) HandleAssignReviewerRequest(c *gin.Context) {
+	prh.handleManualAssignment(c, prh.prService.AssignReviewer)
+}
+
+// HandleUnassignReviewerRequest отвечает за получение и формирование ответа на запрос
+// ручного снятия ревьюера с pull-request`а
+func (prh *PullRequestHandler) HandleUnassignReviewerRequest(c *gin.Context) {
+	prh.handleManualAssignment(c, prh.prService.UnassignReviewer)
+}
+
+func (prh *PullRequestHandler) handleManualAssignment(
+	c *gin.Context,
+	apply func(context.Context, *dto.ManualAssignment) (*dto.PullRequest, error),
+) {
+	var req dto.ManualAssignment
+	if parseErr := bindJSON(c, &req); parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
+		return
+	}
+	req.IfMatch = ifMatch(c)
+
+	resp, err := apply(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setETag(c, dto.ETag(resp.Version))
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr": resp,
+	})
+}
+
+// HandleSubmitVerdictRequest отвечает за получение и формирование ответа на запрос
+// вынесения ревьюером решения по pull-request`у
+func (prh *PullRequestHandler) HandleSubmitVerdictRequest(c *gin.Context) {
+	var req dto.SubmitVerdict
+	if parseErr := bindJSON(c, &req); parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
+		return
+	}
+
+	resp, err := prh.prService.SubmitVerdict(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"verdict": resp,
+	})
+}
+
+// HandleGetRequest отвечает за получение и формирование ответа на запрос
+// pull-request`а с идентификатором pull_request_id (с заголовком ETag)
+func (prh *PullRequestHandler) HandleGetRequest(c *gin.Context) {
+	resp, err := prh.prService.GetPullRequest(c.Request.Context(), requestParam(c, "pull_request_id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setETag(c, dto.ETag(resp.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"pr": resp,
+	})
+}
+
+// HandleReviewerHistoryRequest отвечает за получение и формирование ответа на запрос
+// истории назначений ревьюеров на pull-request с идентификатором pull_request_id
+func (prh *PullRequestHandler) HandleReviewerHistoryRequest(c *gin.Context) {
+	prID := requestParam(c, "pull_request_id")
+
+	resp, err := prh.prService.GetReviewerTimeline(c.Request.Context(), prID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// HandleListRequest отвечает за получение и формирование ответа на запрос
+// списка pull-request'ов с фильтрами status, author_id, repository,
+// label, limit и offset
+func (prh *PullRequestHandler) HandleListRequest(c *gin.Context) {
+	filter, parseErr := parsePullRequestFilter(c)
+	if parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
+		return
+	}
+
+	resp, err := prh.prService.ListPullRequests(c.Request.Context(), filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pull_requests": resp,
+	})
+}
+
+func parsePullRequestFilter(c *gin.Context) (*dto.PullRequestFilter, *dto.ErrorResponse) {
+	filter := &dto.PullRequestFilter{
+		Status:     entity.PullRequestStatus(c.Query("status")),
+		AuthorID:   c.Query("author_id"),
+		Repository: c.Query("repository"),
+		Label:      c.Query("label"),
+	}
+
+	if filter.Status != "" && filter.Status != entity.OPEN && filter.Status != entity.MERGED {
+		return nil, badQueryParam("status")
+	}
+
+	var err error
+	if filter.Limit, err = parseIntQuery(c, "limit"); err != nil {
+		return nil, badQueryParam("limit")
+	}
+	if filter.Offset, err = parseIntQuery(c, "offset"); err != nil {
+		return nil, badQueryParam("offset")
+	}
+
+	return filter, nil
+}
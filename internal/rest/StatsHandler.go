@@ -2,34 +2,94 @@ package rest
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/salex06/pr-service/internal/dto"
 	"github.com/salex06/pr-service/internal/service"
 )
 
+// defaultStatsWindow - временное окно аналитики ревью,
+// если параметр from не задан
+const defaultStatsWindow = 30 * 24 * time.Hour
+
 // StatsHandler представляет контроллер, который
 // отвечает за получение и отправку ответов на запросы
 // статистики по приложению
 type StatsHandler struct {
-	statsService *service.StatsService
+	statsService service.StatsProvider
 }
 
 // NewStatHandler конструирует и возвращает объект StatsHandler
-func NewStatHandler(svc *service.StatsService) *StatsHandler {
+func NewStatHandler(svc service.StatsProvider) *StatsHandler {
 	return &StatsHandler{
 		statsService: svc,
 	}
 }
 
 // HandleGetStatsRequest получает запрос на сбор статистики работы приложения
-// и формирует ответ
+// и формирует ответ. Аналитика ревью рассчитывается за окно [from, to) (RFC 3339,
+// по умолчанию - последние 30 дней) по команде team (по умолчанию - все команды)
+// с группировкой granularity (day - по умолчанию, week)
 func (sh *StatsHandler) HandleGetStatsRequest(c *gin.Context) {
-	stat, err := sh.statsService.GetStat()
+	filter, parseErr := parseStatsFilter(c)
+	if parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
+		return
+	}
+
+	stat, err := sh.statsService.GetStat(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(err.Status, err.Error)
+		_ = c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, stat)
 }
+
+// HandleGetFairnessRequest получает запрос на формирование отчета о равномерности
+// распределения назначений на ревью в команде team (по умолчанию - во всех командах)
+// и формирует ответ
+func (sh *StatsHandler) HandleGetFairnessRequest(c *gin.Context) {
+	report, err := sh.statsService.GetFairnessReport(c.Request.Context(), c.Query("team"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func parseStatsFilter(c *gin.Context) (*dto.StatsFilter, *dto.ErrorResponse) {
+	filter := &dto.StatsFilter{
+		TeamName:    c.Query("team"),
+		Granularity: dto.StatsGranularity(c.DefaultQuery("granularity", string(dto.GranularityDay))),
+	}
+	if filter.Granularity != dto.GranularityDay && filter.Granularity != dto.GranularityWeek {
+		return nil, badQueryParam("granularity")
+	}
+
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		return nil, badQueryParam("from")
+	}
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		return nil, badQueryParam("to")
+	}
+
+	filter.To = time.Now().UTC()
+	if to != nil {
+		filter.To = *to
+	}
+	filter.From = filter.To.Add(-defaultStatsWindow)
+	if from != nil {
+		filter.From = *from
+	}
+	if !filter.From.Before(filter.To) {
+		return nil, badQueryParam("from")
+	}
+
+	return filter, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/salex06/pr-service/internal/auth"
 	"github.com/salex06/pr-service/internal/dto"
 	"github.com/salex06/pr-service/internal/service"
 )
@@ -27,17 +28,15 @@ func NewTeamHandler(ts *service.TeamService) *TeamHandler {
 // на запрос добавления новой команды и создания/обновления сотрудников
 func (th *TeamHandler) HandleAddTeamRequest(c *gin.Context) {
 	var req dto.Team
-	parseErr := c.ShouldBindBodyWithJSON(&req)
-	if parseErr != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "json parsing error",
-		})
+	if parseErr := bindJSON(c, &req); parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
 		return
 	}
+	req.MoveMembers = canMoveMembers(c)
 
-	resp, err := th.teamService.AddTeam(&req)
+	resp, err := th.teamService.AddTeam(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(err.Status, err)
+		_ = c.Error(err)
 		return
 	}
 
@@ -46,30 +45,92 @@ func (th *TeamHandler) HandleAddTeamRequest(c *gin.Context) {
 	})
 }
 
+// HandlePutTeamRequest ответчает за получение и формирование ответа
+// на запрос создания команды team_name (или обновления её участников)
+func (th *TeamHandler) HandlePutTeamRequest(c *gin.Context) {
+	var req dto.Team
+	if parseErr := bindJSON(c, &req); parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
+		return
+	}
+	req.IfMatch = ifMatch(c)
+	req.MoveMembers = canMoveMembers(c)
+
+	resp, created, err := th.teamService.PutTeam(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setETag(c, resp.ETag())
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, gin.H{
+		"team": resp,
+	})
+}
+
 // HandleGetTeamRequest ответчает за получение и формирование ответа
 // на запрос получения информации о команде с именем team_name
 func (th *TeamHandler) HandleGetTeamRequest(c *gin.Context) {
-	teamID := c.Query("team_name")
+	teamID := requestParam(c, "team_name")
 
-	resp, err := th.teamService.GetTeam(teamID)
+	resp, err := th.teamService.GetTeam(c.Request.Context(), teamID)
 	if err != nil {
-		c.JSON(err.Status, err)
+		_ = c.Error(err)
 		return
 	}
 
+	setETag(c, resp.ETag())
+
 	c.JSON(http.StatusOK, resp)
 }
 
 // HandleDeactivateAllRequest получает запрос на перевод в неактивное состояние
 // всех представителей команды с названием команды team_name и формирует ответ
 func (th *TeamHandler) HandleDeactivateAllRequest(c *gin.Context) {
-	teamID := c.Query("team_name")
+	teamID := requestParam(c, "team_name")
 
-	resp, err := th.teamService.DeactivateAllMembers(teamID)
+	resp, err := th.teamService.DeactivateAllMembers(c.Request.Context(), teamID, ifMatch(c))
 	if err != nil {
-		c.JSON(err.Status, err)
+		_ = c.Error(err)
 		return
 	}
 
+	setETag(c, resp.ETag())
+
 	c.JSON(http.StatusOK, resp)
 }
+
+// HandleMoveMemberRequest получает запрос на перевод сотрудника
+// в другую команду и формирует ответ
+func (th *TeamHandler) HandleMoveMemberRequest(c *gin.Context) {
+	var req dto.MoveTeamMember
+	if parseErr := bindJSON(c, &req); parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
+		return
+	}
+	req.IfMatch = ifMatch(c)
+
+	resp, err := th.teamService.MoveMember(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setETag(c, dto.ETag(resp.Version))
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": resp,
+	})
+}
+
+// canMoveMembers проверяет, может ли клиент перечислять в составе команды
+// участников других команд (их переводит только администратор)
+func canMoveMembers(c *gin.Context) bool {
+	principal := auth.GetPrincipal(c)
+	return principal != nil && principal.HasRole(auth.RoleAdmin)
+}
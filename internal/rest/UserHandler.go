@@ -29,20 +29,35 @@ func NewUserHandler(us *service.UserService) *UserHandler {
 // изменения состояния сотрудника (активное/неактивное состояние)
 func (uh *UserHandler) HandleSetIsActiveRequest(c *gin.Context) {
 	var req dto.UserShort
-	parseErr := c.ShouldBindBodyWithJSON(&req)
-	if parseErr != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "json parsing error",
-		})
+	if parseErr := bindJSON(c, &req); parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
 		return
 	}
+	req.IfMatch = ifMatch(c)
 
-	resp, err := uh.userService.SetIsActive(&req)
+	resp, err := uh.userService.SetIsActive(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(err.Status, err)
+		_ = c.Error(err)
 		return
 	}
 
+	setETag(c, dto.ETag(resp.Version))
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": resp,
+	})
+}
+
+// HandleGetUserRequest обрабатывает запрос и формирует ответ на получение
+// сотрудника с идентификатором user_id (с заголовком ETag)
+func (uh *UserHandler) HandleGetUserRequest(c *gin.Context) {
+	resp, err := uh.userService.GetUser(c.Request.Context(), requestParam(c, "user_id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setETag(c, dto.ETag(resp.Version))
 	c.JSON(http.StatusOK, gin.H{
 		"user": resp,
 	})
@@ -51,13 +66,36 @@ func (uh *UserHandler) HandleSetIsActiveRequest(c *gin.Context) {
 // HandleGetReviewRequest обрабатывает запрос и формирует ответ на получение PR`s,
 // где пользователь с идентификатором user_id назначен ревьюером
 func (uh *UserHandler) HandleGetReviewRequest(c *gin.Context) {
-	userID := c.Query("user_id")
+	userID := requestParam(c, "user_id")
 
-	resp, err := uh.userService.GetAssignedPRs(userID)
+	resp, err := uh.userService.GetAssignedPRs(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(err.Status, err)
+		_ = c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// HandleSetDigestOptOutRequest обрабатывает и формирует ответ на запрос
+// отказа сотрудника от рассылки дайджеста (или её возобновления)
+func (uh *UserHandler) HandleSetDigestOptOutRequest(c *gin.Context) {
+	var req dto.DigestSubscription
+	if parseErr := bindJSON(c, &req); parseErr != nil {
+		c.JSON(parseErr.Status, parseErr)
+		return
+	}
+	req.IfMatch = ifMatch(c)
+
+	resp, err := uh.userService.SetDigestOptOut(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setETag(c, dto.ETag(resp.Version))
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": resp,
+	})
+}
@@ -0,0 +1,137 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/salex06/pr-service/internal/auth"
+	"github.com/salex06/pr-service/internal/dto"
+)
+
+// RegisterValidators настраивает валидатор тел запросов: поля в ошибках
+// называются так же, как в JSON, добавляются правило scope (допустимое
+// разрешение ключа доступа) и проверка уникальности участников команды
+func RegisterValidators() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("unexpected validator engine")
+	}
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	v.RegisterStructValidation(validateTeamMembers, dto.Team{})
+
+	return v.RegisterValidation("scope", func(fl validator.FieldLevel) bool {
+		return auth.Scope(fl.Field().String()).IsValid()
+	})
+}
+
+// ValidateStruct проверяет объект по правилам, заданным тегами binding,
+// тем же валидатором, что и тела запросов REST API (например, для gRPC)
+func ValidateStruct(obj any) error {
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// validateTeamMembers проверяет, что идентификаторы
+// участников команды не повторяются
+func validateTeamMembers(sl validator.StructLevel) {
+	team := sl.Current().Interface().(dto.Team)
+
+	seen := make(map[string]struct{}, len(team.Members))
+	for i, member := range team.Members {
+		if member == nil {
+			continue
+		}
+		if _, ok := seen[member.UserID]; ok {
+			sl.ReportError(member.UserID, fmt.Sprintf("members[%d].user_id", i), "UserID", "unique", "")
+		}
+		seen[member.UserID] = struct{}{}
+	}
+}
+
+// bindError формирует ответ на запрос, тело которого не удалось
+// разобрать (BAD_REQUEST) или которое не прошло валидацию (VALIDATION_ERROR)
+func bindError(err error) *dto.ErrorResponse {
+	details := FieldViolations(err)
+	if details == nil {
+		return badRequestBody()
+	}
+
+	return &dto.ErrorResponse{
+		Status: http.StatusBadRequest,
+		Error: map[string]string{
+			"code":    string(dto.ValidationError),
+			"message": "request validation failed",
+		},
+		Details: details,
+	}
+}
+
+// FieldViolations возвращает нарушения правил валидации по полям
+// запроса (nil - если err не является ошибкой валидации)
+func FieldViolations(err error) []*dto.FieldViolation {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil
+	}
+
+	violations := make([]*dto.FieldViolation, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		violations = append(violations, &dto.FieldViolation{
+			Field:  fieldPath(fieldErr),
+			Reason: violationReason(fieldErr),
+		})
+	}
+
+	return violations
+}
+
+// fieldPath возвращает путь к полю в JSON-представлении запроса
+// (например, members[1].username)
+func fieldPath(fieldErr validator.FieldError) string {
+	_, path, _ := strings.Cut(fieldErr.Namespace(), ".")
+	return path
+}
+
+func violationReason(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	isString := fieldErr.Kind() == reflect.String
+
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "max":
+		if isString {
+			return fmt.Sprintf("must be at most %s characters long", param)
+		}
+		return fmt.Sprintf("must be at most %s", param)
+	case "min":
+		if isString {
+			return fmt.Sprintf("must be at least %s characters long", param)
+		}
+		if fieldErr.Kind() == reflect.Slice {
+			return fmt.Sprintf("must contain at least %s items", param)
+		}
+		return fmt.Sprintf("must be at least %s", param)
+	case "email":
+		return "must be a valid email address"
+	case "unique":
+		return "must be unique within the request"
+	case "scope":
+		return "must be a known scope"
+	default:
+		return fmt.Sprintf("failed on the %s rule", fieldErr.Tag())
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/salex06/pr-service/internal/auth"
+	"github.com/salex06/pr-service/internal/converter"
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+	apiKeyRepos "github.com/salex06/pr-service/internal/repos/apikey"
+	"github.com/salex06/pr-service/internal/tracing"
+)
+
+// APIKeyService представляет компонент, отвечающий за выполнение
+// бизнес-логики, связанной с ключами доступа машинных клиентов
+// (выпуск, отзыв и ротация)
+type APIKeyService struct {
+	apiKeyRepository *apiKeyRepos.APIKeyRepository
+	rotationOverlap  time.Duration
+}
+
+// NewAPIKeyService конструирует и возвращает объект APIKeyService
+func NewAPIKeyService(ar *apiKeyRepos.APIKeyRepository, rotationOverlap time.Duration) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepository: ar,
+		rotationOverlap:  rotationOverlap,
+	}
+}
+
+// CreateAPIKey выпускает новый ключ доступа с заданным набором разрешений.
+// Сам ключ возвращается только в ответе на этот запрос, в БД хранится его хеш
+func (svc *APIKeyService) CreateAPIKey(ctx context.Context, req *dto.CreateAPIKey) (*dto.IssuedAPIKey, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.CreateAPIKey")
+	defer span.End()
+
+	if req.Name == "" || len(req.Scopes) == 0 {
+		return nil, invalidArgument("name and scopes are required")
+	}
+
+	for _, scope := range req.Scopes {
+		if !auth.Scope(scope).IsValid() {
+			return nil, invalidArgument(fmt.Sprintf("unknown scope: %s", scope))
+		}
+	}
+
+	return svc.issueAPIKey(ctx, &entity.APIKey{
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+}
+
+// GetAPIKeys возвращает информацию обо всех ключах доступа
+func (svc *APIKeyService) GetAPIKeys(ctx context.Context) ([]*dto.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.GetAPIKeys")
+	defer span.End()
+
+	keys, err := (*svc.apiKeyRepository).GetAPIKeys(ctx)
+	if err != nil {
+		return nil, internal(err, "unable get api keys")
+	}
+
+	return converter.ConvertAPIKeysToDto(keys), nil
+}
+
+// RevokeAPIKey отзывает ключ доступа (повторный отзыв не приводит к ошибке)
+func (svc *APIKeyService) RevokeAPIKey(ctx context.Context, req *dto.RevokeAPIKey) (*dto.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.RevokeAPIKey")
+	defer span.End()
+
+	key, err := (*svc.apiKeyRepository).GetAPIKey(ctx, req.KeyID)
+	if err != nil {
+		return nil, internal(err, "unable get api key")
+	}
+	if key == nil {
+		return nil, notFound()
+	}
+
+	if key.RevokedAt != nil {
+		return converter.ConvertAPIKeyToDto(key), nil
+	}
+
+	revokedAt := time.Now()
+	key.RevokedAt = &revokedAt
+	if err := (*svc.apiKeyRepository).UpdateAPIKey(ctx, key); err != nil {
+		return nil, internal(err, "unable update api key")
+	}
+
+	return converter.ConvertAPIKeyToDto(key), nil
+}
+
+// RotateAPIKey выпускает новый ключ с теми же названием, разрешениями
+// и сроком действия, а прежний ключ оставляет действительным
+// в течение периода перекрытия, чтобы клиенты успели перейти на новый
+func (svc *APIKeyService) RotateAPIKey(ctx context.Context, req *dto.RotateAPIKey) (*dto.IssuedAPIKey, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.RotateAPIKey")
+	defer span.End()
+
+	key, err := (*svc.apiKeyRepository).GetAPIKey(ctx, req.KeyID)
+	if err != nil {
+		return nil, internal(err, "unable get api key")
+	}
+	if key == nil {
+		return nil, notFound()
+	}
+
+	now := time.Now()
+	if !key.IsActive(now) {
+		return nil, conflict(dto.KeyInactive, "cannot rotate revoked or expired api key")
+	}
+
+	overlap := svc.rotationOverlap
+	if req.OverlapSeconds != nil {
+		overlap = time.Duration(*req.OverlapSeconds) * time.Second
+	}
+
+	issued, errResp := svc.issueAPIKey(ctx, &entity.APIKey{
+		Name:        key.Name,
+		Scopes:      key.Scopes,
+		ExpiresAt:   key.ExpiresAt,
+		RotatedFrom: &key.KeyID,
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	overlapEnd := now.Add(overlap)
+	if key.ExpiresAt == nil || overlapEnd.Before(*key.ExpiresAt) {
+		key.ExpiresAt = &overlapEnd
+	}
+	if err := (*svc.apiKeyRepository).UpdateAPIKey(ctx, key); err != nil {
+		return nil, internal(err, "unable update api key")
+	}
+
+	return issued, nil
+}
+
+func (svc *APIKeyService) issueAPIKey(ctx context.Context, key *entity.APIKey) (*dto.IssuedAPIKey, error) {
+	keyID, plainKey, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, internal(err, "unable generate api key")
+	}
+
+	createdAt := time.Now()
+	key.KeyID = keyID
+	key.KeyHash = auth.HashAPIKey(plainKey)
+	key.CreatedAt = &createdAt
+
+	if err := (*svc.apiKeyRepository).SaveAPIKey(ctx, key); err != nil {
+		return nil, internal(err, "unable save api key")
+	}
+
+	return &dto.IssuedAPIKey{
+		APIKey: *converter.ConvertAPIKeyToDto(key),
+		Key:    plainKey,
+	}, nil
+}
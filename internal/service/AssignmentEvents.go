@@ -0,0 +1,84 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/metrics"
+)
+
+// assignmentSubscriberBuffer - размер очереди событий одного подписчика
+const assignmentSubscriberBuffer = 64
+
+// AssignmentEventBroker представляет компонент, который рассылает события
+// назначения ревьюеров всем подписчикам в пределах экземпляра сервиса.
+// Публикация не блокируется: если очередь подписчика заполнена,
+// событие для него отбрасывается
+type AssignmentEventBroker struct {
+	mu          sync.RWMutex
+	subscribers map[chan *dto.AssignmentEvent]struct{}
+	closed      bool
+}
+
+// NewAssignmentEventBroker конструирует и возвращает объект AssignmentEventBroker
+func NewAssignmentEventBroker() *AssignmentEventBroker {
+	return &AssignmentEventBroker{
+		subscribers: make(map[chan *dto.AssignmentEvent]struct{}),
+	}
+}
+
+// Subscribe регистрирует подписчика и возвращает канал событий и функцию
+// отмены подписки. Канал закрывается при отмене подписки или остановке брокера
+func (b *AssignmentEventBroker) Subscribe() (<-chan *dto.AssignmentEvent, func()) {
+	events := make(chan *dto.AssignmentEvent, assignmentSubscriberBuffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(events)
+		return events, func() {}
+	}
+	b.subscribers[events] = struct{}{}
+
+	return events, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if _, ok := b.subscribers[events]; ok {
+			delete(b.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+// Close останавливает брокер: каналы всех подписчиков закрываются,
+// новые подписки сразу получают закрытый канал
+func (b *AssignmentEventBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for events := range b.subscribers {
+		delete(b.subscribers, events)
+		close(events)
+	}
+}
+
+// Publish рассылает событие всем текущим подписчикам
+func (b *AssignmentEventBroker) Publish(event *dto.AssignmentEvent) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for events := range b.subscribers {
+		select {
+		case events <- event:
+		default:
+			metrics.AssignmentEventsDroppedTotal.Inc()
+		}
+	}
+}
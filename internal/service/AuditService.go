@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/salex06/pr-service/internal/audit"
+	"github.com/salex06/pr-service/internal/converter"
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+	"github.com/salex06/pr-service/internal/metrics"
+	auditRepos "github.com/salex06/pr-service/internal/repos/audit"
+	"github.com/salex06/pr-service/internal/tracing"
+)
+
+// Типы объектов, изменения которых фиксируются в журнале аудита
+const (
+	AuditTargetTeam        = "team"
+	AuditTargetUser        = "user"
+	AuditTargetPullRequest = "pull_request"
+)
+
+// Действия, фиксируемые в журнале аудита
+const (
+	AuditActionTeamAdd             = "team.add"
+	AuditActionTeamUpdate          = "team.update"
+	AuditActionTeamDeactivateAll   = "team.deactivate_all"
+	AuditActionTeamMoveMember      = "team.move_member"
+	AuditActionUserSetIsActive     = "user.set_is_active"
+	AuditActionUserSetDigestOptOut = "user.set_digest_opt_out"
+	AuditActionPullRequestCreate   = "pull_request.create"
+	AuditActionPullRequestMerge    = "pull_request.merge"
+	AuditActionPullRequestReassign = "pull_request.reassign"
+	AuditActionPullRequestVerdict  = "pull_request.submit_verdict"
+	AuditActionPullRequestAssign   = "pull_request.assign_reviewer"
+	AuditActionPullRequestUnassign = "pull_request.unassign_reviewer"
+)
+
+const (
+	defaultAuditLimit   = 100
+	maxAuditLimit       = 1000
+	auditVerifyPageSize = 500
+)
+
+// AuditService представляет компонент, отвечающий за ведение
+// журнала аудита изменений и его проверку
+type AuditService struct {
+	auditRepository *auditRepos.AuditRepository
+}
+
+// NewAuditService конструирует и возвращает объект AuditService
+func NewAuditService(ar *auditRepos.AuditRepository) *AuditService {
+	return &AuditService{
+		auditRepository: ar,
+	}
+}
+
+// Record добавляет в журнал запись о выполненном изменении с состоянием
+// объектов до и после изменения. Инициатор изменения и идентификатор
+// запроса берутся из контекста. Ошибка записи не прерывает уже выполненную
+// операцию: она журналируется и учитывается в метрике AuditRecordFailuresTotal
+func (as *AuditService) Record(ctx context.Context, action, targetType string, targetIDs []string, before, after any) {
+	ctx, span := tracing.Start(ctx, "AuditService.Record")
+	defer span.End()
+
+	meta := audit.MetaFromContext(ctx)
+
+	record := &entity.AuditRecord{
+		OccurredAt: time.Now().UTC().Truncate(time.Microsecond),
+		Actor:      meta.Actor,
+		Action:     action,
+		TargetType: targetType,
+		TargetIDs:  targetIDs,
+		Before:     marshalSnapshot(before),
+		After:      marshalSnapshot(after),
+		RequestID:  meta.RequestID,
+	}
+
+	if err := (*as.auditRepository).AppendRecord(ctx, record); err != nil {
+		metrics.AuditRecordFailuresTotal.WithLabelValues(action).Inc()
+		slog.ErrorContext(ctx, "error occured when writing audit record",
+			"action", action, "target_ids", targetIDs, "error", err)
+	}
+}
+
+// GetRecords возвращает записи журнала аудита, удовлетворяющие фильтру
+func (as *AuditService) GetRecords(ctx context.Context, filter *dto.AuditFilter) ([]*dto.AuditRecord, error) {
+	ctx, span := tracing.Start(ctx, "AuditService.GetRecords")
+	defer span.End()
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	filter.Limit = min(filter.Limit, maxAuditLimit)
+	filter.Offset = max(filter.Offset, 0)
+
+	records, err := (*as.auditRepository).GetRecords(ctx, filter)
+	if err != nil {
+		return nil, internal(err, "unable get audit records")
+	}
+
+	return converter.ConvertAuditRecordsToDto(records), nil
+}
+
+// VerifyChain проверяет целостность цепочки хешей журнала аудита
+// и возвращает идентификатор первой записи, на которой цепочка нарушена
+func (as *AuditService) VerifyChain(ctx context.Context) (*dto.AuditChainVerification, error) {
+	ctx, span := tracing.Start(ctx, "AuditService.VerifyChain")
+	defer span.End()
+
+	result := &dto.AuditChainVerification{Valid: true}
+
+	prevHash := ""
+	lastID := int64(0)
+	for {
+		records, err := (*as.auditRepository).GetRecordsAfter(ctx, lastID, auditVerifyPageSize)
+		if err != nil {
+			return nil, internal(err, "unable get audit records")
+		}
+
+		for _, record := range records {
+			result.RecordsChecked++
+			if record.PrevHash != prevHash || record.ComputeHash() != record.Hash {
+				result.Valid = false
+				result.BrokenAtID = &record.ID
+				return result, nil
+			}
+
+			prevHash = record.Hash
+			lastID = record.ID
+		}
+
+		if len(records) < auditVerifyPageSize {
+			return result, nil
+		}
+	}
+}
+
+func marshalSnapshot(snapshot any) json.RawMessage {
+	if snapshot == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		slog.Error("error occured when marshaling audit snapshot", "error", err)
+		return nil
+	}
+
+	return data
+}
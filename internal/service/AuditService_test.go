@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/salex06/pr-service/internal/entity"
+	"github.com/salex06/pr-service/internal/metrics"
+	auditRepos "github.com/salex06/pr-service/internal/repos/audit"
+)
+
+// unavailableAuditRepository - журнал аудита, в который не удается записать
+type unavailableAuditRepository struct {
+	auditRepos.AuditRepository
+}
+
+func (repo *unavailableAuditRepository) AppendRecord(ctx context.Context, record *entity.AuditRecord) error {
+	return errors.New("connection refused")
+}
+
+func TestRecordCountsFailedWrites(t *testing.T) {
+	failures := metrics.AuditRecordFailuresTotal.WithLabelValues(AuditActionTeamAdd)
+	before := testutil.ToFloat64(failures)
+
+	var repo auditRepos.AuditRepository = &unavailableAuditRepository{}
+	NewAuditService(&repo).Record(context.Background(), AuditActionTeamAdd, AuditTargetTeam, []string{"backend"}, nil, nil)
+
+	if got := testutil.ToFloat64(failures) - before; got != 1 {
+		t.Fatalf("audit record failures increased by %v, want 1", got)
+	}
+}
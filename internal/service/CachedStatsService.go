@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/salex06/pr-service/internal/cache"
+	"github.com/salex06/pr-service/internal/dto"
+)
+
+// StatsProvider представляет интерфейс получения статистики работы
+// приложения (реализуется StatsService и его кеширующим декоратором)
+type StatsProvider interface {
+	GetStat(ctx context.Context, filter *dto.StatsFilter) (*dto.AppStat, error)
+	GetFairnessReport(ctx context.Context, teamName string) (*dto.FairnessReport, error)
+}
+
+// CacheInvalidator представляет кеш, который сбрасывается
+// сервисами после изменения данных, влияющих на его содержимое
+type CacheInvalidator interface {
+	Invalidate()
+}
+
+// CachedStatsService представляет собой декоратор StatsProvider, который
+// кеширует статистику и отчеты о равномерности назначений на время ttl.
+// Границы окна аналитики округляются до ttl (и в ключе кеша, и при расчете),
+// поэтому запросы с окном "последние 30 дней" в пределах ttl получают один
+// и тот же ответ, рассчитанный именно для округленного окна.
+// Возвращаемые значения общие для всех запросов и не должны изменяться
+type CachedStatsService struct {
+	next     StatsProvider
+	ttl      time.Duration
+	stats    *cache.Cache[*dto.AppStat]
+	fairness *cache.Cache[*dto.FairnessReport]
+}
+
+// NewCachedStatsService конструирует и возвращает объект CachedStatsService
+func NewCachedStatsService(next StatsProvider, ttl time.Duration, observer cache.Observer) *CachedStatsService {
+	return &CachedStatsService{
+		next:     next,
+		ttl:      ttl,
+		stats:    cache.New[*dto.AppStat]("stats", ttl, observer),
+		fairness: cache.New[*dto.FairnessReport]("fairness", ttl, observer),
+	}
+}
+
+// GetStat возвращает статистику из кеша, а при её отсутствии - рассчитывает
+// для окна с округленными границами и сохраняет в кеш. Окно короче ttl,
+// которое после округления становится пустым, не кешируется
+func (svc *CachedStatsService) GetStat(ctx context.Context, filter *dto.StatsFilter) (*dto.AppStat, error) {
+	rounded := *filter
+	rounded.From = filter.From.Truncate(svc.ttl)
+	rounded.To = filter.To.Truncate(svc.ttl)
+	if !rounded.From.Before(rounded.To) {
+		return svc.next.GetStat(ctx, filter)
+	}
+
+	key := fmt.Sprintf("%d|%d|%s|%s|%s",
+		rounded.From.Unix(), rounded.To.Unix(),
+		rounded.TeamName, strings.Join(rounded.UserIDs, ","), rounded.Granularity)
+	if stat, ok := svc.stats.Get(key); ok {
+		return stat, nil
+	}
+
+	stat, err := svc.next.GetStat(ctx, &rounded)
+	if err != nil {
+		return nil, err
+	}
+	svc.stats.Set(key, stat)
+
+	return stat, nil
+}
+
+// GetFairnessReport возвращает отчет из кеша, а при его отсутствии -
+// формирует и сохраняет в кеш
+func (svc *CachedStatsService) GetFairnessReport(ctx context.Context, teamName string) (*dto.FairnessReport, error) {
+	if report, ok := svc.fairness.Get(teamName); ok {
+		return report, nil
+	}
+
+	report, err := svc.next.GetFairnessReport(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	svc.fairness.Set(teamName, report)
+
+	return report, nil
+}
+
+// Invalidate сбрасывает всю кешированную статистику
+func (svc *CachedStatsService) Invalidate() {
+	svc.stats.Clear()
+	svc.fairness.Clear()
+}
+
+// invalidate сбрасывает кеш c после изменения данных (nil - кеш не используется)
+func invalidate(c CacheInvalidator) {
+	if c != nil {
+		c.Invalidate()
+	}
+}
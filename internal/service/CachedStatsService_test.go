@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/salex06/pr-service/internal/dto"
+)
+
+// recordingStatsProvider запоминает фильтры, с которыми рассчитывалась статистика
+type recordingStatsProvider struct {
+	filters []dto.StatsFilter
+}
+
+func (p *recordingStatsProvider) GetStat(ctx context.Context, filter *dto.StatsFilter) (*dto.AppStat, error) {
+	p.filters = append(p.filters, *filter)
+	return &dto.AppStat{OpenedPRCount: len(p.filters)}, nil
+}
+
+func (p *recordingStatsProvider) GetFairnessReport(ctx context.Context, teamName string) (*dto.FairnessReport, error) {
+	return &dto.FairnessReport{}, nil
+}
+
+func TestCachedGetStatComputesRoundedWindow(t *testing.T) {
+	ctx := context.Background()
+	next := &recordingStatsProvider{}
+	cached := NewCachedStatsService(next, time.Minute, nil)
+
+	to := time.Date(2026, 10, 1, 12, 0, 10, 0, time.UTC)
+	for _, shift := range []time.Duration{0, 20 * time.Second} {
+		filter := &dto.StatsFilter{From: to.Add(shift - 24*time.Hour), To: to.Add(shift), Granularity: dto.GranularityDay}
+		stat, err := cached.GetStat(ctx, filter)
+		if err != nil {
+			t.Fatalf("GetStat: %v", err)
+		}
+		if stat.OpenedPRCount != 1 {
+			t.Fatalf("window shifted by %s: statistics calculated %d times, want 1", shift, stat.OpenedPRCount)
+		}
+	}
+
+	// Статистика рассчитана для того окна, по которому она закеширована
+	wantFrom, wantTo := to.Add(-24*time.Hour).Truncate(time.Minute), to.Truncate(time.Minute)
+	if got := next.filters[0]; !got.From.Equal(wantFrom) || !got.To.Equal(wantTo) {
+		t.Fatalf("calculated window [%s, %s), want [%s, %s)", got.From, got.To, wantFrom, wantTo)
+	}
+
+	// Окно короче ttl рассчитывается без округления и не кешируется
+	short := &dto.StatsFilter{From: to, To: to.Add(30 * time.Second)}
+	for range 2 {
+		if _, err := cached.GetStat(ctx, short); err != nil {
+			t.Fatalf("GetStat: %v", err)
+		}
+	}
+	if len(next.filters) != 3 || !next.filters[2].To.Equal(short.To) {
+		t.Fatalf("short window calculations = %+v, want 2 with exact bounds", next.filters[1:])
+	}
+}
+
+func TestCachedStatsInvalidatedByWrites(t *testing.T) {
+	ctx := context.Background()
+	cached := NewCachedStatsService(nil, time.Hour, nil)
+	env := newTestEnv(t, cached)
+	cached.next = env.stats
+
+	env.addTeam(t, "backend", "u1", "u2", "u3")
+
+	now := time.Now()
+	filter := &dto.StatsFilter{From: now.Add(-24 * time.Hour), To: now.Add(24 * time.Hour), Granularity: dto.GranularityDay}
+	getStat := func() *dto.AppStat {
+		t.Helper()
+
+		stat, err := cached.GetStat(ctx, filter)
+		if err != nil {
+			t.Fatalf("GetStat: %v", err)
+		}
+		return stat
+	}
+	getFairness := func() *dto.FairnessReport {
+		t.Helper()
+
+		report, err := cached.GetFairnessReport(ctx, "backend")
+		if err != nil {
+			t.Fatalf("GetFairnessReport: %v", err)
+		}
+		return report
+	}
+
+	if stat := getStat(); stat.OpenedPRCount != 0 {
+		t.Fatalf("opened PRs = %d, want 0", stat.OpenedPRCount)
+	}
+	if report := getFairness(); report.Teams[0].TotalAssignments != 0 {
+		t.Fatalf("total assignments = %d, want 0", report.Teams[0].TotalAssignments)
+	}
+
+	pr := env.createPullRequest(t, "pr-1", "u1")
+	if stat := getStat(); stat.OpenedPRCount != 1 {
+		t.Fatalf("opened PRs after create = %d, want 1 (cache not invalidated)", stat.OpenedPRCount)
+	}
+	if report := getFairness(); report.Teams[0].TotalAssignments != len(pr.AssignedReviewers) {
+		t.Fatalf("total assignments after create = %d, want %d (cache not invalidated)",
+			report.Teams[0].TotalAssignments, len(pr.AssignedReviewers))
+	}
+
+	if _, err := env.pullRequests.MergePullRequest(ctx, &dto.MergePullRequest{PullRequestID: pr.PullRequestID}); err != nil {
+		t.Fatalf("MergePullRequest: %v", err)
+	}
+	if stat := getStat(); stat.OpenedPRCount != 0 || stat.MergedPRCount != 1 {
+		t.Fatalf("opened/merged PRs after merge = %d/%d, want 0/1 (cache not invalidated)",
+			stat.OpenedPRCount, stat.MergedPRCount)
+	}
+
+	if _, err := env.users.SetIsActive(ctx, &dto.UserShort{UserID: "u3", IsActive: false}); err != nil {
+		t.Fatalf("SetIsActive: %v", err)
+	}
+	if stat := getStat(); stat.ActiveUsersCount != 2 {
+		t.Fatalf("active users after deactivation = %d, want 2 (cache not invalidated)", stat.ActiveUsersCount)
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/salex06/pr-service/internal/digest"
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+	"github.com/salex06/pr-service/internal/mail"
+	userRepos "github.com/salex06/pr-service/internal/repos/user"
+	"github.com/salex06/pr-service/internal/tracing"
+)
+
+// Форматы представления дайджеста
+const (
+	DigestFormatText = "text"
+	DigestFormatHTML = "html"
+)
+
+// DigestService представляет компонент, отвечающий за формирование
+// и рассылку сотрудникам дайджеста открытых PR's, ожидающих их ревью
+type DigestService struct {
+	userService    *UserService
+	userRepository *userRepos.UserRepository
+	renderer       *digest.Renderer
+	sender         mail.Sender
+}
+
+// NewDigestService конструирует и возвращает объект DigestService
+func NewDigestService(
+	us *UserService,
+	ur *userRepos.UserRepository,
+	renderer *digest.Renderer,
+	sender mail.Sender) *DigestService {
+	return &DigestService{
+		userService:    us,
+		userRepository: ur,
+		renderer:       renderer,
+		sender:         sender,
+	}
+}
+
+// BuildDigest формирует дайджест открытых PR's, на которые назначен
+// сотрудник с идентификатором userID (сначала самые старые)
+func (ds *DigestService) BuildDigest(ctx context.Context, userID string) (*dto.ReviewDigest, error) {
+	ctx, span := tracing.Start(ctx, "DigestService.BuildDigest")
+	defer span.End()
+
+	user, err := (*ds.userRepository).GetUser(ctx, userID)
+	if err != nil {
+		return nil, internal(err, "unable get user")
+	}
+	if user == nil {
+		return nil, notFound()
+	}
+
+	reviewDigest, err := ds.buildDigest(ctx, user)
+	if err != nil {
+		return nil, internal(err, "unable build digest")
+	}
+
+	return reviewDigest, nil
+}
+
+// RenderDigest формирует представление дайджеста в заданном формате (text/html)
+func (ds *DigestService) RenderDigest(reviewDigest *dto.ReviewDigest, format string) (string, error) {
+	var (
+		body string
+		err  error
+	)
+
+	switch format {
+	case DigestFormatText:
+		body, err = ds.renderer.RenderText(reviewDigest)
+	case DigestFormatHTML:
+		body, err = ds.renderer.RenderHTML(reviewDigest)
+	default:
+		return "", invalidArgument(fmt.Sprintf("unknown digest format: %s", format))
+	}
+
+	if err != nil {
+		return "", internal(err, "unable render digest")
+	}
+
+	return body, nil
+}
+
+// SendDigests рассылает дайджест всем активным сотрудникам,
+// не отказавшимся от рассылки и имеющим открытые PR's на ревью.
+// Возвращает количество отправленных писем
+func (ds *DigestService) SendDigests(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "DigestService.SendDigests")
+	defer span.End()
+
+	recipients, err := (*ds.userRepository).GetDigestRecipients(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("unable get digest recipients: %w", err)
+	}
+
+	sent := 0
+	for _, recipient := range recipients {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+
+		reviewDigest, err := ds.buildDigest(ctx, recipient)
+		if err != nil {
+			slog.ErrorContext(ctx, "error occured when building digest", "user_id", recipient.UserID, "error", err)
+			continue
+		}
+
+		if len(reviewDigest.PullRequests) == 0 {
+			continue
+		}
+
+		if err := ds.sendDigest(ctx, recipient, reviewDigest); err != nil {
+			slog.ErrorContext(ctx, "error occured when sending digest", "user_id", recipient.UserID, "error", err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+func (ds *DigestService) buildDigest(ctx context.Context, user *entity.User) (*dto.ReviewDigest, error) {
+	prs, err := ds.userService.getAssignedPullRequests(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entries := make([]*dto.DigestEntry, 0, len(prs))
+	for _, pr := range prs {
+		if pr == nil || pr.Status != entity.OPEN {
+			continue
+		}
+
+		entry := &dto.DigestEntry{
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			CreatedAt:       pr.CreatedAt,
+		}
+		if pr.CreatedAt != nil {
+			entry.Age = now.Sub(*pr.CreatedAt)
+		}
+		entries = append(entries, entry)
+	}
+
+	slices.SortFunc(entries, func(a, b *dto.DigestEntry) int {
+		return cmp.Compare(b.Age, a.Age)
+	})
+
+	return &dto.ReviewDigest{
+		UserID:       user.UserID,
+		Username:     user.Username,
+		GeneratedAt:  now,
+		PullRequests: entries,
+	}, nil
+}
+
+func (ds *DigestService) sendDigest(ctx context.Context, user *entity.User, reviewDigest *dto.ReviewDigest) error {
+	textBody, err := ds.renderer.RenderText(reviewDigest)
+	if err != nil {
+		return err
+	}
+
+	htmlBody, err := ds.renderer.RenderHTML(reviewDigest)
+	if err != nil {
+		return err
+	}
+
+	return ds.sender.Send(ctx, &mail.Message{
+		To:       []string{*user.Email},
+		Subject:  ds.renderer.Subject(reviewDigest),
+		TextBody: textBody,
+		HTMLBody: htmlBody,
+	})
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+// NotFoundError означает, что ресурс, к которому
+// обращается операция, не существует
+type NotFoundError struct {
+	Message string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Message
+}
+
+// ConflictError означает, что операция противоречит текущему
+// состоянию ресурса; Code уточняет причину (PR_EXISTS, PR_MERGED и т.д.)
+type ConflictError struct {
+	Code    dto.ErrorCode
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// InvalidArgumentError означает, что параметры
+// операции некорректны независимо от состояния ресурсов
+type InvalidArgumentError struct {
+	Message string
+}
+
+func (e *InvalidArgumentError) Error() string {
+	return e.Message
+}
+
+// InternalError означает, что операция завершилась неожиданной
+// ошибкой Err (ошибка БД, истечение срока выполнения запроса и т.д.)
+type InternalError struct {
+	Message string
+	Err     error
+}
+
+func (e *InternalError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Message, e.Err)
+}
+
+func (e *InternalError) Unwrap() error {
+	return e.Err
+}
+
+func notFound() error {
+	return &NotFoundError{Message: "resource not found"}
+}
+
+func conflict(code dto.ErrorCode, message string) error {
+	return &ConflictError{Code: code, Message: message}
+}
+
+func invalidArgument(message string) error {
+	return &InvalidArgumentError{Message: message}
+}
+
+// internal оборачивает неожиданную ошибку err, сохраняя
+// её для errors.Is/errors.As (в т.ч. context.DeadlineExceeded)
+func internal(err error, message string) error {
+	return &InternalError{Message: message, Err: err}
+}
+
+// checkETag проверяет условие If-Match запроса на изменение
+// для текущего значения etag ресурса (PRECONDITION_FAILED - не выполнено)
+func checkETag(ifMatch, etag string) error {
+	if !dto.MatchesETag(ifMatch, etag) {
+		return conflict(dto.PreconditionFailed, "resource version does not match If-Match")
+	}
+
+	return nil
+}
+
+// updateFailed оборачивает ошибку обновления ресурса: если ресурс был
+// изменен параллельным запросом (entity.ErrStaleVersion), возвращается
+// конфликт VERSION_CONFLICT, иначе - внутренняя ошибка
+func updateFailed(err error, message string) error {
+	if errors.Is(err, entity.ErrStaleVersion) {
+		return conflict(dto.VersionConflict, "resource was modified concurrently, retry the request")
+	}
+
+	return internal(err, message)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/salex06/pr-service/internal/converter"
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+	"github.com/salex06/pr-service/internal/metrics"
+	"github.com/salex06/pr-service/internal/tracing"
+)
+
+// CreatePullRequests выполняет открытие нескольких PR's и возвращает результат
+// по каждому из них в порядке запроса. PR не создается, если он уже существует
+// (или повторяется в пакете) и если не найден его автор или команда автора.
+// Ревьюеры подбираются так же, как при открытии одного PR.
+// PR's пакета сохраняются вместе с назначениями в одной транзакции:
+// при ошибке сохранения не создается ни один PR и запрос завершается ошибкой
+func (svc *PullRequestService) CreatePullRequests(ctx context.Context, req *dto.BatchCreatePullRequests) ([]*dto.BatchCreateResult, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.CreatePullRequests")
+	defer span.End()
+
+	results := make([]*dto.BatchCreateResult, 0, len(req.PullRequests))
+	pullRequests := make([]*dto.PullRequest, 0, len(req.PullRequests))
+	seen := make(map[string]bool, len(req.PullRequests))
+	teamExists := make(map[string]bool)
+	createTime := time.Now()
+
+	for _, item := range req.PullRequests {
+		result := &dto.BatchCreateResult{PullRequestID: item.PullRequestID}
+		results = append(results, result)
+
+		if seen[item.PullRequestID] {
+			result.Error = batchItemError(dto.PrExists, fmt.Sprintf("%s is repeated in the batch", item.PullRequestID))
+			continue
+		}
+		seen[item.PullRequestID] = true
+
+		prExists, err := (*svc.prRepo).PullRequestExists(ctx, item.PullRequestID)
+		if err != nil {
+			return nil, internal(err, "unable check PR")
+		}
+		if prExists {
+			result.Error = batchItemError(dto.PrExists, fmt.Sprintf("%s already exists", item.PullRequestID))
+			continue
+		}
+
+		prAuthor, err := (*svc.userRepo).GetUser(ctx, item.AuthorID)
+		if err != nil {
+			return nil, internal(err, "unable get author")
+		}
+		if prAuthor == nil {
+			result.Error = batchItemError(dto.NotFound, "author not found")
+			continue
+		}
+
+		exists, ok := teamExists[prAuthor.TeamName]
+		if !ok {
+			exists, err = (*svc.teamRepo).TeamExists(ctx, prAuthor.TeamName)
+			if err != nil {
+				return nil, internal(err, "unable check team")
+			}
+			teamExists[prAuthor.TeamName] = exists
+		}
+		if !exists {
+			result.Error = batchItemError(dto.NotFound, "author team not found")
+			continue
+		}
+
+		reviewerIds, err := (*svc.userRepo).ChooseReviewers(ctx, prAuthor)
+		if err != nil {
+			return nil, internal(err, "unable choose reviewers")
+		}
+
+		pullRequest := &dto.PullRequest{
+			PullRequestID:       item.PullRequestID,
+			PullRequestName:     item.PullRequestName,
+			AuthorID:            item.AuthorID,
+			Status:              entity.OPEN,
+			AssignedReviewers:   append(make([]string, 0, dto.MaxAssignedReviewers), reviewerIds...),
+			CreatedAt:           &createTime,
+			PullRequestMetadata: converter.ConvertCreatePrToMetadata(item),
+		}
+		pullRequests = append(pullRequests, pullRequest)
+		result.Created = true
+		result.PullRequest = pullRequest
+	}
+
+	if len(pullRequests) == 0 {
+		return results, nil
+	}
+
+	if err := svc.savePullRequests(ctx, pullRequests); err != nil {
+		return nil, err
+	}
+	invalidate(svc.statsCache)
+
+	return results, nil
+}
+
+// savePullRequests сохраняет PR's пакета вместе с назначениями ревьюеров
+// и после сохранения публикует события назначения и записи аудита
+func (svc *PullRequestService) savePullRequests(ctx context.Context, pullRequests []*dto.PullRequest) error {
+	prEntities := make([]*entity.PullRequest, 0, len(pullRequests))
+	assignments := make([]*entity.AssignedReviewers, 0, len(pullRequests)*dto.MaxAssignedReviewers)
+	for _, pullRequest := range pullRequests {
+		prEntities = append(prEntities, converter.ConvertPrDtoToPrEntity(pullRequest))
+		for _, revID := range pullRequest.AssignedReviewers {
+			assignments = append(assignments, &entity.AssignedReviewers{
+				UserID:        revID,
+				PullRequestID: pullRequest.PullRequestID,
+				Reason:        entity.InitialAssignment,
+			})
+		}
+	}
+
+	if err := (*svc.batchRepo).SavePullRequestsWithAssignments(ctx, prEntities, assignments); err != nil {
+		return internal(err, "unable save PRs")
+	}
+	for i, prEntity := range prEntities {
+		pullRequests[i].Version = prEntity.Version
+	}
+
+	for _, pullRequest := range pullRequests {
+		for _, revID := range pullRequest.AssignedReviewers {
+			metrics.AssignmentsCreatedTotal.WithLabelValues(string(entity.InitialAssignment)).Inc()
+			svc.events.Publish(&dto.AssignmentEvent{
+				Type:          dto.AssignmentAssigned,
+				PullRequestID: pullRequest.PullRequestID,
+				ReviewerID:    revID,
+				Reason:        entity.InitialAssignment,
+				OccurredAt:    time.Now(),
+			})
+		}
+		svc.auditService.Record(ctx, AuditActionPullRequestCreate, AuditTargetPullRequest,
+			append([]string{pullRequest.PullRequestID}, pullRequest.AssignedReviewers...), nil, pullRequest)
+	}
+
+	return nil
+}
+
+func batchItemError(code dto.ErrorCode, message string) *dto.BatchItemError {
+	return &dto.BatchItemError{Code: code, Message: message}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/salex06/pr-service/internal/converter"
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+	"github.com/salex06/pr-service/internal/metrics"
+	"github.com/salex06/pr-service/internal/tracing"
+)
+
+// AssignReviewer вручную назначает сотрудника ревьюером открытого PR.
+// Назначить можно только активного участника команды автора, который
+// еще не является ревьюером PR, если у PR меньше dto.MaxAssignedReviewers ревьюеров
+func (svc *PullRequestService) AssignReviewer(ctx context.Context, req *dto.ManualAssignment) (*dto.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.AssignReviewer")
+	defer span.End()
+
+	pr, err := (*svc.prRepo).GetPullRequest(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable get pull request")
+	}
+	reviewer, err := (*svc.userRepo).GetUser(ctx, req.UserID)
+	if err != nil {
+		return nil, internal(err, "unable get reviewer")
+	}
+	if pr == nil || reviewer == nil {
+		return nil, notFound()
+	}
+
+	if pr.Status == entity.MERGED {
+		return nil, conflict(dto.PrMerged, "cannot assign reviewer on merged PR")
+	}
+	if err := checkETag(req.IfMatch, dto.ETag(pr.Version)); err != nil {
+		return nil, err
+	}
+
+	author, err := (*svc.userRepo).GetUser(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, internal(err, "unable get author")
+	}
+	reviewers, err := (*svc.revsRepo).GetAssignedReviewersIds(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable get assigned reviewers")
+	}
+
+	switch {
+	case reviewer.UserID == pr.AuthorID:
+		return nil, conflict(dto.NotAssignable, "author cannot review own PR")
+	case !reviewer.IsActive:
+		return nil, conflict(dto.NotAssignable, "reviewer is inactive")
+	case author == nil || reviewer.TeamName != author.TeamName:
+		return nil, conflict(dto.NotAssignable, "reviewer is not a member of the author's team")
+	case slices.Contains(reviewers, reviewer.UserID):
+		return nil, conflict(dto.NotAssignable, "reviewer is already assigned to this PR")
+	case len(reviewers) >= dto.MaxAssignedReviewers:
+		return nil, conflict(dto.NotAssignable, "PR already has the maximum number of reviewers")
+	}
+
+	before := converter.ConvertPrToDto(pr, slices.Clone(reviewers))
+
+	// увеличение версии PR до изменения назначений не дает параллельным
+	// запросам изменить тот же PR
+	if err := (*svc.prRepo).UpdatePullRequest(ctx, pr); err != nil {
+		return nil, updateFailed(err, "unable update pull request")
+	}
+
+	err = (*svc.revsRepo).CreateAssignment(ctx, reviewer.UserID, pr.PullRequestID, entity.ManualAssignment)
+	if err != nil {
+		return nil, internal(err, "unable create assignment")
+	}
+
+	metrics.AssignmentsCreatedTotal.WithLabelValues(string(entity.ManualAssignment)).Inc()
+	invalidate(svc.statsCache)
+
+	svc.events.Publish(&dto.AssignmentEvent{
+		Type:          dto.AssignmentAssigned,
+		PullRequestID: pr.PullRequestID,
+		ReviewerID:    reviewer.UserID,
+		Reason:        entity.ManualAssignment,
+		OccurredAt:    time.Now(),
+	})
+
+	reviewers, err = (*svc.revsRepo).GetAssignedReviewersIds(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable get assigned reviewers")
+	}
+	after := converter.ConvertPrToDto(pr, reviewers)
+	svc.auditService.Record(ctx, AuditActionPullRequestAssign, AuditTargetPullRequest,
+		[]string{pr.PullRequestID, reviewer.UserID}, before, after)
+
+	return after, nil
+}
+
+// UnassignReviewer вручную снимает ревьюера с открытого PR
+// без назначения замены
+func (svc *PullRequestService) UnassignReviewer(ctx context.Context, req *dto.ManualAssignment) (*dto.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.UnassignReviewer")
+	defer span.End()
+
+	pr, err := (*svc.prRepo).GetPullRequest(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable get pull request")
+	}
+	if pr == nil {
+		return nil, notFound()
+	}
+
+	if pr.Status == entity.MERGED {
+		return nil, conflict(dto.PrMerged, "cannot unassign reviewer on merged PR")
+	}
+	if err := checkETag(req.IfMatch, dto.ETag(pr.Version)); err != nil {
+		return nil, err
+	}
+
+	reviewers, err := (*svc.revsRepo).GetAssignedReviewersIds(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable get assigned reviewers")
+	}
+	if !slices.Contains(reviewers, req.UserID) {
+		return nil, conflict(dto.NotAssigned, "reviewer is not assigned to this PR")
+	}
+
+	before := converter.ConvertPrToDto(pr, slices.Clone(reviewers))
+
+	if err := (*svc.prRepo).UpdatePullRequest(ctx, pr); err != nil {
+		return nil, updateFailed(err, "unable update pull request")
+	}
+
+	err = (*svc.revsRepo).CloseAssignment(ctx, req.UserID, pr.PullRequestID, entity.ManualAssignment, nil)
+	if err != nil {
+		return nil, internal(err, "unable close assignment")
+	}
+	invalidate(svc.statsCache)
+
+	svc.events.Publish(&dto.AssignmentEvent{
+		Type:          dto.AssignmentUnassigned,
+		PullRequestID: pr.PullRequestID,
+		ReviewerID:    req.UserID,
+		Reason:        entity.ManualAssignment,
+		OccurredAt:    time.Now(),
+	})
+
+	reviewers, err = (*svc.revsRepo).GetAssignedReviewersIds(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable get assigned reviewers")
+	}
+	after := converter.ConvertPrToDto(pr, reviewers)
+	svc.auditService.Record(ctx, AuditActionPullRequestUnassign, AuditTargetPullRequest,
+		[]string{pr.PullRequestID, req.UserID}, before, after)
+
+	return after, nil
+}
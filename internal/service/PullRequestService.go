@@ -3,239 +3,400 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
-	"net/http"
+	"log/slog"
 	"slices"
 	"time"
 
 	"github.com/salex06/pr-service/internal/converter"
 	"github.com/salex06/pr-service/internal/dto"
 	"github.com/salex06/pr-service/internal/entity"
+	"github.com/salex06/pr-service/internal/metrics"
+	batchRepos "github.com/salex06/pr-service/internal/repos/batch"
 	prRepos "github.com/salex06/pr-service/internal/repos/pr"
 	revsRepos "github.com/salex06/pr-service/internal/repos/reviewers"
 	teamRepos "github.com/salex06/pr-service/internal/repos/team"
 	userRepos "github.com/salex06/pr-service/internal/repos/user"
+	"github.com/salex06/pr-service/internal/tracing"
+)
+
+// Ограничения размера выборки PR's
+const (
+	defaultPullRequestLimit = 100
+	maxPullRequestLimit     = 1000
 )
 
 // PullRequestService представляет компонент,
 // отвечающий за выполнение бизнес-логики,
 // связанной с pull-request`ми
 type PullRequestService struct {
-	prRepo   *prRepos.PullRequestRepository
-	revsRepo *revsRepos.AssignedRevsRepository
-	userRepo *userRepos.UserRepository
-	teamRepo *teamRepos.TeamRepository
+	prRepo    *prRepos.PullRequestRepository
+	revsRepo  *revsRepos.AssignedRevsRepository
+	userRepo  *userRepos.UserRepository
+	teamRepo  *teamRepos.TeamRepository
+	batchRepo *batchRepos.PullRequestBatchRepository
+
+	auditService *AuditService
+	events       *AssignmentEventBroker
+	statsCache   CacheInvalidator
 }
 
 // NewPullRequestService конструирует и возвращает объект PullRequestService
+// (statsCache - кеш статистики, сбрасываемый после изменений; может быть nil)
 func NewPullRequestService(
 	prRepo *prRepos.PullRequestRepository,
 	revsRepo *revsRepos.AssignedRevsRepository,
 	userRepo *userRepos.UserRepository,
-	teamRepo *teamRepos.TeamRepository) *PullRequestService {
+	teamRepo *teamRepos.TeamRepository,
+	batchRepo *batchRepos.PullRequestBatchRepository,
+	auditService *AuditService,
+	events *AssignmentEventBroker,
+	statsCache CacheInvalidator) *PullRequestService {
 	return &PullRequestService{
-		prRepo:   prRepo,
-		revsRepo: revsRepo,
-		userRepo: userRepo,
-		teamRepo: teamRepo,
+		prRepo:       prRepo,
+		revsRepo:     revsRepo,
+		userRepo:     userRepo,
+		teamRepo:     teamRepo,
+		batchRepo:    batchRepo,
+		auditService: auditService,
+		events:       events,
+		statsCache:   statsCache,
 	}
 }
 
 // CreatePullRequest выполняет открытие нового PR,
 // случайным образом назначая до 2-х ревьюеров из
 // команды автора PR
-func (svc *PullRequestService) CreatePullRequest(req *dto.CreatePullRequest) (*dto.PullRequest, *dto.ErrorResponse) {
-	prAuthor, _ := (*svc.userRepo).GetUser(context.Background(), req.AuthorID)
+func (svc *PullRequestService) CreatePullRequest(ctx context.Context, req *dto.CreatePullRequest) (*dto.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.CreatePullRequest")
+	defer span.End()
+
+	prAuthor, err := (*svc.userRepo).GetUser(ctx, req.AuthorID)
+	if err != nil {
+		return nil, internal(err, "unable get author")
+	}
 	if prAuthor == nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusNotFound,
-			Error: map[string]string{
-				"code":    string(dto.NotFound),
-				"message": "resource not found",
-			},
-		}
+		return nil, notFound()
 	}
 
-	if exists, _ := (*svc.teamRepo).TeamExists(context.Background(), prAuthor.TeamName); !exists {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusNotFound,
-			Error: map[string]string{
-				"code":    string(dto.NotFound),
-				"message": "resource not found",
-			},
-		}
+	teamExists, err := (*svc.teamRepo).TeamExists(ctx, prAuthor.TeamName)
+	if err != nil {
+		return nil, internal(err, "unable check team")
+	}
+	if !teamExists {
+		return nil, notFound()
 	}
 
-	if exists, _ := (*svc.prRepo).PullRequestExists(context.TODO(), req.PullRequestID); exists {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusConflict,
-			Error: map[string]string{
-				"code":    string(dto.PrExists),
-				"message": fmt.Sprintf("%s already exists", req.PullRequestID),
-			},
-		}
+	prExists, err := (*svc.prRepo).PullRequestExists(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable check PR")
+	}
+	if prExists {
+		return nil, conflict(dto.PrExists, fmt.Sprintf("%s already exists", req.PullRequestID))
 	}
 
 	createTime := time.Now()
 	pullRequest := &dto.PullRequest{
-		PullRequestID:     req.PullRequestID,
-		PullRequestName:   req.PullRequestName,
-		AuthorID:          req.AuthorID,
-		Status:            entity.OPEN,
-		AssignedReviewers: make([]string, 0, dto.MaxAssignedReviewers),
-		CreatedAt:         &createTime,
-	}
-	reviewerIds, err := (*svc.userRepo).ChooseReviewers(context.Background(), prAuthor)
-	if err != nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusInternalServerError,
-			Error: map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": fmt.Sprintf("unable choose reviewers: %s", err),
-			},
-		}
+		PullRequestID:       req.PullRequestID,
+		PullRequestName:     req.PullRequestName,
+		AuthorID:            req.AuthorID,
+		Status:              entity.OPEN,
+		AssignedReviewers:   make([]string, 0, dto.MaxAssignedReviewers),
+		CreatedAt:           &createTime,
+		PullRequestMetadata: converter.ConvertCreatePrToMetadata(req),
+	}
+	reviewerIds, err := (*svc.userRepo).ChooseReviewers(ctx, prAuthor)
+	if err != nil {
+		return nil, internal(err, "unable choose reviewers")
 	}
 
-	err = (*svc.prRepo).SavePullRequest(context.Background(), converter.ConvertPrDtoToPrEntity(pullRequest))
+	prEntity := converter.ConvertPrDtoToPrEntity(pullRequest)
+	err = (*svc.prRepo).SavePullRequest(ctx, prEntity)
 	if err != nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusInternalServerError,
-			Error: map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": fmt.Sprintf("unable save PR: %s", err),
-			},
-		}
+		return nil, internal(err, "unable save PR")
 	}
-	svc.assignReviewers(pullRequest, reviewerIds)
+	pullRequest.Version = prEntity.Version
+	svc.assignReviewers(ctx, pullRequest, reviewerIds)
+	invalidate(svc.statsCache)
+	svc.auditService.Record(ctx, AuditActionPullRequestCreate, AuditTargetPullRequest,
+		append([]string{pullRequest.PullRequestID}, pullRequest.AssignedReviewers...), nil, pullRequest)
 
 	return pullRequest, nil
 }
 
-func (svc *PullRequestService) assignReviewers(pullRequest *dto.PullRequest, reviewers []string) {
+func (svc *PullRequestService) assignReviewers(ctx context.Context, pullRequest *dto.PullRequest, reviewers []string) {
 	for _, revID := range reviewers {
-		err := (*svc.revsRepo).CreateAssignment(context.Background(), revID, pullRequest.PullRequestID)
+		err := (*svc.revsRepo).CreateAssignment(ctx, revID, pullRequest.PullRequestID, entity.InitialAssignment)
 		if err != nil {
-			log.Printf("error occured when creating assignment: %s\n", err)
+			slog.ErrorContext(ctx, "error occured when creating assignment",
+				"pull_request_id", pullRequest.PullRequestID, "user_id", revID, "error", err)
+			continue
 		}
+		metrics.AssignmentsCreatedTotal.WithLabelValues(string(entity.InitialAssignment)).Inc()
+		svc.events.Publish(&dto.AssignmentEvent{
+			Type:          dto.AssignmentAssigned,
+			PullRequestID: pullRequest.PullRequestID,
+			ReviewerID:    revID,
+			Reason:        entity.InitialAssignment,
+			OccurredAt:    time.Now(),
+		})
 	}
 
 	pullRequest.AssignedReviewers = append(pullRequest.AssignedReviewers, reviewers...)
 }
 
+// GetPullRequest возвращает PR с идентификатором prID
+// вместе с назначенными ревьюерами
+func (svc *PullRequestService) GetPullRequest(ctx context.Context, prID string) (*dto.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.GetPullRequest")
+	defer span.End()
+
+	pullRequest, err := (*svc.prRepo).GetPullRequest(ctx, prID)
+	if err != nil {
+		return nil, internal(err, "unable get pull request")
+	}
+	if pullRequest == nil {
+		return nil, notFound()
+	}
+
+	reviewers, err := (*svc.revsRepo).GetAssignedReviewersIds(ctx, pullRequest.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable get assigned reviewers")
+	}
+
+	return converter.ConvertPrToDto(pullRequest, reviewers), nil
+}
+
 // MergePullRequest выполняет закрытие PR
 // и перевод в статус MERGED
-func (svc *PullRequestService) MergePullRequest(req *dto.MergePullRequest) (*dto.PullRequest, *dto.ErrorResponse) {
-	pullRequest, _ := (*svc.prRepo).GetPullRequest(context.Background(), req.PullRequestID)
+func (svc *PullRequestService) MergePullRequest(ctx context.Context, req *dto.MergePullRequest) (*dto.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.MergePullRequest")
+	defer span.End()
+
+	pullRequest, err := (*svc.prRepo).GetPullRequest(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable get pull request")
+	}
 	if pullRequest == nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusNotFound,
-			Error: map[string]string{
-				"code":    string(dto.NotFound),
-				"message": "resource not found",
-			},
-		}
+		return nil, notFound()
+	}
+	if err := checkETag(req.IfMatch, dto.ETag(pullRequest.Version)); err != nil {
+		return nil, err
 	}
 
-	reviewers, _ := (*svc.revsRepo).GetAssignedReviewersIds(context.Background(), pullRequest.PullRequestID)
+	reviewers, err := (*svc.revsRepo).GetAssignedReviewersIds(ctx, pullRequest.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable get assigned reviewers")
+	}
 	if pullRequest.Status == entity.MERGED {
 		return converter.ConvertPrToDto(pullRequest, reviewers), nil
 	}
 
+	before := converter.ConvertPrToDto(pullRequest, reviewers)
 	pullRequest.MergedAt = new(time.Time)
 	*pullRequest.MergedAt = time.Now()
 	pullRequest.Status = entity.MERGED
-	err := (*svc.prRepo).UpdatePullRequest(context.Background(), pullRequest)
-	if err != nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusInternalServerError,
-			Error: map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": fmt.Sprintf("unable update pull request: %s", err),
-			},
-		}
+	err = (*svc.prRepo).UpdatePullRequest(ctx, pullRequest)
+	if err != nil {
+		return nil, updateFailed(err, "unable update pull request")
 	}
+	invalidate(svc.statsCache)
 
-	return converter.ConvertPrToDto(pullRequest, reviewers), nil
+	after := converter.ConvertPrToDto(pullRequest, reviewers)
+	svc.auditService.Record(ctx, AuditActionPullRequestMerge, AuditTargetPullRequest,
+		[]string{pullRequest.PullRequestID}, before, after)
+
+	return after, nil
 }
 
 // ReassignPullRequest выполняет переназначение одного сотрудника
 // на открытый PR (при наличии активных сотрудников в команде)
-func (svc *PullRequestService) ReassignPullRequest(req *dto.ReassignPullRequest) (*dto.ReassignPrResponse, *dto.ErrorResponse) {
-	pr, _ := (*svc.prRepo).GetPullRequest(context.Background(), req.PullRequestID)
-	userToReplace, _ := (*svc.userRepo).GetUser(context.Background(), req.OldReviewerID)
+func (svc *PullRequestService) ReassignPullRequest(ctx context.Context, req *dto.ReassignPullRequest) (*dto.ReassignPrResponse, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.ReassignPullRequest")
+	defer span.End()
+
+	pr, err := (*svc.prRepo).GetPullRequest(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable get pull request")
+	}
+	userToReplace, err := (*svc.userRepo).GetUser(ctx, req.OldReviewerID)
+	if err != nil {
+		return nil, internal(err, "unable get reviewer")
+	}
 
 	if pr == nil || userToReplace == nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusNotFound,
-			Error: map[string]string{
-				"code":    string(dto.NotFound),
-				"message": "resource not found",
-			},
-		}
+		return nil, notFound()
 	}
 
 	if pr.Status == entity.MERGED {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusConflict,
-			Error: map[string]string{
-				"code":    string(dto.PrMerged),
-				"message": "cannot reassign on merged PR",
-			},
-		}
+		return nil, conflict(dto.PrMerged, "cannot reassign on merged PR")
+	}
+	if err := checkETag(req.IfMatch, dto.ETag(pr.Version)); err != nil {
+		return nil, err
 	}
 
-	reviewers, _ := (*svc.revsRepo).GetAssignedReviewersIds(context.Background(), pr.PullRequestID)
+	reviewers, err := (*svc.revsRepo).GetAssignedReviewersIds(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable get assigned reviewers")
+	}
 	if !slices.Contains(reviewers, userToReplace.UserID) {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusConflict,
-			Error: map[string]string{
-				"code":    string(dto.NotAssigned),
-				"message": "reviewer is not assigned to this PR",
-			},
-		}
+		return nil, conflict(dto.NotAssigned, "reviewer is not assigned to this PR")
 	}
 
+	before := converter.ConvertPrToDto(pr, slices.Clone(reviewers))
+
 	idsExclusionList := make([]string, 0, len(reviewers)+1)
 	idsExclusionList = append(idsExclusionList, reviewers...)
 	idsExclusionList = append(idsExclusionList, pr.AuthorID)
 
-	reassignedReviewerID, _ := (*svc.userRepo).ReassignReviewer(
-		context.Background(),
+	reassignedReviewerID, err := (*svc.userRepo).ReassignReviewer(
+		ctx,
 		userToReplace.TeamName,
 		idsExclusionList,
 	)
+	if err != nil {
+		return nil, internal(err, "unable choose replacement reviewer")
+	}
 	if reassignedReviewerID == nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusConflict,
-			Error: map[string]string{
-				"code":    string(dto.NoCandidate),
-				"message": "no active replacement candidate in team",
-			},
-		}
+		metrics.NoCandidateTotal.Inc()
+		return nil, conflict(dto.NoCandidate, "no active replacement candidate in team")
+	}
+
+	// увеличение версии PR до изменения назначений не дает параллельным
+	// запросам (слиянию или другому переназначению) изменить тот же PR
+	if err := (*svc.prRepo).UpdatePullRequest(ctx, pr); err != nil {
+		return nil, updateFailed(err, "unable update pull request")
 	}
 
-	err := (*svc.revsRepo).DeleteAssignment(context.Background(), userToReplace.UserID, pr.PullRequestID)
+	err = (*svc.revsRepo).CloseAssignment(
+		ctx,
+		userToReplace.UserID,
+		pr.PullRequestID,
+		entity.ReassignAssignment,
+		reassignedReviewerID,
+	)
 	if err != nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusInternalServerError,
-			Error: map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": fmt.Sprintf("unable delete assignment: %s", err),
-			},
-		}
+		return nil, internal(err, "unable close assignment")
 	}
 
-	err = (*svc.revsRepo).CreateAssignment(context.Background(), *reassignedReviewerID, pr.PullRequestID)
+	err = (*svc.revsRepo).CreateAssignment(ctx, *reassignedReviewerID, pr.PullRequestID, entity.ReassignAssignment)
 	if err != nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusInternalServerError,
-			Error: map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": fmt.Sprintf("unable create assignment: %s", err),
-			},
-		}
+		return nil, internal(err, "unable create assignment")
+	}
+
+	metrics.AssignmentsCreatedTotal.WithLabelValues(string(entity.ReassignAssignment)).Inc()
+	metrics.ReassignmentsTotal.Inc()
+	invalidate(svc.statsCache)
+
+	reassignedAt := time.Now()
+	svc.events.Publish(&dto.AssignmentEvent{
+		Type:          dto.AssignmentUnassigned,
+		PullRequestID: pr.PullRequestID,
+		ReviewerID:    userToReplace.UserID,
+		Reason:        entity.ReassignAssignment,
+		ReplacedBy:    reassignedReviewerID,
+		OccurredAt:    reassignedAt,
+	})
+	svc.events.Publish(&dto.AssignmentEvent{
+		Type:          dto.AssignmentAssigned,
+		PullRequestID: pr.PullRequestID,
+		ReviewerID:    *reassignedReviewerID,
+		Reason:        entity.ReassignAssignment,
+		OccurredAt:    reassignedAt,
+	})
+
+	reviewers, err = (*svc.revsRepo).GetAssignedReviewersIds(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable get assigned reviewers")
+	}
+	after := converter.ConvertPrToReassigningDto(pr, reviewers, *reassignedReviewerID)
+	svc.auditService.Record(ctx, AuditActionPullRequestReassign, AuditTargetPullRequest,
+		[]string{pr.PullRequestID, userToReplace.UserID, *reassignedReviewerID}, before, after)
+
+	return after, nil
+}
+
+// SubmitVerdict сохраняет решение ревьюера по открытому PR
+// (решение может вынести только действующий ревьюер PR)
+func (svc *PullRequestService) SubmitVerdict(ctx context.Context, req *dto.SubmitVerdict) (*dto.ReviewVerdict, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.SubmitVerdict")
+	defer span.End()
+
+	pr, err := (*svc.prRepo).GetPullRequest(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable get pull request")
+	}
+	if pr == nil {
+		return nil, notFound()
+	}
+	if pr.Status == entity.MERGED {
+		return nil, conflict(dto.PrMerged, "cannot submit verdict on merged PR")
+	}
+
+	reviewers, err := (*svc.revsRepo).GetAssignedReviewersIds(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, internal(err, "unable get assigned reviewers")
+	}
+	if !slices.Contains(reviewers, req.UserID) {
+		return nil, conflict(dto.NotAssigned, "reviewer is not assigned to this PR")
+	}
+
+	verdict := &entity.ReviewVerdict{
+		PullRequestID: pr.PullRequestID,
+		UserID:        req.UserID,
+		Verdict:       req.Verdict,
+		CreatedAt:     new(time.Time),
+	}
+	*verdict.CreatedAt = time.Now()
+	if err := (*svc.prRepo).SaveVerdict(ctx, verdict); err != nil {
+		return nil, internal(err, "unable save review verdict")
+	}
+	invalidate(svc.statsCache)
+
+	resp := converter.ConvertVerdictToDto(verdict)
+	svc.auditService.Record(ctx, AuditActionPullRequestVerdict, AuditTargetPullRequest,
+		[]string{pr.PullRequestID}, nil, resp)
+
+	return resp, nil
+}
+
+// GetReviewerTimeline возвращает полную историю назначений ревьюеров
+// на PR с идентификатором prID, включая снятых и замененных сотрудников
+func (svc *PullRequestService) GetReviewerTimeline(ctx context.Context, prID string) (*dto.ReviewerTimeline, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.GetReviewerTimeline")
+	defer span.End()
+
+	pr, err := (*svc.prRepo).GetPullRequest(ctx, prID)
+	if err != nil {
+		return nil, internal(err, "unable get pull request")
+	}
+	if pr == nil {
+		return nil, notFound()
+	}
+
+	assignments, err := (*svc.revsRepo).GetAssignmentHistory(ctx, prID)
+	if err != nil {
+		return nil, internal(err, "unable get assignment history")
+	}
+
+	return converter.ConvertAssignmentsToTimeline(prID, assignments), nil
+}
+
+// ListPullRequests возвращает краткую информацию
+// о PR's, удовлетворяющих фильтру (сначала самые новые)
+func (svc *PullRequestService) ListPullRequests(ctx context.Context, filter *dto.PullRequestFilter) ([]dto.PullRequestShort, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.ListPullRequests")
+	defer span.End()
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultPullRequestLimit
+	}
+	filter.Limit = min(filter.Limit, maxPullRequestLimit)
+	filter.Offset = max(filter.Offset, 0)
+
+	prs, err := (*svc.prRepo).ListPullRequests(ctx, filter)
+	if err != nil {
+		return nil, internal(err, "unable list pull requests")
 	}
 
-	reviewers, _ = (*svc.revsRepo).GetAssignedReviewersIds(context.Background(), pr.PullRequestID)
-	return converter.ConvertPrToReassigningDto(pr, reviewers, *reassignedReviewerID), nil
+	return converter.ConvertPrToShortPr(prs), nil
 }
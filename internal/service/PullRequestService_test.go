@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+	batchRepos "github.com/salex06/pr-service/internal/repos/batch"
+	prRepos "github.com/salex06/pr-service/internal/repos/pr"
+)
+
+func TestSubmitVerdict(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, nil)
+	env.addTeam(t, "backend", "u1", "u2")
+	env.createPullRequest(t, "pr-1", "u1")
+	merged := env.createPullRequest(t, "pr-merged", "u1")
+	if _, err := env.pullRequests.MergePullRequest(ctx, &dto.MergePullRequest{PullRequestID: merged.PullRequestID}); err != nil {
+		t.Fatalf("MergePullRequest: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		req      *dto.SubmitVerdict
+		wantCode dto.ErrorCode
+		notFound bool
+	}{
+		{name: "assigned reviewer", req: &dto.SubmitVerdict{PullRequestID: "pr-1", UserID: "u2", Verdict: entity.Approved}},
+		{name: "author is not a reviewer", req: &dto.SubmitVerdict{PullRequestID: "pr-1", UserID: "u1", Verdict: entity.Approved},
+			wantCode: dto.NotAssigned},
+		{name: "merged PR", req: &dto.SubmitVerdict{PullRequestID: "pr-merged", UserID: "u2", Verdict: entity.Approved},
+			wantCode: dto.PrMerged},
+		{name: "unknown PR", req: &dto.SubmitVerdict{PullRequestID: "missing", UserID: "u2", Verdict: entity.Approved},
+			notFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := env.pullRequests.SubmitVerdict(ctx, tt.req)
+
+			var conflictErr *ConflictError
+			var notFoundErr *NotFoundError
+			switch {
+			case tt.notFound:
+				if !errors.As(err, &notFoundErr) {
+					t.Fatalf("err = %v, want NotFoundError", err)
+				}
+			case tt.wantCode != "":
+				if !errors.As(err, &conflictErr) || conflictErr.Code != tt.wantCode {
+					t.Fatalf("err = %v, want conflict %s", err, tt.wantCode)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("SubmitVerdict: %v", err)
+				}
+				if resp.Verdict != tt.req.Verdict || resp.CreatedAt == nil {
+					t.Errorf("verdict = %+v, want %s with created_at", resp, tt.req.Verdict)
+				}
+			}
+		})
+	}
+}
+
+// racingBatchRepository перед сохранением пакета открывает PR raceID,
+// имитируя конкурентный запрос, создавший PR после проверки его наличия
+type racingBatchRepository struct {
+	batchRepos.PullRequestBatchRepository
+	prRepo prRepos.PullRequestRepository
+	raceID string
+}
+
+func (repo *racingBatchRepository) SavePullRequestsWithAssignments(
+	ctx context.Context,
+	prs []*entity.PullRequest,
+	assignments []*entity.AssignedReviewers,
+) error {
+	if err := repo.prRepo.SavePullRequest(ctx, &entity.PullRequest{
+		PullRequestID: repo.raceID, PullRequestName: repo.raceID, AuthorID: "u1", Status: entity.OPEN,
+	}); err != nil {
+		return err
+	}
+
+	return repo.PullRequestBatchRepository.SavePullRequestsWithAssignments(ctx, prs, assignments)
+}
+
+func TestCreatePullRequestsIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, nil)
+	env.addTeam(t, "backend", "u1", "u2", "u3")
+	env.batchRepo = &racingBatchRepository{PullRequestBatchRepository: env.batchRepo, prRepo: env.prRepo, raceID: "pr-2"}
+
+	results, err := env.pullRequests.CreatePullRequests(ctx, &dto.BatchCreatePullRequests{
+		PullRequests: []*dto.CreatePullRequest{
+			{PullRequestID: "pr-1", PullRequestName: "pr-1", AuthorID: "u1"},
+			{PullRequestID: "pr-2", PullRequestName: "pr-2", AuthorID: "u2"},
+		},
+	})
+	var internalErr *InternalError
+	if !errors.As(err, &internalErr) {
+		t.Fatalf("CreatePullRequests = %+v, %v, want InternalError", results, err)
+	}
+
+	// Ни PR из пакета, ни назначения на него не сохранены
+	if exists, _ := env.prRepo.PullRequestExists(ctx, "pr-1"); exists {
+		t.Fatal("pr-1 saved although the batch failed")
+	}
+	for _, userID := range []string{"u1", "u2", "u3"} {
+		if prIDs, _ := env.revsRepo.GetAssignedPullRequestIds(ctx, userID); len(prIDs) != 0 {
+			t.Fatalf("%s assigned to %v although the batch failed", userID, prIDs)
+		}
+	}
+}
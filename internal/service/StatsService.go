@@ -2,13 +2,15 @@ package service
 
 import (
 	"context"
-	"net/http"
+	"slices"
 
 	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
 	prRepos "github.com/salex06/pr-service/internal/repos/pr"
 	revsRepos "github.com/salex06/pr-service/internal/repos/reviewers"
 	teamRepos "github.com/salex06/pr-service/internal/repos/team"
 	userRepos "github.com/salex06/pr-service/internal/repos/user"
+	"github.com/salex06/pr-service/internal/tracing"
 )
 
 // StatsService представляет собой компонент,
@@ -21,6 +23,11 @@ type StatsService struct {
 	teamRepo *teamRepos.TeamRepository
 }
 
+// fairnessTolerance - допустимое относительное отклонение доли назначений
+// ревьюера от равномерной, при превышении которого он считается
+// перегруженным (или недогруженным)
+const fairnessTolerance = 0.25
+
 // NewStatsService контструирует и возвращает объект StatsService
 func NewStatsService(
 	prRepo *prRepos.PullRequestRepository,
@@ -35,84 +42,88 @@ func NewStatsService(
 	}
 }
 
-// GetStat возвращает базовую статистику по состоянию приложения
-// (в данный момент только бизнес-метрики)
-func (svc *StatsService) GetStat() (*dto.AppStat, *dto.ErrorResponse) {
+// GetStat возвращает статистику по состоянию приложения и аналитику ревью.
+// Фильтр применяется ко всем разделам: при заданной команде численность
+// сотрудников и команд считается только по ней, а PR's и назначения
+// учитываются только созданные в окне [From, To) и относящиеся к членам команды.
+// Численность сотрудников и команд - текущий срез, окно на нее не влияет
+func (svc *StatsService) GetStat(ctx context.Context, filter *dto.StatsFilter) (*dto.AppStat, error) {
+	ctx, span := tracing.Start(ctx, "StatsService.GetStat")
+	defer span.End()
+
 	var stat dto.AppStat
 
-	userCountInfo, err := svc.getUserCountInfo()
-	if err != nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusInternalServerError,
-			Error: map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": "error occured when getting user count info",
-			},
+	if filter.TeamName != "" {
+		members, err := (*svc.userRepo).GetTeamMembers(ctx, filter.TeamName)
+		if err != nil {
+			return nil, internal(err, "error occured when getting team members")
+		}
+		if len(members) == 0 {
+			return nil, notFound()
 		}
-	}
 
-	teamCount, err := svc.getTeamCount()
-	if err != nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusInternalServerError,
-			Error: map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": "error occured when getting team count info",
-			},
+		filter.UserIDs = make([]string, 0, len(members))
+		for _, member := range members {
+			filter.UserIDs = append(filter.UserIDs, member.UserID)
+			if member.IsActive {
+				stat.ActiveUsersCount++
+			}
+		}
+		stat.TotalUsersCount = len(members)
+		stat.TotalTeamsCount = 1
+		stat.UserCountByTeam = []*dto.TeamSize{{TeamName: filter.TeamName, UserCount: len(members)}}
+	} else {
+		userCountInfo, err := svc.getUserCountInfo(ctx)
+		if err != nil {
+			return nil, internal(err, "error occured when getting user count info")
+		}
+
+		teamCount, err := svc.getTeamCount(ctx)
+		if err != nil {
+			return nil, internal(err, "error occured when getting team count info")
 		}
+
+		userCountByTeams, err := svc.getUserCountGroupedByTeams(ctx)
+		if err != nil {
+			return nil, internal(err, "error occured when getting user count by teams info")
+		}
+
+		stat.TotalUsersCount = userCountInfo["total"]
+		stat.ActiveUsersCount = userCountInfo["active"]
+		stat.TotalTeamsCount = teamCount
+		stat.UserCountByTeam = userCountByTeams
 	}
 
-	prCountInfo, err := svc.getPrCountInfo()
+	prCountByStatus, err := (*svc.prRepo).GetPullRequestCountByStatus(ctx, filter)
 	if err != nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusInternalServerError,
-			Error: map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": "error occured when getting PR count info",
-			},
-		}
+		return nil, internal(err, "error occured when getting PR count info")
 	}
 
-	userCountByTeams, err := svc.getUserCountGroupedByTeams()
+	assignmentsCountByUser, err := (*svc.revsRepo).GetAssignmentsCountByReviewerID(ctx, filter)
 	if err != nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusInternalServerError,
-			Error: map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": "error occured when getting user count by teams info",
-			},
-		}
+		return nil, internal(err, "error occured when getting assignments count by user info")
 	}
 
-	assignmentsCountByUser, err := svc.getAssignmentsCountByUsers()
+	reviewAnalytics, err := svc.getReviewAnalytics(ctx, filter)
 	if err != nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusInternalServerError,
-			Error: map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": "error occured when getting assignments count by user info",
-			},
-		}
+		return nil, err
 	}
 
-	stat.TotalUsersCount = userCountInfo["total"]
-	stat.ActiveUsersCount = userCountInfo["active"]
-	stat.TotalTeamsCount = teamCount
-	stat.OpenedPRCount = prCountInfo["open"]
-	stat.MergedPRCount = prCountInfo["merged"]
-	stat.UserCountByTeam = userCountByTeams
+	stat.OpenedPRCount = prCountByStatus[entity.OPEN]
+	stat.MergedPRCount = prCountByStatus[entity.MERGED]
 	stat.AssignmentsCountByUser = assignmentsCountByUser
+	stat.ReviewAnalytics = reviewAnalytics
 
 	return &stat, nil
 }
 
-func (svc *StatsService) getUserCountInfo() (map[string]int, error) {
-	totalUserCount, err := (*svc.userRepo).GetTotalUserCount(context.Background())
+func (svc *StatsService) getUserCountInfo(ctx context.Context) (map[string]int, error) {
+	totalUserCount, err := (*svc.userRepo).GetTotalUserCount(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	activeUserCount, err := (*svc.userRepo).GetActiveUserCount(context.Background())
+	activeUserCount, err := (*svc.userRepo).GetActiveUserCount(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -123,8 +134,8 @@ func (svc *StatsService) getUserCountInfo() (map[string]int, error) {
 	}, nil
 }
 
-func (svc *StatsService) getTeamCount() (int, error) {
-	teamCount, err := (*svc.teamRepo).GetTeamCount(context.Background())
+func (svc *StatsService) getTeamCount(ctx context.Context) (int, error) {
+	teamCount, err := (*svc.teamRepo).GetTeamCount(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -132,37 +143,188 @@ func (svc *StatsService) getTeamCount() (int, error) {
 	return teamCount, nil
 }
 
-func (svc *StatsService) getPrCountInfo() (map[string]int, error) {
-	openPrCount, err := (*svc.prRepo).GetOpenedPullRequestCount(context.Background())
+func (svc *StatsService) getUserCountGroupedByTeams(ctx context.Context) ([]*dto.TeamSize, error) {
+	count, err := (*svc.userRepo).GetUserCountByTeam(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	mergedPrCount, err := (*svc.prRepo).GetMergedPullRequestCount(context.Background())
+	return count, nil
+}
+
+func (svc *StatsService) getReviewAnalytics(ctx context.Context, filter *dto.StatsFilter) (*dto.ReviewAnalytics, error) {
+	mergeTime, err := (*svc.prRepo).GetMergeTimeStats(ctx, filter)
+	if err != nil {
+		return nil, internal(err, "error occured when getting merge time stats")
+	}
+
+	throughput, err := (*svc.prRepo).GetThroughput(ctx, filter)
 	if err != nil {
-		return nil, err
+		return nil, internal(err, "error occured when getting PR throughput")
 	}
 
-	return map[string]int{
-		"open":   openPrCount,
-		"merged": mergedPrCount,
+	firstVerdictTime, err := (*svc.prRepo).GetFirstVerdictTimeStats(ctx, filter)
+	if err != nil {
+		return nil, internal(err, "error occured when getting first verdict time stats")
+	}
+
+	reassignmentsCount, err := (*svc.revsRepo).GetReassignmentCount(ctx, filter)
+	if err != nil {
+		return nil, internal(err, "error occured when getting reassignment count")
+	}
+
+	return &dto.ReviewAnalytics{
+		From:               filter.From,
+		To:                 filter.To,
+		TeamName:           filter.TeamName,
+		Granularity:        filter.Granularity,
+		MergeTime:          mergeTime,
+		FirstVerdictTime:   firstVerdictTime,
+		Throughput:         throughput,
+		ReassignmentsCount: reassignmentsCount,
 	}, nil
 }
 
-func (svc *StatsService) getUserCountGroupedByTeams() ([]*dto.TeamSize, error) {
-	count, err := (*svc.userRepo).GetUserCountByTeam(context.Background())
-	if err != nil {
-		return nil, err
+// GetFairnessReport возвращает отчет о равномерности распределения
+// назначений на ревью в команде teamName (пустая строка - во всех командах)
+func (svc *StatsService) GetFairnessReport(ctx context.Context, teamName string) (*dto.FairnessReport, error) {
+	ctx, span := tracing.Start(ctx, "StatsService.GetFairnessReport")
+	defer span.End()
+
+	teamNames := []string{teamName}
+	if teamName == "" {
+		teamSizes, err := svc.getUserCountGroupedByTeams(ctx)
+		if err != nil {
+			return nil, internal(err, "error occured when getting teams")
+		}
+
+		teamNames = make([]string, 0, len(teamSizes))
+		for _, teamSize := range teamSizes {
+			teamNames = append(teamNames, teamSize.TeamName)
+		}
+		slices.Sort(teamNames)
 	}
 
-	return count, nil
+	report := &dto.FairnessReport{
+		Teams: make([]*dto.TeamFairness, 0, len(teamNames)),
+	}
+	for _, name := range teamNames {
+		teamFairness, errResp := svc.getTeamFairness(ctx, name)
+		if errResp != nil {
+			return nil, errResp
+		}
+		report.Teams = append(report.Teams, teamFairness)
+	}
+
+	return report, nil
 }
 
-func (svc *StatsService) getAssignmentsCountByUsers() ([]*dto.AssignmentsByUser, error) {
-	count, err := (*svc.revsRepo).GetAssignmentsCountByReviewerID(context.Background())
+func (svc *StatsService) getTeamFairness(ctx context.Context, teamName string) (*dto.TeamFairness, error) {
+	members, err := (*svc.userRepo).GetTeamMembers(ctx, teamName)
 	if err != nil {
-		return nil, err
+		return nil, internal(err, "error occured when getting team members")
+	}
+	if len(members) == 0 {
+		return nil, notFound()
 	}
 
-	return count, nil
+	memberIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		memberIDs = append(memberIDs, member.UserID)
+	}
+
+	counts, err := (*svc.revsRepo).GetReviewerAssignmentsCount(ctx, memberIDs)
+	if err != nil {
+		return nil, internal(err, "error occured when getting reviewer assignments count")
+	}
+	countByUser := make(map[string]*dto.ReviewerAssignmentsCount, len(counts))
+	for _, count := range counts {
+		countByUser[count.UserID] = count
+	}
+
+	teamFairness := &dto.TeamFairness{
+		TeamName:           teamName,
+		Members:            make([]*dto.ReviewerLoad, 0, len(members)),
+		OverloadedUserIDs:  make([]string, 0),
+		UnderloadedUserIDs: make([]string, 0),
+	}
+
+	activeCount, activeTotal := 0, 0
+	for _, member := range members {
+		count := countByUser[member.UserID]
+		if count == nil {
+			count = &dto.ReviewerAssignmentsCount{UserID: member.UserID}
+		}
+
+		teamFairness.TotalAssignments += count.TotalAssignments
+		teamFairness.Members = append(teamFairness.Members, &dto.ReviewerLoad{
+			UserID:           member.UserID,
+			Username:         member.Username,
+			IsActive:         member.IsActive,
+			OpenAssignments:  count.OpenAssignments,
+			TotalAssignments: count.TotalAssignments,
+		})
+		if member.IsActive {
+			activeCount++
+			activeTotal += count.TotalAssignments
+		}
+	}
+
+	// Доли рассчитываются среди активных участников: назначения
+	// неактивных не уменьшают долю тех, кто продолжает ревьюить
+	activeLoads := make([]float64, 0, activeCount)
+	for _, load := range teamFairness.Members {
+		if !load.IsActive {
+			load.LoadStatus = dto.LoadInactive
+			continue
+		}
+
+		if activeTotal > 0 {
+			load.Share = float64(load.TotalAssignments) / float64(activeTotal)
+		}
+		load.ExpectedShare = 1 / float64(activeCount)
+		load.LoadStatus = loadStatus(load.Share, load.ExpectedShare)
+		switch load.LoadStatus {
+		case dto.LoadOverloaded:
+			teamFairness.OverloadedUserIDs = append(teamFairness.OverloadedUserIDs, load.UserID)
+		case dto.LoadUnderloaded:
+			teamFairness.UnderloadedUserIDs = append(teamFairness.UnderloadedUserIDs, load.UserID)
+		}
+		activeLoads = append(activeLoads, float64(load.TotalAssignments))
+	}
+	teamFairness.GiniCoefficient = giniCoefficient(activeLoads)
+
+	return teamFairness, nil
+}
+
+// loadStatus сравнивает долю назначений ревьюера с долей при
+// равномерном распределении с учетом допустимого отклонения
+func loadStatus(share, expectedShare float64) dto.LoadStatus {
+	switch {
+	case share > expectedShare*(1+fairnessTolerance):
+		return dto.LoadOverloaded
+	case share < expectedShare*(1-fairnessTolerance):
+		return dto.LoadUnderloaded
+	default:
+		return dto.LoadBalanced
+	}
+}
+
+// giniCoefficient рассчитывает коэффициент Джини для набора значений
+// (0 - если набор пуст или все значения нулевые)
+func giniCoefficient(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	var sum, weightedSum float64
+	for i, v := range sorted {
+		sum += v
+		weightedSum += float64(i+1) * v
+	}
+	if sum == 0 {
+		return 0
+	}
+
+	n := float64(len(sorted))
+	return 2*weightedSum/(n*sum) - (n+1)/n
 }
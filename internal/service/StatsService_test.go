@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+func TestGetStatAppliesFiltersToEverySection(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, nil)
+	env.addTeam(t, "backend", "u1", "u2")
+	env.addTeam(t, "frontend", "u3", "u4", "u5")
+
+	env.createPullRequest(t, "pr-backend", "u1")
+	env.createPullRequest(t, "pr-frontend-1", "u3")
+	merged := env.createPullRequest(t, "pr-frontend-2", "u3")
+	if _, err := env.pullRequests.MergePullRequest(ctx, &dto.MergePullRequest{PullRequestID: merged.PullRequestID}); err != nil {
+		t.Fatalf("MergePullRequest: %v", err)
+	}
+
+	now := time.Now()
+	stat, err := env.stats.GetStat(ctx, &dto.StatsFilter{
+		From:        now.Add(-time.Hour),
+		To:          now.Add(time.Hour),
+		TeamName:    "backend",
+		Granularity: dto.GranularityDay,
+	})
+	if err != nil {
+		t.Fatalf("GetStat: %v", err)
+	}
+
+	if stat.TotalUsersCount != 2 || stat.ActiveUsersCount != 2 || stat.TotalTeamsCount != 1 {
+		t.Errorf("users/active/teams = %d/%d/%d, want 2/2/1",
+			stat.TotalUsersCount, stat.ActiveUsersCount, stat.TotalTeamsCount)
+	}
+	if len(stat.UserCountByTeam) != 1 || stat.UserCountByTeam[0].TeamName != "backend" {
+		t.Errorf("users_count_by_team = %+v, want only backend", stat.UserCountByTeam)
+	}
+	if stat.OpenedPRCount != 1 || stat.MergedPRCount != 0 {
+		t.Errorf("opened/merged = %d/%d, want 1/0", stat.OpenedPRCount, stat.MergedPRCount)
+	}
+	if len(stat.AssignmentsCountByUser) != 1 || stat.AssignmentsCountByUser[0].UserID != "u2" {
+		t.Errorf("assignments_count_by_user = %+v, want only u2", stat.AssignmentsCountByUser)
+	}
+
+	stat, err = env.stats.GetStat(ctx, &dto.StatsFilter{
+		From:        now.Add(time.Hour),
+		To:          now.Add(2 * time.Hour),
+		Granularity: dto.GranularityDay,
+	})
+	if err != nil {
+		t.Fatalf("GetStat: %v", err)
+	}
+	if stat.TotalUsersCount != 5 || stat.TotalTeamsCount != 2 {
+		t.Errorf("users/teams = %d/%d, want the 5/2 snapshot", stat.TotalUsersCount, stat.TotalTeamsCount)
+	}
+	if stat.OpenedPRCount != 0 || stat.MergedPRCount != 0 || len(stat.AssignmentsCountByUser) != 0 {
+		t.Errorf("window without PRs: opened/merged = %d/%d, assignments = %+v",
+			stat.OpenedPRCount, stat.MergedPRCount, stat.AssignmentsCountByUser)
+	}
+}
+
+func TestGetStatUnknownTeam(t *testing.T) {
+	env := newTestEnv(t, nil)
+	now := time.Now()
+
+	_, err := env.stats.GetStat(context.Background(), &dto.StatsFilter{
+		From:     now.Add(-time.Hour),
+		To:       now,
+		TeamName: "missing",
+	})
+	var notFoundErr *NotFoundError
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("GetStat for unknown team: err = %v, want NotFoundError", err)
+	}
+}
+
+func TestGetStatReportsTimeToFirstVerdict(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, nil)
+	env.addTeam(t, "backend", "u1", "u2")
+	env.createPullRequest(t, "pr-reviewed", "u1")
+	env.createPullRequest(t, "pr-pending", "u1")
+
+	for _, verdict := range []entity.Verdict{entity.ChangesRequested, entity.Approved} {
+		_, err := env.pullRequests.SubmitVerdict(ctx, &dto.SubmitVerdict{
+			PullRequestID: "pr-reviewed",
+			UserID:        "u2",
+			Verdict:       verdict,
+		})
+		if err != nil {
+			t.Fatalf("SubmitVerdict(%s): %v", verdict, err)
+		}
+	}
+
+	now := time.Now()
+	stat, err := env.stats.GetStat(ctx, &dto.StatsFilter{
+		From:        now.Add(-time.Hour),
+		To:          now.Add(time.Hour),
+		Granularity: dto.GranularityDay,
+	})
+	if err != nil {
+		t.Fatalf("GetStat: %v", err)
+	}
+
+	firstVerdict := stat.ReviewAnalytics.FirstVerdictTime
+	if firstVerdict == nil || firstVerdict.PullRequestsCount != 1 {
+		t.Fatalf("first_verdict_time = %+v, want one reviewed PR", firstVerdict)
+	}
+	if firstVerdict.MedianSeconds == nil || *firstVerdict.MedianSeconds < 0 {
+		t.Errorf("median_seconds = %v, want a non-negative duration", firstVerdict.MedianSeconds)
+	}
+}
+
+func TestGetFairnessReportCountsOpenAndTotalAssignments(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, nil)
+	env.addTeam(t, "backend", "u1", "u2")
+
+	env.createPullRequest(t, "pr-open", "u1")
+	merged := env.createPullRequest(t, "pr-merged", "u1")
+	if _, err := env.pullRequests.MergePullRequest(ctx, &dto.MergePullRequest{PullRequestID: merged.PullRequestID}); err != nil {
+		t.Fatalf("MergePullRequest: %v", err)
+	}
+
+	report, err := env.stats.GetFairnessReport(ctx, "backend")
+	if err != nil {
+		t.Fatalf("GetFairnessReport: %v", err)
+	}
+	if len(report.Teams) != 1 {
+		t.Fatalf("report has %d teams, want 1", len(report.Teams))
+	}
+
+	loads := make(map[string]*dto.ReviewerLoad)
+	for _, load := range report.Teams[0].Members {
+		loads[load.UserID] = load
+	}
+	if loads["u2"].OpenAssignments != 1 || loads["u2"].TotalAssignments != 2 {
+		t.Errorf("u2 open/total = %d/%d, want 1/2", loads["u2"].OpenAssignments, loads["u2"].TotalAssignments)
+	}
+	if loads["u1"].OpenAssignments != 0 || loads["u1"].TotalAssignments != 0 {
+		t.Errorf("u1 open/total = %d/%d, want 0/0", loads["u1"].OpenAssignments, loads["u1"].TotalAssignments)
+	}
+	if report.Teams[0].TotalAssignments != 2 {
+		t.Errorf("team total = %d, want 2", report.Teams[0].TotalAssignments)
+	}
+}
+
+func TestLoadStatus(t *testing.T) {
+	tests := []struct {
+		share, expectedShare float64
+		want                 dto.LoadStatus
+	}{
+		{share: 0.25, expectedShare: 0.25, want: dto.LoadBalanced},
+		{share: 0.3, expectedShare: 0.25, want: dto.LoadBalanced},
+		{share: 0.2, expectedShare: 0.25, want: dto.LoadBalanced},
+		{share: 0.32, expectedShare: 0.25, want: dto.LoadOverloaded},
+		{share: 0.18, expectedShare: 0.25, want: dto.LoadUnderloaded},
+		{share: 0, expectedShare: 0.5, want: dto.LoadUnderloaded},
+		{share: 1, expectedShare: 1, want: dto.LoadBalanced},
+	}
+
+	for _, tt := range tests {
+		if got := loadStatus(tt.share, tt.expectedShare); got != tt.want {
+			t.Errorf("loadStatus(%v, %v) = %s, want %s", tt.share, tt.expectedShare, got, tt.want)
+		}
+	}
+}
+
+func TestGiniCoefficient(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{name: "empty", values: nil, want: 0},
+		{name: "all zero", values: []float64{0, 0, 0}, want: 0},
+		{name: "equal", values: []float64{3, 3, 3, 3}, want: 0},
+		{name: "all at one of two", values: []float64{0, 10}, want: 0.5},
+		{name: "all at one of four", values: []float64{7, 0, 0, 0}, want: 0.75},
+		{name: "unsorted", values: []float64{3, 1, 2}, want: 2.0 / 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := giniCoefficient(tt.values); math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("giniCoefficient(%v) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFairnessIgnoresAssignmentsOfInactiveMembers(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, nil)
+	env.addTeam(t, "backend", "u1", "u2", "u3", "u4")
+
+	// u4 успел получить большую часть назначений до деактивации
+	assign := func(userID string, count int) {
+		t.Helper()
+		for i := range count {
+			prID := fmt.Sprintf("pr-%s-%d", userID, i)
+			if err := env.revsRepo.CreateAssignment(ctx, userID, prID, entity.InitialAssignment); err != nil {
+				t.Fatalf("CreateAssignment: %v", err)
+			}
+		}
+	}
+	assign("u1", 2)
+	assign("u2", 2)
+	assign("u3", 2)
+	assign("u4", 6)
+	u4, err := env.userRepo.GetUser(ctx, "u4")
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	u4.IsActive = false
+	if err := env.userRepo.UpdateUser(ctx, u4); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+
+	report, err := env.stats.GetFairnessReport(ctx, "backend")
+	if err != nil {
+		t.Fatalf("GetFairnessReport: %v", err)
+	}
+	team := report.Teams[0]
+
+	if team.TotalAssignments != 12 {
+		t.Errorf("total assignments = %d, want 12", team.TotalAssignments)
+	}
+	for _, load := range team.Members {
+		if load.UserID == "u4" {
+			if load.LoadStatus != dto.LoadInactive || load.Share != 0 {
+				t.Errorf("u4 = %+v, want INACTIVE with zero share", load)
+			}
+			continue
+		}
+		if load.LoadStatus != dto.LoadBalanced || math.Abs(load.Share-load.ExpectedShare) > 1e-9 {
+			t.Errorf("%s = %+v, want BALANCED with share equal to expected", load.UserID, load)
+		}
+	}
+	if len(team.UnderloadedUserIDs) != 0 || len(team.OverloadedUserIDs) != 0 || team.GiniCoefficient != 0 {
+		t.Errorf("underloaded = %v, overloaded = %v, gini = %v, want none and 0",
+			team.UnderloadedUserIDs, team.OverloadedUserIDs, team.GiniCoefficient)
+	}
+}
@@ -3,140 +3,345 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
-	"net/http"
+	"log/slog"
 
 	"github.com/salex06/pr-service/internal/converter"
 	"github.com/salex06/pr-service/internal/dto"
 	"github.com/salex06/pr-service/internal/entity"
+	prRepos "github.com/salex06/pr-service/internal/repos/pr"
+	revsRepos "github.com/salex06/pr-service/internal/repos/reviewers"
 	teamRepos "github.com/salex06/pr-service/internal/repos/team"
 	userRepos "github.com/salex06/pr-service/internal/repos/user"
+	"github.com/salex06/pr-service/internal/tracing"
 )
 
 // TeamService представляет компонент, отвечающий за
 // выполнение бизнес-логики, связанной с командами -
 // группами пользователей с уникальным именем
 type TeamService struct {
-	teamRepository *teamRepos.TeamRepository
-	userRepository *userRepos.UserRepository
+	teamRepository         *teamRepos.TeamRepository
+	userRepository         *userRepos.UserRepository
+	assignedRevsRepository *revsRepos.AssignedRevsRepository
+	pullRequestRepository  *prRepos.PullRequestRepository
+	auditService           *AuditService
+	statsCache             CacheInvalidator
 }
 
 // NewTeamService конструирует и возвращает объект TeamService
-func NewTeamService(tr *teamRepos.TeamRepository, ur *userRepos.UserRepository) *TeamService {
+// (sc - кеш статистики, сбрасываемый после изменений; может быть nil)
+func NewTeamService(
+	tr *teamRepos.TeamRepository,
+	ur *userRepos.UserRepository,
+	ar *revsRepos.AssignedRevsRepository,
+	pr *prRepos.PullRequestRepository,
+	as *AuditService,
+	sc CacheInvalidator) *TeamService {
 	return &TeamService{
-		teamRepository: tr,
-		userRepository: ur,
+		teamRepository:         tr,
+		userRepository:         ur,
+		assignedRevsRepository: ar,
+		pullRequestRepository:  pr,
+		auditService:           as,
+		statsCache:             sc,
 	}
 }
 
-// AddTeam выполняет сохранение команды и её представителей
-func (ts *TeamService) AddTeam(req *dto.Team) (*dto.Team, *dto.ErrorResponse) {
+// AddTeam выполняет сохранение команды и её представителей.
+// Участники других команд переводятся, только если req.MoveMembers,
+// иначе команда не создается
+func (ts *TeamService) AddTeam(ctx context.Context, req *dto.Team) (*dto.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.AddTeam")
+	defer span.End()
+
 	teamName := req.TeamName
 
-	if exists, _ := (*ts.teamRepository).TeamExists(context.Background(), teamName); exists {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusBadRequest,
-			Error: map[string]string{
-				"code":    string(dto.TeamExists),
-				"message": fmt.Sprintf("%s already exists", teamName),
-			},
+	exists, err := (*ts.teamRepository).TeamExists(ctx, teamName)
+	if err != nil {
+		return nil, internal(err, "unable check team")
+	}
+	if exists {
+		return nil, conflict(dto.TeamExists, fmt.Sprintf("%s already exists", teamName))
+	}
+	if !req.MoveMembers {
+		if err := ts.checkMembersTeam(ctx, req); err != nil {
+			return nil, err
 		}
 	}
 
 	team := &entity.Team{TeamName: teamName}
-	err := (*ts.teamRepository).SaveTeam(context.Background(), team)
+	err = (*ts.teamRepository).SaveTeam(ctx, team)
 	if err != nil {
-		return nil, &dto.ErrorResponse{
-			Status: http.StatusInternalServerError,
-			Error: map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": fmt.Sprintf("unable save team: %s", err),
-			},
-		}
+		return nil, internal(err, "unable save team")
 	}
 
-	ts.saveMembers(req)
+	updatedMembers := ts.saveMembers(ctx, req)
+	invalidate(ts.statsCache)
 
-	return &dto.Team{
+	resp := &dto.Team{
 		TeamName: team.TeamName,
 		Members:  req.Members,
-	}, nil
+		Version:  team.Version,
+	}
+	ts.auditService.Record(ctx, AuditActionTeamAdd, AuditTargetTeam,
+		teamTargetIDs(team.TeamName, req.Members), updatedMembers, resp)
+
+	return resp, nil
+}
+
+// PutTeam создает команду, если её нет, и создает/обновляет перечисленных
+// участников (участники, не указанные в запросе, остаются в команде).
+// Участники других команд переводятся, только если req.MoveMembers,
+// иначе запрос отклоняется без изменений.
+// Возвращает команду со всеми участниками и признак создания команды
+func (ts *TeamService) PutTeam(ctx context.Context, req *dto.Team) (*dto.Team, bool, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.PutTeam")
+	defer span.End()
+
+	if !req.MoveMembers {
+		if err := ts.checkMembersTeam(ctx, req); err != nil {
+			return nil, false, err
+		}
+	}
+
+	team, err := (*ts.teamRepository).GetTeam(ctx, req.TeamName)
+	if err != nil {
+		return nil, false, internal(err, "unable get team")
+	}
+	exists := team != nil
+	if !exists {
+		if req.IfMatch != "" {
+			return nil, false, conflict(dto.PreconditionFailed, "team does not exist")
+		}
+		team = &entity.Team{TeamName: req.TeamName}
+		if err := (*ts.teamRepository).SaveTeam(ctx, team); err != nil {
+			return nil, false, internal(err, "unable save team")
+		}
+	} else {
+		if req.IfMatch != "" {
+			current, err := (*ts.userRepository).GetTeamMembers(ctx, team.TeamName)
+			if err != nil {
+				return nil, false, internal(err, "unable get team members")
+			}
+			currentTeam := &dto.Team{
+				TeamName: team.TeamName,
+				Members:  converter.ConvertUsersToTeamMembers(current),
+				Version:  team.Version,
+			}
+			if err := checkETag(req.IfMatch, currentTeam.ETag()); err != nil {
+				return nil, false, err
+			}
+		}
+		if err := (*ts.teamRepository).UpdateTeam(ctx, team); err != nil {
+			return nil, false, updateFailed(err, "unable update team")
+		}
+	}
+
+	updatedMembers := ts.saveMembers(ctx, req)
+	invalidate(ts.statsCache)
+
+	members, err := (*ts.userRepository).GetTeamMembers(ctx, req.TeamName)
+	if err != nil {
+		return nil, false, internal(err, "unable get team members")
+	}
+	resp := &dto.Team{
+		TeamName: req.TeamName,
+		Members:  converter.ConvertUsersToTeamMembers(members),
+		Version:  team.Version,
+	}
+
+	action := AuditActionTeamUpdate
+	if !exists {
+		action = AuditActionTeamAdd
+	}
+	ts.auditService.Record(ctx, action, AuditTargetTeam,
+		teamTargetIDs(req.TeamName, req.Members), updatedMembers, resp)
+
+	return resp, !exists, nil
 }
 
-func (ts *TeamService) saveMembers(req *dto.Team) {
+// checkMembersTeam проверяет, что перечисленные в запросе
+// существующие сотрудники уже состоят в команде req.TeamName
+func (ts *TeamService) checkMembersTeam(ctx context.Context, req *dto.Team) error {
+	for _, member := range req.Members {
+		user, err := (*ts.userRepository).GetUser(ctx, member.UserID)
+		if err != nil {
+			return internal(err, "unable get user")
+		}
+
+		if user != nil && user.TeamName != req.TeamName {
+			return conflict(dto.MemberOfOtherTeam,
+				fmt.Sprintf("%s is a member of team %s", user.UserID, user.TeamName))
+		}
+	}
+
+	return nil
+}
+
+// saveMembers сохраняет участников команды и возвращает
+// прежнее состояние уже существовавших пользователей
+func (ts *TeamService) saveMembers(ctx context.Context, req *dto.Team) []*dto.User {
 	teamName := req.TeamName
+	updatedMembers := make([]*dto.User, 0)
 
 	for _, member := range req.Members {
-		if userFromDB, _ := (*ts.userRepository).GetUser(context.Background(), member.UserID); userFromDB != nil {
+		userFromDB, err := (*ts.userRepository).GetUser(ctx, member.UserID)
+		if err != nil {
+			slog.ErrorContext(ctx, "error occured when getting user",
+				"team_name", teamName, "user_id", member.UserID, "error", err)
+			continue
+		}
+
+		if userFromDB != nil {
+			updatedMembers = append(updatedMembers, converter.ConvertUserEntityToDto(userFromDB))
+
 			// WARN: при создании команды для существующего человека обновляются его поля: teamName, username, isActive
 
 			userFromDB.TeamName = teamName
 			userFromDB.Username = member.Username
 			userFromDB.IsActive = member.IsActive
+			if member.Email != nil {
+				userFromDB.Email = member.Email
+			}
 
-			err := (*ts.userRepository).UpdateUser(context.Background(), userFromDB)
+			err := (*ts.userRepository).UpdateUser(ctx, userFromDB)
 			if err != nil {
-				log.Printf("error occured when updating user: %s\n", err)
+				slog.ErrorContext(ctx, "error occured when updating user",
+					"team_name", teamName, "user_id", member.UserID, "error", err)
 			}
 		} else {
-			err := (*ts.userRepository).SaveUser(context.Background(), converter.ConvertTeamMemberToUser(member, teamName))
+			err := (*ts.userRepository).SaveUser(ctx, converter.ConvertTeamMemberToUser(member, teamName))
 			if err != nil {
-				log.Printf("error occured when saving user: %s\n", err)
+				slog.ErrorContext(ctx, "error occured when saving user",
+					"team_name", teamName, "user_id", member.UserID, "error", err)
 			}
 		}
 	}
+
+	return updatedMembers
 }
 
 // GetTeam возвращает объект команды,
 // имеющей идентификатор teamID
-func (ts *TeamService) GetTeam(teamID string) (*dto.Team, *dto.ErrorResponse) {
-	if team, _ := (*ts.teamRepository).GetTeam(context.Background(), teamID); team != nil {
-		members, _ := (*ts.userRepository).GetTeamMembers(context.Background(), team.TeamName)
-		return &dto.Team{
-			TeamName: team.TeamName,
-			Members:  converter.ConvertUsersToTeamMembers(members),
-		}, nil
-	}
-
-	return nil, &dto.ErrorResponse{
-		Status: http.StatusNotFound,
-		Error: map[string]string{
-			"code":    string(dto.NotFound),
-			"message": "resource not found",
-		},
+func (ts *TeamService) GetTeam(ctx context.Context, teamID string) (*dto.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.GetTeam")
+	defer span.End()
+
+	team, err := (*ts.teamRepository).GetTeam(ctx, teamID)
+	if err != nil {
+		return nil, internal(err, "unable get team")
+	}
+	if team == nil {
+		return nil, notFound()
+	}
+
+	members, err := (*ts.userRepository).GetTeamMembers(ctx, team.TeamName)
+	if err != nil {
+		return nil, internal(err, "unable get team members")
 	}
+
+	return &dto.Team{
+		TeamName: team.TeamName,
+		Members:  converter.ConvertUsersToTeamMembers(members),
+		Version:  team.Version,
+	}, nil
 }
 
 // DeactivateAllMembers выполняет перевод в неактивное состояние всех
-// представителей команды с идентификатором teamID
-func (ts *TeamService) DeactivateAllMembers(teamID string) (*dto.Team, *dto.ErrorResponse) {
-	if team, _ := (*ts.teamRepository).GetTeam(context.Background(), teamID); team != nil {
-		members, _ := (*ts.userRepository).GetTeamMembers(context.Background(), team.TeamName)
-
-		for _, v := range members {
-			v.IsActive = false
-			err := (*ts.userRepository).UpdateUser(context.Background(), v)
-			if err != nil {
-				return nil, &dto.ErrorResponse{
-					Status: http.StatusInternalServerError,
-					Error: map[string]string{
-						"code":    "INTERNAL_ERROR",
-						"message": "error occured when updating user",
-					},
-				}
-			}
+// представителей команды с идентификатором teamID и снимает их с открытых
+// PR's (причина снятия - deactivation). Непустой ifMatch
+// сравнивается с ETag команды до изменения
+func (ts *TeamService) DeactivateAllMembers(ctx context.Context, teamID string, ifMatch string) (*dto.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.DeactivateAllMembers")
+	defer span.End()
+
+	team, err := (*ts.teamRepository).GetTeam(ctx, teamID)
+	if err != nil {
+		return nil, internal(err, "unable get team")
+	}
+	if team == nil {
+		return nil, notFound()
+	}
+
+	members, err := (*ts.userRepository).GetTeamMembers(ctx, team.TeamName)
+	if err != nil {
+		return nil, internal(err, "unable get team members")
+	}
+	before := &dto.Team{
+		TeamName: team.TeamName,
+		Members:  converter.ConvertUsersToTeamMembers(members),
+		Version:  team.Version,
+	}
+	if err := checkETag(ifMatch, before.ETag()); err != nil {
+		return nil, err
+	}
+	if err := (*ts.teamRepository).UpdateTeam(ctx, team); err != nil {
+		return nil, updateFailed(err, "unable update team")
+	}
+
+	for _, v := range members {
+		v.IsActive = false
+		err := (*ts.userRepository).UpdateUser(ctx, v)
+		if err != nil {
+			return nil, updateFailed(err, "error occured when updating user")
+		}
+		err = closeOpenAssignments(ctx, ts.assignedRevsRepository, ts.pullRequestRepository, v.UserID)
+		if err != nil {
+			return nil, internal(err, "unable close assignments")
 		}
-		return &dto.Team{
-			TeamName: team.TeamName,
-			Members:  converter.ConvertUsersToTeamMembers(members),
-		}, nil
+	}
+	invalidate(ts.statsCache)
+	after := &dto.Team{
+		TeamName: team.TeamName,
+		Members:  converter.ConvertUsersToTeamMembers(members),
+		Version:  team.Version,
+	}
+	ts.auditService.Record(ctx, AuditActionTeamDeactivateAll, AuditTargetTeam,
+		teamTargetIDs(team.TeamName, after.Members), before, after)
+
+	return after, nil
+}
+
+// MoveMember переводит сотрудника в другую (существующую) команду
+func (ts *TeamService) MoveMember(ctx context.Context, req *dto.MoveTeamMember) (*dto.User, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.MoveMember")
+	defer span.End()
+
+	team, err := (*ts.teamRepository).GetTeam(ctx, req.TeamName)
+	if err != nil {
+		return nil, internal(err, "unable get team")
+	}
+	user, err := (*ts.userRepository).GetUser(ctx, req.UserID)
+	if err != nil {
+		return nil, internal(err, "unable get user")
+	}
+	if team == nil || user == nil {
+		return nil, notFound()
 	}
 
-	return nil, &dto.ErrorResponse{
-		Status: http.StatusNotFound,
-		Error: map[string]string{
-			"code":    string(dto.NotFound),
-			"message": "resource not found",
-		},
+	if err := checkETag(req.IfMatch, dto.ETag(user.Version)); err != nil {
+		return nil, err
 	}
+
+	before := converter.ConvertUserEntityToDto(user)
+	user.TeamName = team.TeamName
+	if err := (*ts.userRepository).UpdateUser(ctx, user); err != nil {
+		return nil, updateFailed(err, "unable update user")
+	}
+	invalidate(ts.statsCache)
+
+	after := converter.ConvertUserEntityToDto(user)
+	ts.auditService.Record(ctx, AuditActionTeamMoveMember, AuditTargetUser,
+		[]string{user.UserID, before.TeamName, team.TeamName}, before, after)
+
+	return after, nil
+}
+
+func teamTargetIDs(teamName string, members []*dto.TeamMember) []string {
+	ids := make([]string, 0, len(members)+1)
+	ids = append(ids, teamName)
+	for _, member := range members {
+		ids = append(ids, member.UserID)
+	}
+
+	return ids
 }
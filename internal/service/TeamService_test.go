@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
+)
+
+func TestDeactivateAllMembersClosesOpenAssignments(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, nil)
+	env.addTeam(t, "backend", "u1", "u2", "u3")
+
+	open := env.createPullRequest(t, "pr-open", "u1")
+	merged := env.createPullRequest(t, "pr-merged", "u1")
+	if _, err := env.pullRequests.MergePullRequest(ctx, &dto.MergePullRequest{PullRequestID: merged.PullRequestID}); err != nil {
+		t.Fatalf("MergePullRequest: %v", err)
+	}
+
+	if _, err := env.teams.DeactivateAllMembers(ctx, "backend", ""); err != nil {
+		t.Fatalf("DeactivateAllMembers: %v", err)
+	}
+
+	history, err := env.revsRepo.GetAssignmentHistory(ctx, open.PullRequestID)
+	if err != nil {
+		t.Fatalf("GetAssignmentHistory: %v", err)
+	}
+	if len(history) != len(open.AssignedReviewers) || len(history) == 0 {
+		t.Fatalf("history of %s has %d records, want %d", open.PullRequestID, len(history), len(open.AssignedReviewers))
+	}
+	for _, assignment := range history {
+		if assignment.IsActive() {
+			t.Errorf("assignment of %s on %s is still active", assignment.UserID, open.PullRequestID)
+			continue
+		}
+		if assignment.UnassignReason == nil || *assignment.UnassignReason != entity.DeactivationAssignment {
+			t.Errorf("assignment of %s closed with reason %v, want %s",
+				assignment.UserID, assignment.UnassignReason, entity.DeactivationAssignment)
+		}
+	}
+
+	reviewers, err := env.revsRepo.GetAssignedReviewersIds(ctx, merged.PullRequestID)
+	if err != nil {
+		t.Fatalf("GetAssignedReviewersIds: %v", err)
+	}
+	if len(reviewers) != len(merged.AssignedReviewers) {
+		t.Errorf("merged PR has %d active reviewers, want %d kept", len(reviewers), len(merged.AssignedReviewers))
+	}
+}
+
+func TestSetIsActiveFalseClosesOpenAssignments(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, nil)
+	env.addTeam(t, "backend", "u1", "u2")
+	pr := env.createPullRequest(t, "pr-1", "u1")
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u2" {
+		t.Fatalf("assigned reviewers = %v, want [u2]", pr.AssignedReviewers)
+	}
+
+	if _, err := env.users.SetIsActive(ctx, &dto.UserShort{UserID: "u2", IsActive: false}); err != nil {
+		t.Fatalf("SetIsActive: %v", err)
+	}
+
+	got, err := env.pullRequests.GetPullRequest(ctx, pr.PullRequestID)
+	if err != nil {
+		t.Fatalf("GetPullRequest: %v", err)
+	}
+	if len(got.AssignedReviewers) != 0 {
+		t.Errorf("assigned reviewers after deactivation = %v, want none", got.AssignedReviewers)
+	}
+}
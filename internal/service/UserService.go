@@ -4,14 +4,15 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"net/http"
+	"slices"
 
 	"github.com/salex06/pr-service/internal/converter"
 	"github.com/salex06/pr-service/internal/dto"
+	"github.com/salex06/pr-service/internal/entity"
 	prRepos "github.com/salex06/pr-service/internal/repos/pr"
 	revsRepos "github.com/salex06/pr-service/internal/repos/reviewers"
 	userRepos "github.com/salex06/pr-service/internal/repos/user"
+	"github.com/salex06/pr-service/internal/tracing"
 )
 
 // UserService представляет компонент,
@@ -21,63 +22,171 @@ type UserService struct {
 	userRepository         *userRepos.UserRepository
 	assignedRevsRepository *revsRepos.AssignedRevsRepository
 	pullRequestRepository  *prRepos.PullRequestRepository
+	auditService           *AuditService
+	statsCache             CacheInvalidator
 }
 
 // NewUserService конструирует и возвращает объект структуры UserService
+// (sc - кеш статистики, сбрасываемый после изменений; может быть nil)
 func NewUserService(
 	ur *userRepos.UserRepository,
 	ar *revsRepos.AssignedRevsRepository,
-	pr *prRepos.PullRequestRepository) *UserService {
+	pr *prRepos.PullRequestRepository,
+	as *AuditService,
+	sc CacheInvalidator) *UserService {
 	return &UserService{
 		userRepository:         ur,
 		assignedRevsRepository: ar,
 		pullRequestRepository:  pr,
+		auditService:           as,
+		statsCache:             sc,
 	}
 }
 
-// SetIsActive изменяет состояние сотрудника (активен или нет)
-func (us *UserService) SetIsActive(req *dto.UserShort) (*dto.User, *dto.ErrorResponse) {
-	if user, _ := (*us.userRepository).GetUser(context.Background(), req.UserID); user != nil {
-		user.IsActive = req.IsActive
-		err := (*us.userRepository).UpdateUser(context.Background(), user)
-		if err != nil {
-			return nil, &dto.ErrorResponse{
-				Status: http.StatusInternalServerError,
-				Error: map[string]string{
-					"code":    "INTERNAL_ERROR",
-					"message": fmt.Sprintf("unable update user: %s", err),
-				},
-			}
-		}
+// GetUser возвращает сотрудника с идентификатором userID
+func (us *UserService) GetUser(ctx context.Context, userID string) (*dto.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUser")
+	defer span.End()
+
+	user, err := (*us.userRepository).GetUser(ctx, userID)
+	if err != nil {
+		return nil, internal(err, "unable get user")
+	}
+	if user == nil {
+		return nil, notFound()
+	}
+
+	return converter.ConvertUserEntityToDto(user), nil
+}
+
+// SetIsActive изменяет состояние сотрудника (активен или нет); деактивированный
+// сотрудник снимается с открытых PR's (причина снятия - deactivation)
+func (us *UserService) SetIsActive(ctx context.Context, req *dto.UserShort) (*dto.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetIsActive")
+	defer span.End()
+
+	user, err := (*us.userRepository).GetUser(ctx, req.UserID)
+	if err != nil {
+		return nil, internal(err, "unable get user")
+	}
+	if user == nil {
+		return nil, notFound()
+	}
 
-		return converter.ConvertUserEntityToDto(user), nil
+	if err := checkETag(req.IfMatch, dto.ETag(user.Version)); err != nil {
+		return nil, err
 	}
 
-	return nil, &dto.ErrorResponse{
-		Status: http.StatusNotFound,
-		Error: map[string]string{
-			"code":    string(dto.NotFound),
-			"message": "resource not found",
-		},
+	before := converter.ConvertUserEntityToDto(user)
+	user.IsActive = req.IsActive
+	err = (*us.userRepository).UpdateUser(ctx, user)
+	if err != nil {
+		return nil, updateFailed(err, "unable update user")
 	}
+	invalidate(us.statsCache)
+
+	if before.IsActive && !user.IsActive {
+		err := closeOpenAssignments(ctx, us.assignedRevsRepository, us.pullRequestRepository, user.UserID)
+		if err != nil {
+			return nil, internal(err, "unable close assignments")
+		}
+	}
+
+	after := converter.ConvertUserEntityToDto(user)
+	us.auditService.Record(ctx, AuditActionUserSetIsActive, AuditTargetUser, []string{user.UserID}, before, after)
+
+	return after, nil
 }
 
 // GetAssignedPRs возвращает пулл-реквесты,
 // на которые назначен сотрудник с идентификатором userID
-func (us *UserService) GetAssignedPRs(userID string) (*dto.AssignedPullRequests, *dto.ErrorResponse) {
-	if exists, _ := (*us.userRepository).UserExists(context.Background(), userID); exists {
-		prIds, _ := (*us.assignedRevsRepository).GetAssignedPullRequestIds(context.Background(), userID)
-		prs, _ := (*us.pullRequestRepository).GetPullRequests(context.Background(), prIds)
+func (us *UserService) GetAssignedPRs(ctx context.Context, userID string) (*dto.AssignedPullRequests, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetAssignedPRs")
+	defer span.End()
 
-		return converter.ConvertPRsToAssignedPRs(userID, prs), nil
+	exists, err := (*us.userRepository).UserExists(ctx, userID)
+	if err != nil {
+		return nil, internal(err, "unable check user")
+	}
+	if !exists {
+		// В API не прописана данная ветка
+		return nil, notFound()
 	}
 
-	// В API не прописана данная ветка
-	return nil, &dto.ErrorResponse{
-		Status: http.StatusNotFound,
-		Error: map[string]string{
-			"code":    string(dto.NotFound),
-			"message": "resource not found",
-		},
+	prs, err := us.getAssignedPullRequests(ctx, userID)
+	if err != nil {
+		return nil, internal(err, "unable get assigned pull requests")
+	}
+
+	return converter.ConvertPRsToAssignedPRs(userID, prs), nil
+}
+
+// SetDigestOptOut изменяет признак отказа сотрудника от рассылки дайджеста
+func (us *UserService) SetDigestOptOut(ctx context.Context, req *dto.DigestSubscription) (*dto.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetDigestOptOut")
+	defer span.End()
+
+	user, err := (*us.userRepository).GetUser(ctx, req.UserID)
+	if err != nil {
+		return nil, internal(err, "unable get user")
 	}
+	if user == nil {
+		return nil, notFound()
+	}
+
+	if err := checkETag(req.IfMatch, dto.ETag(user.Version)); err != nil {
+		return nil, err
+	}
+
+	before := &dto.DigestSubscription{UserID: user.UserID, DigestOptOut: user.DigestOptOut}
+	user.DigestOptOut = req.DigestOptOut
+	err = (*us.userRepository).UpdateUser(ctx, user)
+	if err != nil {
+		return nil, updateFailed(err, "unable update user")
+	}
+
+	us.auditService.Record(ctx, AuditActionUserSetDigestOptOut, AuditTargetUser, []string{user.UserID},
+		before, &dto.DigestSubscription{UserID: user.UserID, DigestOptOut: user.DigestOptOut})
+
+	return converter.ConvertUserEntityToDto(user), nil
+}
+
+func (us *UserService) getAssignedPullRequests(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
+	prIds, err := (*us.assignedRevsRepository).GetAssignedPullRequestIds(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*us.pullRequestRepository).GetPullRequests(ctx, prIds)
+}
+
+// closeOpenAssignments снимает деактивированного сотрудника со всех открытых PR's,
+// на которые он назначен (назначения на слитые PR's остаются в истории без изменений)
+func closeOpenAssignments(
+	ctx context.Context,
+	revsRepo *revsRepos.AssignedRevsRepository,
+	prRepo *prRepos.PullRequestRepository,
+	userID string,
+) error {
+	prIds, err := (*revsRepo).GetAssignedPullRequestIds(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	prs, err := (*prRepo).GetPullRequests(ctx, slices.Clone(prIds))
+	if err != nil {
+		return err
+	}
+
+	for _, pr := range prs {
+		if pr == nil || pr.Status != entity.OPEN {
+			continue
+		}
+		err := (*revsRepo).CloseAssignment(ctx, userID, pr.PullRequestID, entity.DeactivationAssignment, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
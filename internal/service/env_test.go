@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/salex06/pr-service/internal/dto"
+	auditRepos "github.com/salex06/pr-service/internal/repos/audit"
+	batchRepos "github.com/salex06/pr-service/internal/repos/batch"
+	prRepos "github.com/salex06/pr-service/internal/repos/pr"
+	revsRepos "github.com/salex06/pr-service/internal/repos/reviewers"
+	teamRepos "github.com/salex06/pr-service/internal/repos/team"
+	userRepos "github.com/salex06/pr-service/internal/repos/user"
+)
+
+// testEnv связывает сервисы с in-memory репозиториями
+type testEnv struct {
+	teamRepo  teamRepos.TeamRepository
+	userRepo  userRepos.UserRepository
+	prRepo    prRepos.PullRequestRepository
+	revsRepo  revsRepos.AssignedRevsRepository
+	batchRepo batchRepos.PullRequestBatchRepository
+	auditRepo auditRepos.AuditRepository
+
+	teams        *TeamService
+	users        *UserService
+	pullRequests *PullRequestService
+	stats        *StatsService
+}
+
+func newTestEnv(t *testing.T, statsCache CacheInvalidator) *testEnv {
+	t.Helper()
+
+	prRepo := prRepos.NewInMemoryPullRequestRepository()
+	revsRepo := revsRepos.NewInMemoryAssignedRevsRepository(prRepo)
+	env := &testEnv{
+		teamRepo:  teamRepos.NewInMemoryTeamRepository(),
+		userRepo:  userRepos.NewInMemoryUserRepository(),
+		prRepo:    prRepo,
+		revsRepo:  revsRepo,
+		batchRepo: batchRepos.NewInMemoryPullRequestBatchRepository(prRepo, revsRepo),
+		auditRepo: auditRepos.NewInMemoryAuditRepository(),
+	}
+	audit := NewAuditService(&env.auditRepo)
+	env.teams = NewTeamService(&env.teamRepo, &env.userRepo, &env.revsRepo, &env.prRepo, audit, statsCache)
+	env.users = NewUserService(&env.userRepo, &env.revsRepo, &env.prRepo, audit, statsCache)
+	env.pullRequests = NewPullRequestService(&env.prRepo, &env.revsRepo, &env.userRepo, &env.teamRepo, &env.batchRepo,
+		audit, NewAssignmentEventBroker(), statsCache)
+	env.stats = NewStatsService(&env.prRepo, &env.revsRepo, &env.userRepo, &env.teamRepo)
+
+	return env
+}
+
+// addTeam создает команду из активных сотрудников с заданными идентификаторами
+func (env *testEnv) addTeam(t *testing.T, teamName string, userIDs ...string) {
+	t.Helper()
+
+	members := make([]*dto.TeamMember, 0, len(userIDs))
+	for _, userID := range userIDs {
+		members = append(members, &dto.TeamMember{UserID: userID, Username: userID, IsActive: true})
+	}
+	if _, err := env.teams.AddTeam(context.Background(), &dto.Team{TeamName: teamName, Members: members}); err != nil {
+		t.Fatalf("AddTeam(%s): %v", teamName, err)
+	}
+}
+
+func (env *testEnv) createPullRequest(t *testing.T, prID, authorID string) *dto.PullRequest {
+	t.Helper()
+
+	pr, err := env.pullRequests.CreatePullRequest(context.Background(), &dto.CreatePullRequest{
+		PullRequestID:   prID,
+		PullRequestName: prID,
+		AuthorID:        authorID,
+	})
+	if err != nil {
+		t.Fatalf("CreatePullRequest(%s): %v", prID, err)
+	}
+
+	return pr
+}
@@ -0,0 +1,31 @@
+// Package tracing - пакет, отвечающий за настройку трассировки
+// OpenTelemetry и создание спанов в слоях приложения
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName - имя, под которым приложение создает спаны
+const instrumentationName = "github.com/salex06/pr-service"
+
+// Start создает дочерний (по отношению к спану из контекста) спан
+// с именем name и атрибутами attrs и возвращает контекст, содержащий его
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// TraceID возвращает идентификатор трассировки из контекста
+// (пустая строка - если контекст не содержит сэмплируемого спана)
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsSampled() {
+		return ""
+	}
+
+	return spanContext.TraceID().String()
+}
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS api_keys(
+    id BIGSERIAL PRIMARY KEY,
+    key_id VARCHAR(32) UNIQUE NOT NULL,
+    name VARCHAR(128) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    rotated_from VARCHAR(32)
+);
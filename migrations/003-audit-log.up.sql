@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS audit_log(
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    target_type VARCHAR(32) NOT NULL,
+    target_ids TEXT[] NOT NULL,
+    before_state JSON,
+    after_state JSON,
+    request_id VARCHAR(64),
+    prev_hash VARCHAR(64) NOT NULL,
+    hash VARCHAR(64) UNIQUE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_occurred_at_idx ON audit_log(occurred_at);
+CREATE INDEX IF NOT EXISTS audit_log_actor_idx ON audit_log(actor);
+CREATE INDEX IF NOT EXISTS audit_log_target_ids_idx ON audit_log USING GIN(target_ids);
+
+CREATE OR REPLACE RULE audit_log_no_update AS ON UPDATE TO audit_log DO INSTEAD NOTHING;
+CREATE OR REPLACE RULE audit_log_no_delete AS ON DELETE TO audit_log DO INSTEAD NOTHING;
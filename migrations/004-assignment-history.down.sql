@@ -0,0 +1,14 @@
+DELETE FROM assigned_reviewers WHERE unassigned_at IS NOT NULL;
+
+DROP INDEX IF EXISTS assigned_reviewers_pull_request_idx;
+DROP INDEX IF EXISTS assigned_reviewers_active_idx;
+
+ALTER TABLE assigned_reviewers DROP CONSTRAINT IF EXISTS assigned_reviewers_pkey;
+ALTER TABLE assigned_reviewers ADD CONSTRAINT assigned_reviewers_pkey PRIMARY KEY (user_id, pull_request_id);
+
+ALTER TABLE assigned_reviewers DROP COLUMN IF EXISTS replaced_by;
+ALTER TABLE assigned_reviewers DROP COLUMN IF EXISTS unassign_reason;
+ALTER TABLE assigned_reviewers DROP COLUMN IF EXISTS reason;
+ALTER TABLE assigned_reviewers DROP COLUMN IF EXISTS unassigned_at;
+ALTER TABLE assigned_reviewers DROP COLUMN IF EXISTS assigned_at;
+ALTER TABLE assigned_reviewers DROP COLUMN IF EXISTS id;
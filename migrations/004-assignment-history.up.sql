@@ -0,0 +1,14 @@
+ALTER TABLE assigned_reviewers ADD COLUMN IF NOT EXISTS id BIGSERIAL;
+ALTER TABLE assigned_reviewers ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE assigned_reviewers ADD COLUMN IF NOT EXISTS unassigned_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE assigned_reviewers ADD COLUMN IF NOT EXISTS reason VARCHAR(16) NOT NULL DEFAULT 'initial';
+ALTER TABLE assigned_reviewers ADD COLUMN IF NOT EXISTS unassign_reason VARCHAR(16);
+ALTER TABLE assigned_reviewers ADD COLUMN IF NOT EXISTS replaced_by VARCHAR(255) REFERENCES users(user_id);
+
+ALTER TABLE assigned_reviewers DROP CONSTRAINT IF EXISTS assigned_reviewers_pkey;
+ALTER TABLE assigned_reviewers ADD CONSTRAINT assigned_reviewers_pkey PRIMARY KEY (id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS assigned_reviewers_active_idx
+    ON assigned_reviewers(user_id, pull_request_id)
+    WHERE unassigned_at IS NULL;
+CREATE INDEX IF NOT EXISTS assigned_reviewers_pull_request_idx ON assigned_reviewers(pull_request_id);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS pull_requests_labels_idx;
+DROP INDEX IF EXISTS pull_requests_repository_idx;
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS lines_changed;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS labels;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS url;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS target_branch;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS source_branch;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS repository;
@@ -0,0 +1,9 @@
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS repository VARCHAR(255);
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS source_branch VARCHAR(255);
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS target_branch VARCHAR(255);
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS url TEXT;
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS labels TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS lines_changed INTEGER;
+
+CREATE INDEX IF NOT EXISTS pull_requests_repository_idx ON pull_requests(repository);
+CREATE INDEX IF NOT EXISTS pull_requests_labels_idx ON pull_requests USING GIN (labels);
@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS digest_runs(
+    period_start TIMESTAMP WITH TIME ZONE PRIMARY KEY,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS review_verdicts(
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id),
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id),
+    verdict VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS review_verdicts_pull_request_idx ON review_verdicts(pull_request_id, created_at);
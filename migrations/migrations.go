@@ -0,0 +1,11 @@
+// Package migrations - пакет со встроенными в бинарный файл SQL-миграциями
+// схемы БД. Миграция состоит из пары файлов <версия>-<название>.up.sql
+// и <версия>-<название>.down.sql
+package migrations
+
+import "embed"
+
+// FS содержит SQL-миграции схемы БД
+//
+//go:embed *.sql
+var FS embed.FS